@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/commands"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
 )
 
 var (
@@ -21,12 +24,24 @@ var rootCmd = &cobra.Command{
 
 Oferece compilação automática, templates customizáveis e
 um ambiente de desenvolvimento isolado e reproduzível.`,
-	Version: "2.0.0",
+}
+
+// SetVersion define a versão exibida por --version e por 'ltx status'
+func SetVersion(version, buildTime string) {
+	rootCmd.Version = version
+	commands.Version = version
+	commands.BuildTime = buildTime
 }
 
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Fprintln(os.Stderr, err)
+
+		// Propagar o código de saída do comando executado no ambiente (ex: latexmk)
+		var exitErr *commands.ExitError
+		if errors.As(err, &exitErr) && exitErr.Code > 0 {
+			os.Exit(exitErr.Code)
+		}
 		os.Exit(1)
 	}
 }
@@ -50,17 +65,31 @@ func init() {
 	rootCmd.AddCommand(commands.ResetCmd)
 	rootCmd.AddCommand(commands.TemplateCmd)
 	rootCmd.AddCommand(commands.BackupCmd)
+	rootCmd.AddCommand(commands.ImageCmd)
+	rootCmd.AddCommand(commands.LockCmd)
+	rootCmd.AddCommand(commands.EnvCmd)
+	rootCmd.AddCommand(commands.DoctorCmd)
+	rootCmd.AddCommand(commands.CountCmd)
+	rootCmd.AddCommand(commands.GraphCmd)
+	rootCmd.AddCommand(commands.LintCmd)
+	rootCmd.AddCommand(commands.BibCmd)
+	rootCmd.AddCommand(commands.LatexmkrcCmd)
+	rootCmd.AddCommand(commands.HistoryCmd)
 }
 
 func initConfig() {
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
-		viper.AddConfigPath("./config")
-		viper.SetConfigName("latex-cli")
-		viper.SetConfigType("conf")
+	if cfgFile == "" {
+		cfgFile = filepath.Join("config", "latex-cli.conf")
+	}
+
+	viper.SetConfigFile(cfgFile)
+	if filepath.Ext(cfgFile) == ".conf" {
+		// Formato KEY="valor", compartilhado com os scripts shell
+		viper.SetConfigType("env")
 	}
 
+	// Valores padrão fazem parte da configuração efetiva (build, status, doctor)
+	config.SetDefaults()
 	viper.AutomaticEnv()
 
 	if err := viper.ReadInConfig(); err == nil {
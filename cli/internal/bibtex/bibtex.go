@@ -0,0 +1,486 @@
+// Package bibtex lê, formata e converte bancos de referências BibTeX/BibLaTeX
+// (.bib): entradas com seus campos, @string, @preamble e @comment.
+package bibtex
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// PartKind é o tipo de um trecho de um valor de campo
+type PartKind int
+
+const (
+	Braced PartKind = iota // {texto}
+	Quoted                 // "texto"
+	Number                 // 2020
+	Macro                  // jan, ieee (definido por @string)
+)
+
+// Part é um trecho de um valor; valores podem ser concatenados com #
+type Part struct {
+	Kind PartKind
+	Text string // sem os delimitadores
+}
+
+// Value é o valor de um campo como escrito no arquivo
+type Value []Part
+
+// String retorna o valor na sintaxe do BibTeX
+func (v Value) String() string {
+	parts := make([]string, len(v))
+	for i, part := range v {
+		switch part.Kind {
+		case Braced:
+			parts[i] = "{" + part.Text + "}"
+		case Quoted:
+			parts[i] = `"` + part.Text + `"`
+		default:
+			parts[i] = part.Text
+		}
+	}
+	return strings.Join(parts, " # ")
+}
+
+// Field é um campo de uma entrada (ou a definição de um @string)
+type Field struct {
+	Name  string // em minúsculas
+	Value Value
+	Text  string // valor com as macros expandidas e sem os delimitadores externos
+	Line  int
+}
+
+// Entry é uma referência do banco
+type Entry struct {
+	Type   string // em minúsculas: article, book, inproceedings...
+	Key    string
+	Fields []Field
+	File   string
+	Line   int
+}
+
+// Field retorna o campo com o nome informado
+func (e *Entry) Field(name string) (Field, bool) {
+	name = strings.ToLower(name)
+	for _, f := range e.Fields {
+		if f.Name == name {
+			return f, true
+		}
+	}
+	return Field{}, false
+}
+
+// Get retorna o valor expandido de um campo, ou "" se não houver
+func (e *Entry) Get(name string) string {
+	f, _ := e.Field(name)
+	return strings.TrimSpace(f.Text)
+}
+
+// Has indica se a entrada tem o campo, com valor não vazio
+func (e *Entry) Has(name string) bool {
+	return e.Get(name) != ""
+}
+
+// SyntaxError é um erro de sintaxe no arquivo; a entrada com erro é ignorada
+// e a leitura continua na seguinte
+type SyntaxError struct {
+	File    string
+	Line    int
+	Message string
+}
+
+func (e *SyntaxError) Error() string {
+	if e.File == "" {
+		return fmt.Sprintf("linha %d: %s", e.Line, e.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s", e.File, e.Line, e.Message)
+}
+
+// Database é o conteúdo de um ou mais arquivos .bib
+type Database struct {
+	Entries   []*Entry
+	Strings   []Field // @string, na ordem em que aparecem
+	Preambles []Value
+	Comments  []string // conteúdo dos @comment
+	Errors    []*SyntaxError
+}
+
+// Find retorna a entrada com a chave informada (sem diferenciar maiúsculas,
+// como o BibTeX)
+func (db *Database) Find(key string) *Entry {
+	for _, e := range db.Entries {
+		if strings.EqualFold(e.Key, key) {
+			return e
+		}
+	}
+	return nil
+}
+
+// months são as macros predefinidas do BibTeX
+var months = map[string]string{
+	"jan": "January", "feb": "February", "mar": "March", "apr": "April",
+	"may": "May", "jun": "June", "jul": "July", "aug": "August",
+	"sep": "September", "oct": "October", "nov": "November", "dec": "December",
+}
+
+// ParseFile lê um arquivo .bib
+func ParseFile(path string) (*Database, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	db := Parse(string(data))
+	for _, e := range db.Entries {
+		e.File = path
+	}
+	for _, e := range db.Errors {
+		e.File = path
+	}
+	return db, nil
+}
+
+// Parse lê o conteúdo de um arquivo .bib. Texto fora de entradas é
+// comentário para o BibTeX e é ignorado; "@" dentro de campos (e-mails, URLs)
+// não inicia entrada. Erros de sintaxe ficam em Database.Errors.
+func Parse(src string) *Database {
+	p := &parser{src: src, db: &Database{}, macros: make(map[string]string)}
+	for i, c := range src {
+		if c == '\n' {
+			p.newlines = append(p.newlines, i)
+		}
+	}
+
+	for p.pos < len(src) {
+		at := strings.IndexByte(src[p.pos:], '@')
+		if at < 0 {
+			break
+		}
+		p.pos += at
+		p.entry()
+	}
+	return p.db
+}
+
+type parser struct {
+	src      string
+	pos      int
+	newlines []int // posições das quebras de linha
+	db       *Database
+	macros   map[string]string
+}
+
+// line retorna a linha (1-based) de uma posição
+func (p *parser) line(pos int) int {
+	return sort.SearchInts(p.newlines, pos) + 1
+}
+
+func (p *parser) fail(pos int, format string, args ...interface{}) {
+	p.db.Errors = append(p.db.Errors, &SyntaxError{Line: p.line(pos), Message: fmt.Sprintf(format, args...)})
+}
+
+// entry lê um bloco iniciado por @ em p.pos
+func (p *parser) entry() {
+	start := p.pos
+	p.pos++
+	entryType := strings.ToLower(p.identifier())
+
+	p.skipSpace()
+	if entryType == "" || p.pos >= len(p.src) || (p.src[p.pos] != '{' && p.src[p.pos] != '(') {
+		// "@" solto no texto entre entradas: comentário
+		p.pos = start + 1
+		return
+	}
+
+	open := p.pos
+	closeChar := byte('}')
+	if p.src[open] == '(' {
+		closeChar = ')'
+	}
+	p.pos++
+
+	var ok bool
+	switch entryType {
+	case "comment":
+		end := matchEntry(p.src, open)
+		p.db.Comments = append(p.db.Comments, p.src[open+1:min(end, len(p.src))])
+		p.pos = end
+		ok = true
+	case "preamble":
+		ok = p.preamble(closeChar)
+	case "string":
+		ok = p.stringDef(closeChar)
+	default:
+		ok = p.regular(entryType, start, closeChar)
+	}
+
+	if !ok {
+		// A leitura continua do ponto do erro, a partir do próximo @
+		return
+	}
+	p.pos++
+}
+
+func (p *parser) preamble(closeChar byte) bool {
+	value, _, ok := p.value()
+	if !ok {
+		return false
+	}
+	p.skipSpace()
+	if !p.expect(closeChar) {
+		return false
+	}
+	p.pos--
+	p.db.Preambles = append(p.db.Preambles, value)
+	return true
+}
+
+func (p *parser) stringDef(closeChar byte) bool {
+	p.skipSpace()
+	line := p.line(p.pos)
+	name := strings.ToLower(p.fieldName())
+	if name == "" {
+		p.fail(p.pos, "@string sem nome")
+		return false
+	}
+	p.skipSpace()
+	if !p.expect('=') {
+		return false
+	}
+
+	value, text, ok := p.value()
+	if !ok {
+		return false
+	}
+	p.skipSpace()
+	if !p.expect(closeChar) {
+		return false
+	}
+	p.pos--
+
+	p.macros[name] = text
+	p.db.Strings = append(p.db.Strings, Field{Name: name, Value: value, Text: text, Line: line})
+	return true
+}
+
+func (p *parser) regular(entryType string, start int, closeChar byte) bool {
+	entry := &Entry{Type: entryType, Line: p.line(start)}
+
+	p.skipSpace()
+	keyStart := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(", \t\r\n", rune(p.src[p.pos])) && p.src[p.pos] != closeChar {
+		p.pos++
+	}
+	entry.Key = p.src[keyStart:p.pos]
+	if entry.Key == "" {
+		p.fail(start, "@%s sem chave", entryType)
+		return false
+	}
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			p.fail(start, "@%s{%s: entrada não fechada", entryType, entry.Key)
+			return false
+		}
+		if p.src[p.pos] == closeChar {
+			break
+		}
+		if !p.expect(',') {
+			return false
+		}
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == closeChar {
+			break // vírgula final
+		}
+
+		line := p.line(p.pos)
+		name := strings.ToLower(p.fieldName())
+		if name == "" {
+			p.fail(p.pos, "nome de campo esperado em %s", entry.Key)
+			return false
+		}
+		p.skipSpace()
+		if !p.expect('=') {
+			return false
+		}
+		value, text, ok := p.value()
+		if !ok {
+			return false
+		}
+		entry.Fields = append(entry.Fields, Field{Name: name, Value: value, Text: text, Line: line})
+	}
+
+	p.db.Entries = append(p.db.Entries, entry)
+	return true
+}
+
+// value lê um valor (trechos concatenados com #) e retorna também o texto
+// expandido
+func (p *parser) value() (Value, string, bool) {
+	var value Value
+	var text strings.Builder
+
+	for {
+		p.skipSpace()
+		if p.pos >= len(p.src) {
+			p.fail(p.pos, "valor esperado")
+			return nil, "", false
+		}
+
+		switch c := p.src[p.pos]; {
+		case c == '{':
+			end := matchBrace(p.src, p.pos)
+			if end < 0 {
+				p.fail(p.pos, "chave { sem par")
+				return nil, "", false
+			}
+			part := p.src[p.pos+1 : end]
+			value = append(value, Part{Kind: Braced, Text: part})
+			text.WriteString(part)
+			p.pos = end + 1
+
+		case c == '"':
+			end := matchQuote(p.src, p.pos)
+			if end < 0 {
+				p.fail(p.pos, "aspas sem par")
+				return nil, "", false
+			}
+			part := p.src[p.pos+1 : end]
+			value = append(value, Part{Kind: Quoted, Text: part})
+			text.WriteString(part)
+			p.pos = end + 1
+
+		case c >= '0' && c <= '9':
+			start := p.pos
+			for p.pos < len(p.src) && p.src[p.pos] >= '0' && p.src[p.pos] <= '9' {
+				p.pos++
+			}
+			value = append(value, Part{Kind: Number, Text: p.src[start:p.pos]})
+			text.WriteString(p.src[start:p.pos])
+
+		default:
+			start := p.pos
+			name := p.fieldName()
+			if name == "" {
+				p.fail(start, "valor inválido")
+				return nil, "", false
+			}
+			value = append(value, Part{Kind: Macro, Text: name})
+			if expanded, ok := p.macros[strings.ToLower(name)]; ok {
+				text.WriteString(expanded)
+			} else if month, ok := months[strings.ToLower(name)]; ok {
+				text.WriteString(month)
+			} else {
+				p.fail(start, "macro %q não definida", name)
+			}
+		}
+
+		p.skipSpace()
+		if p.pos < len(p.src) && p.src[p.pos] == '#' {
+			p.pos++
+			continue
+		}
+		return value, text.String(), true
+	}
+}
+
+func (p *parser) expect(c byte) bool {
+	if p.pos < len(p.src) && p.src[p.pos] == c {
+		p.pos++
+		return true
+	}
+	found := "fim do arquivo"
+	if p.pos < len(p.src) {
+		found = fmt.Sprintf("%q", p.src[p.pos])
+	}
+	p.fail(p.pos, "%q esperado, encontrado %s", c, found)
+	return false
+}
+
+func (p *parser) skipSpace() {
+	for p.pos < len(p.src) && strings.IndexByte(" \t\r\n", p.src[p.pos]) >= 0 {
+		p.pos++
+	}
+}
+
+// identifier lê letras (tipo de entrada)
+func (p *parser) identifier() string {
+	start := p.pos
+	for p.pos < len(p.src) && isLetter(p.src[p.pos]) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+// fieldName lê um nome de campo ou de macro
+func (p *parser) fieldName() string {
+	start := p.pos
+	for p.pos < len(p.src) && !strings.ContainsRune(" \t\r\n=,#{}()\"%'", rune(p.src[p.pos])) {
+		p.pos++
+	}
+	return p.src[start:p.pos]
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// matchEntry retorna a posição do delimitador que fecha a entrada aberta em i
+func matchEntry(src string, i int) int {
+	closeChar := byte('}')
+	if src[i] == '(' {
+		closeChar = ')'
+	}
+
+	depth := 0
+	for j := i + 1; j < len(src); j++ {
+		switch c := src[j]; {
+		case c == '{':
+			depth++
+		case c == '}' && depth > 0:
+			depth--
+		case c == closeChar && depth == 0:
+			return j
+		}
+	}
+	return len(src)
+}
+
+// matchBrace retorna a posição da } que fecha a { em i, ou -1
+func matchBrace(src string, i int) int {
+	depth := 0
+	for j := i; j < len(src); j++ {
+		switch src[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// matchQuote retorna a posição das aspas que fecham as abertas em i; aspas
+// dentro de chaves ({"}) não fecham o valor
+func matchQuote(src string, i int) int {
+	depth := 0
+	for j := i + 1; j < len(src); j++ {
+		switch src[j] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		case '"':
+			if depth == 0 {
+				return j
+			}
+		}
+	}
+	return -1
+}
@@ -0,0 +1,90 @@
+package bibtex
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected []string // tipo:chave:linha
+		errors   int
+	}{
+		{
+			name:     "vazio",
+			src:      "",
+			expected: nil,
+		},
+		{
+			name:     "tipos e chaves",
+			src:      "@Article{silva2020,\n  title = {A {B} c},\n  author = {Silva}\n}\n\n@book(souza, title = \"X\")\n",
+			expected: []string{"article:silva2020:1", "book:souza:6"},
+		},
+		{
+			name:     "arroba em campo, texto solto e blocos especiais",
+			src:      "Texto livre com e-mail@exemplo.com\n@string{ieee = \"IEEE\"}\n@comment{ignorar @misc{x}}\n@misc{site,\n  note = {contato@exemplo.com}\n}",
+			expected: []string{"misc:site:4"},
+		},
+		{
+			name:     "erro de sintaxe não impede as entradas seguintes",
+			src:      "@article{a,\n  title = {Sem fim,\n}\n@book{b, title = x y}\n@misc{c, title = {Ok},}\n",
+			expected: []string{"misc:c:5"},
+			errors:   3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := Parse(tt.src)
+			var got []string
+			for _, e := range db.Entries {
+				got = append(got, fmt.Sprintf("%s:%s:%d", e.Type, e.Key, e.Line))
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("Parse() = %v, expected %v", got, tt.expected)
+			}
+			if len(db.Errors) != tt.errors {
+				t.Errorf("Errors = %v, expected %d", db.Errors, tt.errors)
+			}
+		})
+	}
+}
+
+func TestParseValues(t *testing.T) {
+	src := `@string{ieee = "IEEE"}
+@inproceedings{x,
+  booktitle = ieee # { Conference on } # "Testing",
+  year      = 2020,
+  month     = mar,
+  title     = "Quoted {"}word",
+}`
+
+	db := Parse(src)
+	if len(db.Errors) > 0 {
+		t.Fatalf("Errors = %v", db.Errors)
+	}
+	e := db.Find("X")
+	if e == nil {
+		t.Fatal("Find(\"X\") = nil, expected entrada x")
+	}
+
+	expected := map[string]string{
+		"booktitle": "IEEE Conference on Testing",
+		"year":      "2020",
+		"month":     "March",
+		"title":     `Quoted {"}word`,
+	}
+	for field, value := range expected {
+		if got := e.Get(field); got != value {
+			t.Errorf("Get(%q) = %q, expected %q", field, got, value)
+		}
+	}
+
+	f, _ := e.Field("booktitle")
+	if got := f.Value.String(); got != `ieee # { Conference on } # "Testing"` {
+		t.Errorf("Value.String() = %q", got)
+	}
+}
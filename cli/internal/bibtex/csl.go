@@ -0,0 +1,363 @@
+package bibtex
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// cslTypes mapeia os tipos do BibTeX/BibLaTeX para os do CSL
+var cslTypes = map[string]string{
+	"article":       "article-journal",
+	"book":          "book",
+	"mvbook":        "book",
+	"booklet":       "pamphlet",
+	"inbook":        "chapter",
+	"incollection":  "chapter",
+	"inproceedings": "paper-conference",
+	"conference":    "paper-conference",
+	"proceedings":   "book",
+	"manual":        "report",
+	"mastersthesis": "thesis",
+	"phdthesis":     "thesis",
+	"thesis":        "thesis",
+	"techreport":    "report",
+	"report":        "report",
+	"unpublished":   "manuscript",
+	"online":        "webpage",
+	"electronic":    "webpage",
+	"www":           "webpage",
+	"patent":        "patent",
+	"dataset":       "dataset",
+	"software":      "software",
+	"misc":          "document",
+}
+
+// cslFields são os campos copiados diretamente (BibTeX -> CSL), na ordem de
+// preferência quando mais de um campo corresponde à mesma variável
+var cslFields = []struct{ bib, csl string }{
+	{"title", "title"},
+	{"journaltitle", "container-title"},
+	{"journal", "container-title"},
+	{"booktitle", "container-title"},
+	{"series", "collection-title"},
+	{"volume", "volume"},
+	{"number", "issue"},
+	{"edition", "edition"},
+	{"chapter", "chapter-number"},
+	{"publisher", "publisher"},
+	{"school", "publisher"},
+	{"institution", "publisher"},
+	{"organization", "publisher"},
+	{"location", "publisher-place"},
+	{"address", "publisher-place"},
+	{"doi", "DOI"},
+	{"url", "URL"},
+	{"isbn", "ISBN"},
+	{"issn", "ISSN"},
+	{"abstract", "abstract"},
+	{"note", "note"},
+	{"keywords", "keyword"},
+	{"language", "language"},
+}
+
+// CSLName é um nome no formato do CSL-JSON
+type CSLName struct {
+	Family   string `json:"family,omitempty"`
+	Given    string `json:"given,omitempty"`
+	Particle string `json:"non-dropping-particle,omitempty"`
+	Suffix   string `json:"suffix,omitempty"`
+	Literal  string `json:"literal,omitempty"`
+}
+
+// CSLDate é uma data no formato do CSL-JSON
+type CSLDate struct {
+	DateParts [][]int `json:"date-parts"`
+}
+
+// CSLItem é uma referência no formato CSL-JSON (usado pelo pandoc, Zotero...)
+type CSLItem map[string]interface{}
+
+// ToCSL converte as entradas do banco para CSL-JSON
+func ToCSL(db *Database) []CSLItem {
+	items := make([]CSLItem, 0, len(db.Entries))
+	for _, e := range db.Entries {
+		items = append(items, EntryToCSL(e))
+	}
+	return items
+}
+
+// EntryToCSL converte uma entrada para CSL-JSON
+func EntryToCSL(e *Entry) CSLItem {
+	item := CSLItem{"id": e.Key, "type": "document"}
+	if t, ok := cslTypes[e.Type]; ok {
+		item["type"] = t
+	}
+
+	for _, field := range []string{"author", "editor", "translator"} {
+		if names := cslNames(e.Get(field)); len(names) > 0 {
+			item[field] = names
+		}
+	}
+
+	for _, f := range cslFields {
+		if _, done := item[f.csl]; done {
+			continue
+		}
+		if value := e.Get(f.bib); value != "" {
+			if f.csl == "DOI" || f.csl == "URL" {
+				item[f.csl] = strings.TrimSpace(strings.NewReplacer(`\_`, "_", `\%`, "%", `\&`, "&").Replace(value))
+			} else {
+				item[f.csl] = PlainText(value)
+			}
+		}
+	}
+
+	if subtitle := e.Get("subtitle"); subtitle != "" {
+		if title, ok := item["title"].(string); ok {
+			item["title"] = title + ": " + PlainText(subtitle)
+		}
+	}
+	if pages := e.Get("pages"); pages != "" {
+		item["page"] = PlainText(strings.ReplaceAll(strings.ReplaceAll(pages, "---", "-"), "--", "-"))
+	}
+	if item["type"] == "thesis" {
+		switch genre := e.Get("type"); {
+		case genre != "":
+			item["genre"] = PlainText(genre)
+		case e.Type == "phdthesis":
+			item["genre"] = "PhD thesis"
+		case e.Type == "mastersthesis":
+			item["genre"] = "Master's thesis"
+		}
+	}
+	if issued := cslDate(e.Get("date"), e.Get("year"), e.Get("month")); issued != nil {
+		item["issued"] = issued
+	}
+	if accessed := cslDate(e.Get("urldate"), "", ""); accessed != nil {
+		item["accessed"] = accessed
+	}
+
+	return item
+}
+
+func cslNames(s string) []CSLName {
+	var names []CSLName
+	for _, n := range ParseNames(s) {
+		names = append(names, CSLName{Family: n.Family, Given: n.Given, Particle: n.Particle, Suffix: n.Suffix, Literal: n.Literal})
+	}
+	return names
+}
+
+// cslDate converte date (AAAA-MM-DD) ou year e month em uma data do CSL
+func cslDate(date, year, month string) *CSLDate {
+	var parts []int
+	if date != "" {
+		for _, part := range strings.SplitN(strings.SplitN(date, "/", 2)[0], "-", 3) {
+			n, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				break
+			}
+			parts = append(parts, n)
+		}
+	} else if y, err := strconv.Atoi(PlainText(year)); err == nil {
+		parts = append(parts, y)
+		if m := monthNumber(month); m > 0 {
+			parts = append(parts, m)
+		}
+	}
+
+	if len(parts) == 0 {
+		return nil
+	}
+	return &CSLDate{DateParts: [][]int{parts}}
+}
+
+// monthNumber converte "3", "mar" ou "March" no número do mês
+func monthNumber(month string) int {
+	month = strings.ToLower(strings.TrimSpace(month))
+	if n, err := strconv.Atoi(month); err == nil && n >= 1 && n <= 12 {
+		return n
+	}
+	if len(month) < 3 {
+		return 0
+	}
+	for i, name := range []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"} {
+		if month[:3] == name {
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// bibTypes mapeia os tipos do CSL para os do BibTeX (inverso de cslTypes)
+var bibTypes = map[string]string{
+	"article":           "article",
+	"article-journal":   "article",
+	"article-magazine":  "article",
+	"article-newspaper": "article",
+	"book":              "book",
+	"chapter":           "incollection",
+	"paper-conference":  "inproceedings",
+	"thesis":            "phdthesis",
+	"report":            "techreport",
+	"manuscript":        "unpublished",
+	"pamphlet":          "booklet",
+	"patent":            "patent",
+}
+
+// ParseCSLJSON lê referências em CSL-JSON (uma lista de itens ou um item);
+// o id de cada item vira a chave
+func ParseCSLJSON(data []byte) ([]*Entry, error) {
+	var items []CSLItem
+	if err := json.Unmarshal(data, &items); err != nil {
+		var item CSLItem
+		if json.Unmarshal(data, &item) != nil {
+			return nil, fmt.Errorf("CSL-JSON inválido: %w", err)
+		}
+		items = []CSLItem{item}
+	}
+
+	entries := make([]*Entry, 0, len(items))
+	for _, item := range items {
+		entries = append(entries, CSLToEntry(item))
+	}
+	return entries, nil
+}
+
+// CSLToEntry converte um item CSL-JSON em uma entrada
+func CSLToEntry(item CSLItem) *Entry {
+	str := func(name string) string {
+		switch v := item[name].(type) {
+		case string:
+			return v
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+		return ""
+	}
+
+	cslType := str("type")
+	e := &Entry{Type: "misc", Key: str("id")}
+	if t, ok := bibTypes[cslType]; ok {
+		e.Type = t
+	}
+	if e.Type == "phdthesis" && strings.Contains(strings.ToLower(str("genre")), "master") {
+		e.Type = "mastersthesis"
+	}
+
+	for _, field := range []string{"author", "editor", "translator"} {
+		addNames(e, field, cslItemNames(item[field]))
+	}
+
+	title := str("title")
+	if subtitle := str("subtitle"); subtitle != "" {
+		title += ": " + subtitle
+	}
+	addField(e, "title", title)
+
+	switch e.Type {
+	case "article":
+		addField(e, "journal", str("container-title"))
+	case "incollection", "inproceedings":
+		addField(e, "booktitle", str("container-title"))
+	default:
+		addField(e, "howpublished", str("container-title"))
+	}
+
+	for _, f := range cslFields {
+		switch f.csl {
+		case "title", "container-title":
+			continue
+		case "publisher":
+			switch e.Type {
+			case "phdthesis", "mastersthesis":
+				addField(e, "school", str(f.csl))
+			case "techreport":
+				addField(e, "institution", str(f.csl))
+			default:
+				addField(e, "publisher", str(f.csl))
+			}
+		default:
+			if f.bib == "journaltitle" || f.bib == "journal" || f.bib == "booktitle" || f.bib == "location" {
+				continue
+			}
+			addField(e, f.bib, str(f.csl))
+		}
+	}
+	addField(e, "pages", str("page"))
+
+	if date := cslItemDate(item["issued"]); date != "" {
+		addDate(e, date)
+	}
+	if date := cslItemDate(item["accessed"]); date != "" {
+		addField(e, "urldate", date)
+	}
+	return e
+}
+
+// cslItemNames converte os nomes de um item para a forma "Sobrenome, Nome"
+func cslItemNames(v interface{}) []string {
+	list, _ := v.([]interface{})
+	var names []string
+	for _, raw := range list {
+		n, _ := raw.(map[string]interface{})
+		get := func(key string) string {
+			s, _ := n[key].(string)
+			return strings.TrimSpace(s)
+		}
+
+		if literal := get("literal"); literal != "" {
+			names = append(names, "{"+literal+"}")
+			continue
+		}
+		family := strings.TrimSpace(get("non-dropping-particle") + " " + get("family"))
+		if family == "" {
+			continue
+		}
+		name := family
+		if suffix := get("suffix"); suffix != "" {
+			name += ", " + suffix
+		}
+		if given := strings.TrimSpace(get("given") + " " + get("dropping-particle")); given != "" {
+			name += ", " + given
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+// cslItemDate converte uma data do CSL (date-parts, raw ou literal) em
+// AAAA, AAAA-MM ou AAAA-MM-DD
+func cslItemDate(v interface{}) string {
+	date, _ := v.(map[string]interface{})
+	if parts, ok := date["date-parts"].([]interface{}); ok && len(parts) > 0 {
+		first, _ := parts[0].([]interface{})
+		var out []string
+		for i, part := range first {
+			var n int
+			switch p := part.(type) {
+			case float64:
+				n = int(p)
+			case string:
+				n, _ = strconv.Atoi(p)
+			}
+			if n <= 0 {
+				break
+			}
+			if i == 0 {
+				out = append(out, fmt.Sprintf("%04d", n))
+			} else {
+				out = append(out, fmt.Sprintf("%02d", n))
+			}
+		}
+		return strings.Join(out, "-")
+	}
+	for _, key := range []string{"raw", "literal"} {
+		if s, ok := date[key].(string); ok {
+			return s
+		}
+	}
+	return ""
+}
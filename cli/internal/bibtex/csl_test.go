@@ -0,0 +1,75 @@
+package bibtex
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestEntryToCSL(t *testing.T) {
+	db := Parse(`@phdthesis{souza2021,
+  author = {Souza, Jo{\~a}o and {Grupo de Pesquisa}},
+  title  = {Compila{\c{c}}{\~a}o},
+  subtitle = {um estudo},
+  school = {USP},
+  year   = 2021,
+  month  = mar,
+  pages  = {10--20},
+  doi    = {10.1000/x\_y},
+}`)
+
+	item := EntryToCSL(db.Entries[0])
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{
+		"id":        "souza2021",
+		"type":      "thesis",
+		"genre":     "PhD thesis",
+		"title":     "Compilação: um estudo",
+		"publisher": "USP",
+		"page":      "10-20",
+		"DOI":       "10.1000/x_y",
+		"issued":    map[string]interface{}{"date-parts": []interface{}{[]interface{}{2021.0, 3.0}}},
+		"author": []interface{}{
+			map[string]interface{}{"family": "Souza", "given": "João"},
+			map[string]interface{}{"literal": "Grupo de Pesquisa"},
+		},
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("EntryToCSL() = %s", data)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	a := Parse("@article{x, title = {Título}, year = 2020}\n@book{y, title = {Livro}}\n@string{ieee = {IEEE}}")
+	b := Parse("@article{X, title = {{T}ítulo}, doi = {10.1/x}}\n@book{y, title = {Outro livro}}\n@misc{z, title = {Z}}\n@string{ieee = {IEEE Press}}")
+
+	merged, conflicts := Merge([]*Database{a, b}, []string{"a.bib", "b.bib"})
+
+	var keys []string
+	for _, e := range merged.Entries {
+		keys = append(keys, e.Key)
+	}
+	if !reflect.DeepEqual(keys, []string{"x", "y", "z"}) {
+		t.Errorf("Entries = %v, expected [x y z]", keys)
+	}
+	if merged.Entries[0].Get("doi") != "10.1/x" {
+		t.Errorf("x.doi = %q, expected campo completado pelo segundo arquivo", merged.Entries[0].Get("doi"))
+	}
+
+	expected := []Conflict{
+		{Key: "@string ieee", Kept: "a.bib", Other: "b.bib", Fields: []string{"ieee"}},
+		{Key: "y", Kept: "a.bib", Other: "b.bib", Fields: []string{"title"}},
+	}
+	if !reflect.DeepEqual(conflicts, expected) {
+		t.Errorf("conflicts = %+v, expected %+v", conflicts, expected)
+	}
+}
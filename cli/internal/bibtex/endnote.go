@@ -0,0 +1,212 @@
+package bibtex
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// endnoteTypes mapeia os tipos do EndNote (nome ou número de ref-type) para
+// os do BibTeX
+var endnoteTypes = map[string]string{
+	"journal article":        "article",
+	"17":                     "article",
+	"magazine article":       "article",
+	"19":                     "article",
+	"newspaper article":      "article",
+	"23":                     "article",
+	"book":                   "book",
+	"6":                      "book",
+	"edited book":            "book",
+	"28":                     "book",
+	"book section":           "incollection",
+	"5":                      "incollection",
+	"conference proceedings": "inproceedings",
+	"10":                     "inproceedings",
+	"conference paper":       "inproceedings",
+	"47":                     "inproceedings",
+	"thesis":                 "phdthesis",
+	"32":                     "phdthesis",
+	"report":                 "techreport",
+	"27":                     "techreport",
+	"unpublished work":       "unpublished",
+	"34":                     "unpublished",
+	"patent":                 "patent",
+	"25":                     "patent",
+}
+
+// xmlNode é um elemento genérico do XML do EndNote
+type xmlNode struct {
+	XMLName xml.Name
+	Attrs   []xml.Attr `xml:",any,attr"`
+	Content string     `xml:",chardata"`
+	Nodes   []xmlNode  `xml:",any"`
+}
+
+// text retorna o texto do elemento e de todos os descendentes (o EndNote
+// envolve os valores em <style>)
+func (n *xmlNode) text() string {
+	var b strings.Builder
+	n.writeText(&b)
+	return strings.TrimSpace(b.String())
+}
+
+func (n *xmlNode) writeText(b *strings.Builder) {
+	b.WriteString(n.Content)
+	for i := range n.Nodes {
+		n.Nodes[i].writeText(b)
+	}
+}
+
+// find retorna o primeiro elemento no caminho informado (ex.: "titles/title")
+func (n *xmlNode) find(path string) *xmlNode {
+	current := n
+	for _, name := range strings.Split(path, "/") {
+		var next *xmlNode
+		for i := range current.Nodes {
+			if current.Nodes[i].XMLName.Local == name {
+				next = &current.Nodes[i]
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		current = next
+	}
+	return current
+}
+
+// get retorna o texto do primeiro caminho existente
+func (n *xmlNode) get(paths ...string) string {
+	for _, path := range paths {
+		if node := n.find(path); node != nil {
+			if text := node.text(); text != "" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+// all retorna os textos dos filhos de um elemento (autores, palavras-chave)
+func (n *xmlNode) all(path string) []string {
+	node := n.find(path)
+	if node == nil {
+		return nil
+	}
+	var values []string
+	for i := range node.Nodes {
+		if text := node.Nodes[i].text(); text != "" {
+			values = append(values, text)
+		}
+	}
+	return values
+}
+
+func (n *xmlNode) attr(name string) string {
+	for _, a := range n.Attrs {
+		if a.Name.Local == name {
+			return a.Value
+		}
+	}
+	return ""
+}
+
+// ParseEndNote lê o XML exportado pelo EndNote (<xml><records><record>...)
+func ParseEndNote(data []byte) ([]*Entry, error) {
+	var root xmlNode
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+	if err := decoder.Decode(&root); err != nil {
+		return nil, fmt.Errorf("XML do EndNote inválido: %w", err)
+	}
+
+	records := &root
+	if root.XMLName.Local != "records" {
+		if records = root.find("records"); records == nil {
+			return nil, fmt.Errorf("XML do EndNote sem <records>")
+		}
+	}
+
+	var entries []*Entry
+	for i := range records.Nodes {
+		if records.Nodes[i].XMLName.Local == "record" {
+			entries = append(entries, endnoteEntry(&records.Nodes[i]))
+		}
+	}
+	return entries, nil
+}
+
+// endnoteEntry converte um <record> em uma entrada
+func endnoteEntry(r *xmlNode) *Entry {
+	e := &Entry{Type: "misc"}
+	if refType := r.find("ref-type"); refType != nil {
+		name := strings.ToLower(refType.attr("name"))
+		if t, ok := endnoteTypes[name]; ok {
+			e.Type = t
+		} else if t, ok := endnoteTypes[refType.text()]; ok {
+			e.Type = t
+		}
+	}
+	if strings.Contains(strings.ToLower(r.get("work-type")), "master") && e.Type == "phdthesis" {
+		e.Type = "mastersthesis"
+	}
+
+	addNames(e, "author", r.all("contributors/authors"))
+	if e.Type != "article" {
+		addNames(e, "editor", r.all("contributors/secondary-authors"))
+	}
+
+	addField(e, "title", r.get("titles/title"))
+	container := r.get("titles/secondary-title", "periodical/full-title", "titles/alt-title")
+	switch e.Type {
+	case "article":
+		addField(e, "journal", container)
+	case "incollection", "inproceedings":
+		addField(e, "booktitle", container)
+	case "book", "techreport":
+		addField(e, "series", r.get("titles/tertiary-title", "titles/secondary-title"))
+	default:
+		addField(e, "howpublished", container)
+	}
+
+	addField(e, "volume", r.get("volume"))
+	addField(e, "number", r.get("number", "issue"))
+	addField(e, "pages", r.get("pages"))
+	addField(e, "edition", r.get("edition"))
+
+	publisher := r.get("publisher")
+	switch e.Type {
+	case "phdthesis", "mastersthesis":
+		addField(e, "school", publisher)
+	case "techreport":
+		addField(e, "institution", publisher)
+	default:
+		addField(e, "publisher", publisher)
+	}
+	addField(e, "address", r.get("pub-location"))
+
+	addDate(e, r.get("dates/year"))
+	if e.Has("year") {
+		// pub-dates costuma vir como "March 15" ou "Mar"
+		addMonth(e, monthNumber(strings.Fields(r.get("dates/pub-dates/date") + " x")[0]))
+	}
+
+	if isbn := r.get("isbn"); isbn != "" {
+		if e.Type == "article" {
+			addField(e, "issn", isbn)
+		} else {
+			addField(e, "isbn", isbn)
+		}
+	}
+	addField(e, "doi", r.get("electronic-resource-num"))
+	addField(e, "url", r.get("urls/related-urls/url", "urls/web-urls/url"))
+	addField(e, "language", r.get("language"))
+	addField(e, "keywords", strings.Join(r.all("keywords"), ", "))
+	addField(e, "abstract", r.get("abstract"))
+	addField(e, "note", r.get("notes"))
+
+	return e
+}
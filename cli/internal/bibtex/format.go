@@ -0,0 +1,219 @@
+package bibtex
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// FieldOrder é a ordem canônica dos campos; os demais vêm depois, em ordem
+// alfabética
+var FieldOrder = []string{
+	"author", "editor", "translator", "title", "subtitle", "booktitle", "maintitle",
+	"journal", "journaltitle", "series", "edition", "volume", "number", "chapter", "pages",
+	"type", "school", "institution", "organization", "publisher", "address", "location",
+	"month", "year", "date", "isbn", "issn", "doi", "eprint", "eprinttype", "url", "urldate",
+	"language", "keywords", "abstract", "note", "file",
+}
+
+var spacesPattern = regexp.MustCompile(`\s+`)
+
+// Estilos de chave de 'ltx bib format --keys'
+const (
+	KeysKeep            = "keep"            // mantém as chaves
+	KeysAuthorYear      = "authoryear"      // silva2020
+	KeysAuthorYearTitle = "authoryeartitle" // silva2020aprendizado
+)
+
+// FormatOptions controla a formatação de um banco
+type FormatOptions struct {
+	Indent string // indentação dos campos (padrão: dois espaços)
+	Sort   bool   // ordena as entradas pela chave
+}
+
+// Format escreve o banco em forma canônica: @preamble e @string no início,
+// tipos e campos em minúsculas, campos na ordem de FieldOrder com os "="
+// alinhados e valores entre chaves (números e macros sem delimitador). Texto
+// solto fora de entradas é descartado; @comment é mantido no fim.
+func Format(db *Database, opts FormatOptions) string {
+	indent := opts.Indent
+	if indent == "" {
+		indent = "  "
+	}
+
+	var blocks []string
+	for _, preamble := range db.Preambles {
+		blocks = append(blocks, "@preamble{"+canonicalValue("", preamble)+"}")
+	}
+
+	if len(db.Strings) > 0 {
+		var lines []string
+		for _, s := range db.Strings {
+			lines = append(lines, fmt.Sprintf("@string{%s = %s}", s.Name, canonicalValue(s.Name, s.Value)))
+		}
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+
+	entries := append([]*Entry(nil), db.Entries...)
+	if opts.Sort {
+		sort.SliceStable(entries, func(i, j int) bool {
+			return strings.ToLower(entries[i].Key) < strings.ToLower(entries[j].Key)
+		})
+	}
+	for _, e := range entries {
+		blocks = append(blocks, FormatEntry(e, indent))
+	}
+
+	for _, comment := range db.Comments {
+		blocks = append(blocks, "@comment{"+comment+"}")
+	}
+
+	if len(blocks) == 0 {
+		return ""
+	}
+	return strings.Join(blocks, "\n\n") + "\n"
+}
+
+// FormatEntry escreve uma entrada em forma canônica
+func FormatEntry(e *Entry, indent string) string {
+	fields := SortFields(e.Fields)
+
+	width := 0
+	for _, f := range fields {
+		width = max(width, len(f.Name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "@%s{%s", e.Type, e.Key)
+	for _, f := range fields {
+		fmt.Fprintf(&b, ",\n%s%-*s = %s", indent, width, f.Name, canonicalValue(f.Name, f.Value))
+	}
+	b.WriteString("\n}")
+	return b.String()
+}
+
+// SortFields ordena os campos segundo FieldOrder
+func SortFields(fields []Field) []Field {
+	rank := func(name string) int {
+		for i, known := range FieldOrder {
+			if known == name {
+				return i
+			}
+		}
+		return len(FieldOrder)
+	}
+
+	sorted := append([]Field(nil), fields...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, rj := rank(sorted[i].Name), rank(sorted[j].Name)
+		if ri != rj {
+			return ri < rj
+		}
+		return ri == len(FieldOrder) && sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// canonicalValue escreve o valor de um campo com chaves no lugar de aspas e
+// sequências de espaços reduzidas a um. Partes de uma concatenação (#) mantêm
+// o espaço das pontas (jan # " 1st"), e campos literais ficam como estão.
+func canonicalValue(name string, v Value) string {
+	canonical := make(Value, len(v))
+	for i, part := range v {
+		if part.Kind == Quoted {
+			part.Kind = Braced
+		}
+		if part.Kind == Braced && !verbatimFields[name] {
+			part.Text = spacesPattern.ReplaceAllString(part.Text, " ")
+			if len(v) == 1 {
+				part.Text = strings.TrimSpace(part.Text)
+			}
+		}
+		canonical[i] = part
+	}
+	return canonical.String()
+}
+
+// ParseKeyStyle valida um estilo de chave; vazio equivale a keep
+func ParseKeyStyle(style string) (string, error) {
+	switch style = strings.ToLower(strings.TrimSpace(style)); style {
+	case "", KeysKeep:
+		return KeysKeep, nil
+	case KeysAuthorYear, KeysAuthorYearTitle:
+		return style, nil
+	default:
+		return "", fmt.Errorf("estilo de chave inválido: %q (use %s, %s ou %s)", style, KeysKeep, KeysAuthorYear, KeysAuthorYearTitle)
+	}
+}
+
+// keyStylePatterns são os padrões de chave equivalentes a cada estilo
+var keyStylePatterns = map[string]string{
+	KeysAuthorYear:      "{author}{year}",
+	KeysAuthorYearTitle: "{author}{year}{firstword}",
+}
+
+// GenerateKey cria a chave de uma entrada no estilo informado, ou "" se a
+// entrada não tiver autor (ou editor) e ano
+func GenerateKey(e *Entry, style string) string {
+	if firstAuthor(e) == "" || Year(e) == "" {
+		return ""
+	}
+	return KeyFromPattern(e, keyStylePatterns[style])
+}
+
+// RenameKeys troca as chaves das entradas pelo estilo informado, com sufixos
+// a, b, c... quando coincidem, e retorna as chaves alteradas (antiga -> nova).
+// Entradas sem autor ou ano mantêm a chave.
+func RenameKeys(db *Database, style string) map[string]string {
+	renames := make(map[string]string)
+	if style == KeysKeep {
+		return renames
+	}
+
+	generated := make([]string, len(db.Entries))
+	count := make(map[string]int)
+	for i, e := range db.Entries {
+		generated[i] = GenerateKey(e, style)
+		if generated[i] != "" {
+			count[generated[i]]++
+		}
+	}
+
+	// Chaves mantidas (sem autor ou ano) continuam reservadas
+	used := make(map[string]bool)
+	for i, e := range db.Entries {
+		if generated[i] == "" {
+			used[strings.ToLower(e.Key)] = true
+		}
+	}
+
+	suffix := make(map[string]int)
+	for i, e := range db.Entries {
+		key := generated[i]
+		if key == "" {
+			continue
+		}
+		if count[key] > 1 || used[key] {
+			key = uniqueKey(key, used, suffix)
+		}
+		used[key] = true
+
+		if key != e.Key {
+			renames[e.Key] = key
+			e.Key = key
+		}
+	}
+	return renames
+}
+
+// Year retorna o ano de uma entrada, do campo year ou do início de date
+func Year(e *Entry) string {
+	if year := e.Get("year"); year != "" {
+		return PlainText(year)
+	}
+	if date := e.Get("date"); len(date) >= 4 {
+		return date[:4]
+	}
+	return ""
+}
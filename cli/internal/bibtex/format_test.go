@@ -0,0 +1,83 @@
+package bibtex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	src := `@COMMENT{jabref-meta: databaseType:biblatex;}
+@Article{ silva2020 ,
+    Year = 2020, title="Um  título",
+  journal = ieee, Author = {Silva, Ana},
+  xyz = {b}, abc = {a}
+}
+@string{ieee = {IEEE}}
+`
+
+	expected := `@string{ieee = {IEEE}}
+
+@article{silva2020,
+  author  = {Silva, Ana},
+  title   = {Um título},
+  journal = ieee,
+  year    = 2020,
+  abc     = {a},
+  xyz     = {b}
+}
+
+@comment{jabref-meta: databaseType:biblatex;}
+`
+
+	got := Format(Parse(src), FormatOptions{})
+	if got != expected {
+		t.Errorf("Format() =\n%s\nexpected\n%s", got, expected)
+	}
+
+	// Formatar de novo não muda nada
+	if again := Format(Parse(got), FormatOptions{}); again != got {
+		t.Errorf("Format() não é idempotente:\n%s", again)
+	}
+}
+
+func TestFormatPreservesSpacing(t *testing.T) {
+	src := `@misc{evento,
+  month = jan # "  1st",
+  note = "Dia " # {2} # " de   março",
+  file = {:docs/Relatório  final.pdf:PDF},
+  title = {  Um   título  }
+}
+`
+
+	expected := `@misc{evento,
+  title = {Um título},
+  month = jan # { 1st},
+  note  = {Dia } # {2} # { de março},
+  file  = {:docs/Relatório  final.pdf:PDF}
+}
+`
+
+	got := Format(Parse(src), FormatOptions{})
+	if got != expected {
+		t.Errorf("Format() =\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestRenameKeys(t *testing.T) {
+	db := Parse(`@article{a, author = {Silva, Ana}, year = 2020, title = {O aprendizado de máquina}}
+@article{b, author = {da Silva, João}, year = 2020, title = {Redes}}
+@book{c, author = {Müller, K.}, date = {2019-05-01}, title = {The Book}}
+@misc{silva2020c, title = {Sem autor}}
+@misc{d, author = {{IEEE}}, year = {2018}}`)
+
+	renames := RenameKeys(db, KeysAuthorYear)
+	expected := map[string]string{"a": "silva2020a", "b": "silva2020b", "c": "muller2019", "d": "ieee2018"}
+	if !reflect.DeepEqual(renames, expected) {
+		t.Errorf("RenameKeys(authoryear) = %v, expected %v", renames, expected)
+	}
+
+	db = Parse(`@article{a, author = {Silva, Ana}, year = 2020, title = {O {Aprendizado} de máquina}}`)
+	if renames := RenameKeys(db, KeysAuthorYearTitle); renames["a"] != "silva2020aprendizado" {
+		t.Errorf("RenameKeys(authoryeartitle) = %v, expected silva2020aprendizado", renames)
+	}
+}
@@ -0,0 +1,274 @@
+package bibtex
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Formatos aceitos por 'ltx bib import'
+const (
+	ImportAuto    = "auto"
+	ImportBibTeX  = "bibtex"
+	ImportRIS     = "ris"
+	ImportEndNote = "endnote"
+	ImportCSLJSON = "csl-json"
+)
+
+// ParseImportFormat valida um formato de importação; vazio equivale a auto
+func ParseImportFormat(format string) (string, error) {
+	switch format = strings.ToLower(strings.TrimSpace(format)); format {
+	case "", ImportAuto:
+		return ImportAuto, nil
+	case ImportBibTeX, ImportRIS, ImportEndNote, ImportCSLJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("formato inválido: %q (use %s, %s, %s, %s ou %s)", format, ImportAuto, ImportBibTeX, ImportRIS, ImportEndNote, ImportCSLJSON)
+	}
+}
+
+var risStart = regexp.MustCompile(`(?m)^TY  - `)
+
+// DetectFormat identifica o formato de um arquivo pela extensão ou, se ela
+// não bastar, pelo conteúdo
+func DetectFormat(path string, data []byte) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".bib", ".bibtex":
+		return ImportBibTeX, nil
+	case ".ris":
+		return ImportRIS, nil
+	case ".json":
+		return ImportCSLJSON, nil
+	}
+
+	content := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	switch {
+	case bytes.HasPrefix(content, []byte("[")) || bytes.HasPrefix(content, []byte("{")):
+		return ImportCSLJSON, nil
+	case bytes.HasPrefix(content, []byte("<")) && bytes.Contains(content, []byte("<record")):
+		return ImportEndNote, nil
+	case risStart.Match(content):
+		return ImportRIS, nil
+	case bytes.HasPrefix(content, []byte("@")) || bytes.Contains(content, []byte("\n@")):
+		return ImportBibTeX, nil
+	}
+	return "", fmt.Errorf("formato de %s não reconhecido; informe --format", path)
+}
+
+// Import converte o conteúdo de um arquivo no formato informado em entradas
+// normalizadas. As chaves são as do arquivo de origem (quando houver).
+func Import(data []byte, format string) ([]*Entry, error) {
+	var entries []*Entry
+	switch format {
+	case ImportBibTeX:
+		db := Parse(string(data))
+		if len(db.Errors) > 0 {
+			return nil, db.Errors[0]
+		}
+		entries = db.Entries
+	case ImportRIS:
+		entries = ParseRIS(string(data))
+	case ImportEndNote:
+		var err error
+		if entries, err = ParseEndNote(data); err != nil {
+			return nil, err
+		}
+	case ImportCSLJSON:
+		var err error
+		if entries, err = ParseCSLJSON(data); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("formato não suportado: %s", format)
+	}
+
+	for _, e := range entries {
+		Normalize(e)
+	}
+	return entries, nil
+}
+
+var (
+	doiPrefix   = regexp.MustCompile(`(?i)^(https?://(dx\.)?doi\.org/|doi:\s*)`)
+	singleRange = regexp.MustCompile(`^(\w+)\s*[-‐–—]\s*(\w+)$`)
+)
+
+// Normalize limpa os campos de uma entrada importada: remove campos vazios,
+// deixa o DOI sem prefixo de URL e separa intervalos de páginas com --
+func Normalize(e *Entry) {
+	fields := e.Fields[:0]
+	for _, f := range e.Fields {
+		if strings.TrimSpace(f.Text) == "" {
+			continue
+		}
+		switch f.Name {
+		case "doi":
+			f = textField(f.Name, doiPrefix.ReplaceAllString(strings.TrimSpace(f.Text), ""))
+		case "pages":
+			if m := singleRange.FindStringSubmatch(strings.TrimSpace(f.Text)); m != nil {
+				f = textField(f.Name, m[1]+"--"+m[2])
+			}
+		}
+		fields = append(fields, f)
+	}
+	e.Fields = fields
+}
+
+// Duplicate indica a entrada já existente que corresponde a uma importada
+type Duplicate struct {
+	Entry  *Entry
+	Reason string // doi, key ou title
+}
+
+// Index localiza entradas repetidas pelo DOI ou pelo título e ano
+type Index struct {
+	dois   map[string]*Entry
+	titles map[string]*Entry
+}
+
+// NewIndex indexa as entradas de um banco
+func NewIndex(entries []*Entry) *Index {
+	idx := &Index{dois: make(map[string]*Entry), titles: make(map[string]*Entry)}
+	for _, e := range entries {
+		idx.Add(e)
+	}
+	return idx
+}
+
+// Add acrescenta uma entrada ao índice
+func (idx *Index) Add(e *Entry) {
+	if doi := doiKey(e); doi != "" {
+		if _, ok := idx.dois[doi]; !ok {
+			idx.dois[doi] = e
+		}
+	}
+	if title := titleKey(e); title != "" {
+		if _, ok := idx.titles[title]; !ok {
+			idx.titles[title] = e
+		}
+	}
+}
+
+// Find retorna a entrada indexada equivalente a e: com o mesmo DOI ou, sem
+// DOI em uma das duas, com o mesmo título (ignorando acentos, pontuação e
+// maiúsculas) e o mesmo ano
+func (idx *Index) Find(e *Entry) (Duplicate, bool) {
+	doi := doiKey(e)
+	if doi != "" {
+		if found, ok := idx.dois[doi]; ok {
+			return Duplicate{Entry: found, Reason: "doi"}, true
+		}
+	}
+	if title := titleKey(e); title != "" {
+		if found, ok := idx.titles[title]; ok && (doi == "" || doiKey(found) == "") {
+			return Duplicate{Entry: found, Reason: "title"}, true
+		}
+	}
+	return Duplicate{}, false
+}
+
+func doiKey(e *Entry) string {
+	return strings.ToLower(doiPrefix.ReplaceAllString(strings.TrimSpace(e.Get("doi")), ""))
+}
+
+func titleKey(e *Entry) string {
+	title := nonAlnum.ReplaceAllString(strings.ToLower(Fold(PlainText(e.Get("title")))), "")
+	if title == "" {
+		return ""
+	}
+	return title + "/" + Year(e)
+}
+
+// AssignKeys troca as chaves das entradas pelo padrão informado, sem repetir
+// as chaves usadas (em minúsculas) nem as das outras entradas. Com keep, as
+// chaves de origem são mantidas e só as ausentes ou repetidas são geradas.
+func AssignKeys(entries []*Entry, pattern string, used map[string]bool) {
+	suffix := make(map[string]int)
+	for _, e := range entries {
+		key := e.Key
+		if pattern != KeysKeep || key == "" {
+			p := pattern
+			if p == KeysKeep {
+				p = DefaultKeyPattern
+			}
+			key = KeyFromPattern(e, p)
+		}
+		if used[strings.ToLower(key)] {
+			key = uniqueKey(key, used, suffix)
+		}
+		used[strings.ToLower(key)] = true
+		e.Key = key
+	}
+}
+
+// latexSpecial são escapados nos campos de texto vindos de formatos sem LaTeX
+var latexSpecial = strings.NewReplacer(`\`, `\textbackslash{}`, "&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`, "{", `\{`, "}", `\}`)
+
+// verbatimFields não são escapados (o BibTeX os trata como URLs) nem têm os
+// espaços normalizados por Format
+var verbatimFields = map[string]bool{"doi": true, "url": true, "file": true, "eprint": true}
+
+// textField cria um campo a partir de um valor já em sintaxe BibTeX
+func textField(name, text string) Field {
+	return Field{Name: name, Value: Value{{Kind: Braced, Text: text}}, Text: text}
+}
+
+// addField acrescenta um campo de texto simples (sem LaTeX), escapando os
+// caracteres especiais; valores vazios e campos já presentes são ignorados
+func addField(e *Entry, name, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" || e.Has(name) {
+		return
+	}
+	if !verbatimFields[name] {
+		value = latexSpecial.Replace(value)
+	}
+	e.Fields = append(e.Fields, textField(name, value))
+}
+
+// addNames acrescenta um campo de nomes (author, editor) unidos por "and".
+// Nomes entre chaves ({IEEE}) são institucionais e mantêm as chaves.
+func addNames(e *Entry, name string, names []string) {
+	var clean []string
+	for _, n := range names {
+		n = strings.Join(strings.Fields(n), " ")
+		if literal := strings.TrimSuffix(strings.TrimPrefix(n, "{"), "}"); literal != "" && len(literal) == len(n)-2 {
+			clean = append(clean, "{"+latexSpecial.Replace(literal)+"}")
+		} else if n != "" {
+			clean = append(clean, latexSpecial.Replace(n))
+		}
+	}
+	if len(clean) > 0 && !e.Has(name) {
+		e.Fields = append(e.Fields, textField(name, strings.Join(clean, " and ")))
+	}
+}
+
+var monthMacros = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+var yearPattern = regexp.MustCompile(`\d{4}`)
+
+// addDate acrescenta year e month a partir de datas como 2020/03/15,
+// 2020-03 ou "March 2020"
+func addDate(e *Entry, date string) {
+	year := yearPattern.FindString(date)
+	if year == "" || e.Has("year") {
+		return
+	}
+	addField(e, "year", year)
+
+	parts := strings.FieldsFunc(date, func(r rune) bool { return r == '/' || r == '-' })
+	if len(parts) >= 2 && parts[0] == year {
+		addMonth(e, monthNumber(parts[1]))
+	}
+}
+
+// addMonth acrescenta o mês (1 a 12) como macro do BibTeX (jan, feb...)
+func addMonth(e *Entry, month int) {
+	if month < 1 || month > 12 || e.Has("month") {
+		return
+	}
+	macro := monthMacros[month-1]
+	e.Fields = append(e.Fields, Field{Name: "month", Value: Value{{Kind: Macro, Text: macro}}, Text: months[macro]})
+}
@@ -0,0 +1,192 @@
+package bibtex
+
+import (
+	"strings"
+	"testing"
+)
+
+// importedEntry resume uma entrada para comparação nos testes
+func importedEntry(e *Entry) string {
+	var b strings.Builder
+	b.WriteString("@" + e.Type + "{" + e.Key)
+	for _, f := range e.Fields {
+		if f.Name != "abstract" {
+			b.WriteString("," + f.Name + "=" + f.Value.String())
+		}
+	}
+	return b.String() + "}"
+}
+
+func TestImport(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		content  string
+		expected []string
+	}{
+		{
+			name:   "RIS do Zotero",
+			format: ImportRIS,
+			content: "TY  - JOUR\r\nAU  - Silva, Ana\r\nAU  - Souza, João\r\nTI  - Redes & grafos\r\nT2  - Revista Brasileira\r\n" +
+				"PY  - 2020/03/15/\r\nVL  - 12\r\nSP  - 10\r\nEP  - 25\r\nDO  - https://doi.org/10.1000/ABC_1\r\nKW  - redes\r\nKW  - grafos\r\n" +
+				"AB  - Um resumo\r\n   que continua\r\nER  - \r\n\r\nTY  - CHAP\r\nAU  - Müller, K.\r\nA2  - Lee, B.\r\nTI  - Capítulo\r\nT2  - Livro\r\nPY  - 2019\r\nSP  - 5-9\r\nPB  - Springer\r\nER  - \r\n",
+			expected: []string{
+				"@article{,author={Silva, Ana and Souza, João},title={Redes \\& grafos},journal={Revista Brasileira},volume={12},pages={10--25},year={2020},month=mar,doi={10.1000/ABC_1},keywords={redes, grafos}}",
+				"@incollection{,author={Müller, K.},editor={Lee, B.},title={Capítulo},booktitle={Livro},pages={5--9},publisher={Springer},year={2019}}",
+			},
+		},
+		{
+			name:   "CSL-JSON",
+			format: ImportCSLJSON,
+			content: `[{"id": "item1", "type": "thesis", "genre": "Master's thesis", "title": "Uma tese",
+  "author": [{"family": "Silva", "given": "Ana", "non-dropping-particle": "da"}, {"literal": "IEEE"}],
+  "publisher": "USP", "issued": {"date-parts": [[2021, 6]]}, "URL": "https://x.org/a_b"},
+ {"id": "item2", "type": "webpage", "title": "Site", "issued": {"raw": "2018"}}]`,
+			expected: []string{
+				"@mastersthesis{item1,author={da Silva, Ana and {IEEE}},title={Uma tese},school={USP},url={https://x.org/a_b},year={2021},month=jun}",
+				"@misc{item2,title={Site},year={2018}}",
+			},
+		},
+		{
+			name:   "EndNote XML",
+			format: ImportEndNote,
+			content: `<?xml version="1.0" encoding="UTF-8"?><xml><records><record>
+<ref-type name="Journal Article">17</ref-type>
+<contributors><authors><author><style face="normal">Silva, Ana</style></author></authors></contributors>
+<titles><title><style face="normal">Aprendizado </style><style face="italic">profundo</style></title>
+<secondary-title>Nature</secondary-title></titles>
+<pages>1-10</pages><volume>5</volume><dates><year>2017</year><pub-dates><date>Sep</date></pub-dates></dates>
+<electronic-resource-num>10.1038/xyz</electronic-resource-num>
+<keywords><keyword>ia</keyword><keyword>redes</keyword></keywords>
+</record></records></xml>`,
+			expected: []string{
+				"@article{,author={Silva, Ana},title={Aprendizado profundo},journal={Nature},volume={5},pages={1--10},year={2017},month=sep,doi={10.1038/xyz},keywords={ia, redes}}",
+			},
+		},
+		{
+			name:     "BibTeX",
+			format:   ImportBibTeX,
+			content:  `@Article{a, title = {T}, doi = {doi:10.1/X}, pages = {3-4}, note = {}}`,
+			expected: []string{"@article{a,title={T},doi={10.1/X},pages={3--4}}"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entries, err := Import([]byte(tt.content), tt.format)
+			if err != nil {
+				t.Fatalf("Import() error = %v", err)
+			}
+			if len(entries) != len(tt.expected) {
+				t.Fatalf("Import() = %d entradas, expected %d", len(entries), len(tt.expected))
+			}
+			for i, e := range entries {
+				if got := importedEntry(e); got != tt.expected[i] {
+					t.Errorf("Import()[%d] =\n%s\nexpected\n%s", i, got, tt.expected[i])
+				}
+			}
+		})
+	}
+
+	t.Run("resumo em várias linhas", func(t *testing.T) {
+		entries := ParseRIS("TY  - JOUR\nAB  - Um resumo\n  que continua\nER  - \n")
+		if got := entries[0].Get("abstract"); got != "Um resumo que continua" {
+			t.Errorf("abstract = %q, expected %q", got, "Um resumo que continua")
+		}
+	})
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		path     string
+		content  string
+		expected string
+	}{
+		{path: "refs.ris", expected: ImportRIS},
+		{path: "refs.json", expected: ImportCSLJSON},
+		{path: "refs.bib", expected: ImportBibTeX},
+		{path: "export.txt", content: "\nTY  - BOOK\nER  - \n", expected: ImportRIS},
+		{path: "export.xml", content: "<?xml version=\"1.0\"?><xml><records><record>", expected: ImportEndNote},
+		{path: "export", content: "% comentário\n@book{a, title={T}}", expected: ImportBibTeX},
+		{path: "export.txt", content: "texto qualquer", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			result, err := DetectFormat(tt.path, []byte(tt.content))
+			if (err != nil) != (tt.expected == "") {
+				t.Fatalf("DetectFormat() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("DetectFormat(%q) = %q, expected %q", tt.path, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestKeyPattern(t *testing.T) {
+	e := Parse(`@article{x, author = {da Silva, Ana and Souza, João and Lima, C.}, year = 2020, title = {O Aprendizado de Máquina Profundo}}`).Entries[0]
+
+	tests := []struct {
+		pattern  string
+		expected string
+		wantErr  bool
+	}{
+		{pattern: "", expected: "silva2020aprendizado"},
+		{pattern: "{authors}_{year}", expected: "silvaetal_2020"},
+		{pattern: "{author}:{shorttitle}", expected: "silva:aprendizadomaquinaprofundo"},
+		{pattern: "{autor}{year}", wantErr: true},
+		{pattern: "ref", wantErr: true},
+		{pattern: "{author} {year}", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			pattern, err := ParseKeyPattern(tt.pattern)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseKeyPattern(%q) error = %v, wantErr %v", tt.pattern, err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := KeyFromPattern(e, pattern); got != tt.expected {
+				t.Errorf("KeyFromPattern(%q) = %q, expected %q", pattern, got, tt.expected)
+			}
+		})
+	}
+
+	if got := KeyFromPattern(&Entry{}, "{author}-{year}"); got != "ref" {
+		t.Errorf("KeyFromPattern() sem campos = %q, expected ref", got)
+	}
+}
+
+func TestAssignKeysAndIndex(t *testing.T) {
+	existing := Parse(`@article{silva2020redes, author = {Silva, Ana}, year = 2020, title = {Redes}, doi = {10.1/A}}
+@book{lima2019, author = {Lima, C.}, year = 2019, title = {Grafos: uma introdução}}`)
+
+	imported := Parse(`@article{z1, author = {Silva, Ana}, year = 2020, title = {Redes neurais}}
+@article{z2, author = {Outro, B.}, year = 2021, title = {Outro}, doi = {https://doi.org/10.1/a}}
+@book{z3, author = {Lima, C.}, year = 2019, title = {GRAFOS -- Uma Introdução}}
+@article{z4, author = {Silva, Ana}, year = 2020, title = {Redes}, doi = {10.2/B}}`).Entries
+	for _, e := range imported {
+		Normalize(e)
+	}
+
+	idx := NewIndex(existing.Entries)
+	var reasons []string
+	for _, e := range imported {
+		if dup, ok := idx.Find(e); ok {
+			reasons = append(reasons, e.Key+":"+dup.Reason+":"+dup.Entry.Key)
+		}
+	}
+	expected := "z2:doi:silva2020redes z3:title:lima2019"
+	if got := strings.Join(reasons, " "); got != expected {
+		t.Errorf("Index.Find() = %q, expected %q", got, expected)
+	}
+
+	used := map[string]bool{"silva2020redes": true, "lima2019": true}
+	AssignKeys([]*Entry{imported[0], imported[3]}, DefaultKeyPattern, used)
+	if imported[0].Key != "silva2020redesa" || imported[3].Key != "silva2020redesb" {
+		t.Errorf("AssignKeys() = %s, %s; expected silva2020redesa, silva2020redesb", imported[0].Key, imported[3].Key)
+	}
+}
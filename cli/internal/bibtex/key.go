@@ -0,0 +1,154 @@
+package bibtex
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// DefaultKeyPattern é o padrão de chave de 'ltx bib import'
+const DefaultKeyPattern = "{author}{year}{firstword}"
+
+// keyTokens são os campos aceitos em um padrão de chave
+var keyTokens = map[string]func(e *Entry) string{
+	"author":     firstAuthor,
+	"authors":    authorsKey,
+	"year":       Year,
+	"firstword":  func(e *Entry) string { return strings.Join(titleWords(e, 1), "") },
+	"shorttitle": func(e *Entry) string { return strings.Join(titleWords(e, 3), "") },
+}
+
+var keyToken = regexp.MustCompile(`\{([a-z]*)\}`)
+
+// keyLiteral são os caracteres permitidos fora dos campos de um padrão
+var keyLiteral = regexp.MustCompile(`^[A-Za-z0-9_:.-]*$`)
+
+// ParseKeyPattern valida um padrão de chave como {author}{year}{firstword}.
+// Campos: {author} (sobrenome do primeiro autor), {authors} (dois autores ou
+// o primeiro seguido de etal), {year}, {firstword} (primeira palavra
+// significativa do título) e {shorttitle} (as três primeiras). keep mantém
+// as chaves de origem.
+func ParseKeyPattern(pattern string) (string, error) {
+	pattern = strings.TrimSpace(pattern)
+	switch pattern {
+	case "":
+		return DefaultKeyPattern, nil
+	case KeysKeep:
+		return KeysKeep, nil
+	}
+
+	tokens := 0
+	for _, match := range keyToken.FindAllStringSubmatch(pattern, -1) {
+		if _, ok := keyTokens[match[1]]; !ok {
+			return "", fmt.Errorf("padrão de chave inválido: campo desconhecido %s (use {author}, {authors}, {year}, {firstword} ou {shorttitle})", match[0])
+		}
+		tokens++
+	}
+	if tokens == 0 {
+		return "", fmt.Errorf("padrão de chave inválido: %q não tem nenhum campo (ex.: %s)", pattern, DefaultKeyPattern)
+	}
+	if !keyLiteral.MatchString(keyToken.ReplaceAllString(pattern, "")) {
+		return "", fmt.Errorf("padrão de chave inválido: %q (fora dos campos, use apenas letras, números e _ : . -)", pattern)
+	}
+	return pattern, nil
+}
+
+// KeyFromPattern cria a chave de uma entrada a partir de um padrão já
+// validado; campos ausentes na entrada ficam vazios e, se nada sobrar, a chave
+// é "ref"
+func KeyFromPattern(e *Entry, pattern string) string {
+	key := keyToken.ReplaceAllStringFunc(pattern, func(token string) string {
+		if value, ok := keyTokens[token[1:len(token)-1]]; ok {
+			return value(e)
+		}
+		return ""
+	})
+	if key == "" || key == keyToken.ReplaceAllString(pattern, "") {
+		return "ref"
+	}
+	return key
+}
+
+// uniqueKey acrescenta a, b, c... à chave até encontrar uma não usada
+func uniqueKey(key string, used map[string]bool, suffix map[string]int) string {
+	for {
+		candidate := key + string(rune('a'+suffix[key]))
+		suffix[key]++
+		if !used[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
+
+var nonAlnum = regexp.MustCompile(`[^a-z0-9]+`)
+
+// keyWord simplifica uma palavra para uso em chaves: ASCII, minúsculas e só
+// letras e números
+func keyWord(s string) string {
+	return nonAlnum.ReplaceAllString(strings.ToLower(Fold(PlainText(s))), "")
+}
+
+// authorNames retorna os autores ou, sem eles, os editores
+func authorNames(e *Entry) []Name {
+	names := ParseNames(e.Get("author"))
+	if len(names) == 0 {
+		names = ParseNames(e.Get("editor"))
+	}
+	return names
+}
+
+func nameKey(n Name) string {
+	if n.Literal != "" {
+		return keyWord(strings.Fields(n.Literal)[0])
+	}
+	return keyWord(n.Family)
+}
+
+// firstAuthor retorna o sobrenome do primeiro autor para a chave
+func firstAuthor(e *Entry) string {
+	names := authorNames(e)
+	if len(names) == 0 {
+		return ""
+	}
+	return nameKey(names[0])
+}
+
+// authorsKey retorna os sobrenomes de até dois autores ou o primeiro seguido
+// de etal
+func authorsKey(e *Entry) string {
+	names := authorNames(e)
+	switch len(names) {
+	case 0:
+		return ""
+	case 1:
+		return nameKey(names[0])
+	case 2:
+		return nameKey(names[0]) + nameKey(names[1])
+	default:
+		return nameKey(names[0]) + "etal"
+	}
+}
+
+// stopWords são ignoradas ao escolher palavras do título para a chave
+var stopWords = map[string]bool{
+	"a": true, "an": true, "the": true, "of": true, "on": true, "in": true, "for": true, "and": true, "to": true,
+	"o": true, "os": true, "as": true, "um": true, "uma": true, "de": true, "do": true, "da": true, "dos": true,
+	"das": true, "em": true, "no": true, "na": true, "para": true, "e": true, "el": true, "la": true, "los": true,
+}
+
+// titleWords retorna as n primeiras palavras significativas do título
+func titleWords(e *Entry, n int) []string {
+	var words []string
+	for _, word := range strings.FieldsFunc(strings.ToLower(Fold(PlainText(e.Get("title")))), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(words) == n {
+			break
+		}
+		if !stopWords[word] {
+			words = append(words, word)
+		}
+	}
+	return words
+}
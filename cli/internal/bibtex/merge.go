@@ -0,0 +1,96 @@
+package bibtex
+
+import (
+	"strings"
+)
+
+// Conflict é uma chave (ou @string) definida de formas diferentes em dois
+// arquivos; a primeira definição é mantida
+type Conflict struct {
+	Key    string   `json:"key"`
+	Kept   string   `json:"kept"`   // arquivo cuja definição foi mantida
+	Other  string   `json:"other"`  // arquivo cuja definição foi descartada
+	Fields []string `json:"fields"` // campos com valores diferentes (ou "type")
+}
+
+// Merge junta vários bancos na ordem informada. Entradas repetidas com o mesmo
+// conteúdo são unificadas (campos ausentes na primeira são completados pela
+// segunda); entradas com valores diferentes geram um Conflict.
+func Merge(dbs []*Database, files []string) (*Database, []Conflict) {
+	merged := &Database{}
+	var conflicts []Conflict
+
+	byKey := make(map[string]*Entry)
+	stringIndex := make(map[string]int)
+	stringFile := make(map[string]string)
+
+	for i, db := range dbs {
+		file := ""
+		if i < len(files) {
+			file = files[i]
+		}
+
+		merged.Preambles = append(merged.Preambles, db.Preambles...)
+		merged.Comments = append(merged.Comments, db.Comments...)
+		merged.Errors = append(merged.Errors, db.Errors...)
+
+		for _, s := range db.Strings {
+			j, ok := stringIndex[s.Name]
+			if !ok {
+				stringIndex[s.Name] = len(merged.Strings)
+				stringFile[s.Name] = file
+				merged.Strings = append(merged.Strings, s)
+				continue
+			}
+			if normalize(merged.Strings[j].Text) != normalize(s.Text) {
+				conflicts = append(conflicts, Conflict{Key: "@string " + s.Name, Kept: stringFile[s.Name], Other: file, Fields: []string{s.Name}})
+			}
+		}
+
+		for _, e := range db.Entries {
+			key := strings.ToLower(e.Key)
+			kept, ok := byKey[key]
+			if !ok {
+				copied := *e
+				copied.Fields = append([]Field(nil), e.Fields...)
+				copied.File = file
+				byKey[key] = &copied
+				merged.Entries = append(merged.Entries, &copied)
+				continue
+			}
+
+			if differing := diffEntries(kept, e); len(differing) > 0 {
+				conflicts = append(conflicts, Conflict{Key: e.Key, Kept: kept.File, Other: file, Fields: differing})
+				continue
+			}
+			for _, f := range e.Fields {
+				if !kept.Has(f.Name) {
+					kept.Fields = append(kept.Fields, f)
+				}
+			}
+		}
+	}
+
+	return merged, conflicts
+}
+
+// diffEntries lista os campos presentes nas duas entradas com valores
+// diferentes (ignorando chaves, espaços e maiúsculas)
+func diffEntries(a, b *Entry) []string {
+	var differing []string
+	if a.Type != b.Type {
+		differing = append(differing, "type")
+	}
+	for _, f := range a.Fields {
+		other, ok := b.Field(f.Name)
+		if ok && normalize(f.Text) != normalize(other.Text) {
+			differing = append(differing, f.Name)
+		}
+	}
+	return differing
+}
+
+// normalize simplifica um valor para comparação
+func normalize(s string) string {
+	return strings.ToLower(PlainText(s))
+}
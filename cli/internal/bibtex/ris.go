@@ -0,0 +1,160 @@
+package bibtex
+
+import (
+	"regexp"
+	"strings"
+)
+
+// risTypes mapeia os tipos do RIS para os do BibTeX
+var risTypes = map[string]string{
+	"JOUR":    "article",
+	"JFULL":   "article",
+	"MGZN":    "article",
+	"NEWS":    "article",
+	"EJOUR":   "article",
+	"BOOK":    "book",
+	"EBOOK":   "book",
+	"EDBOOK":  "book",
+	"CHAP":    "incollection",
+	"ECHAP":   "incollection",
+	"CONF":    "inproceedings",
+	"CPAPER":  "inproceedings",
+	"THES":    "phdthesis",
+	"RPRT":    "techreport",
+	"UNPB":    "unpublished",
+	"MANSCPT": "unpublished",
+	"PAT":     "patent",
+	"ELEC":    "misc",
+	"WEB":     "misc",
+	"DATA":    "misc",
+	"COMP":    "misc",
+	"GEN":     "misc",
+}
+
+var risLine = regexp.MustCompile(`^([A-Z][A-Z0-9])  -(?: (.*))?$`)
+
+// ParseRIS lê referências no formato RIS (Zotero, Mendeley, EndNote). Cada
+// referência começa em TY e termina em ER; tags repetidas (AU, KW) acumulam
+// valores e linhas sem tag continuam a anterior.
+func ParseRIS(content string) []*Entry {
+	var entries []*Entry
+	var tags map[string][]string
+	last := ""
+
+	flush := func() {
+		if tags != nil {
+			entries = append(entries, risEntry(tags))
+		}
+		tags, last = nil, ""
+	}
+
+	for _, line := range strings.Split(strings.TrimPrefix(content, "\ufeff"), "\n") {
+		line = strings.TrimRight(line, "\r")
+		m := risLine.FindStringSubmatch(line)
+		if m == nil {
+			// Continuação de um valor longo (resumos, notas)
+			if tags != nil && last != "" && strings.TrimSpace(line) != "" {
+				values := tags[last]
+				values[len(values)-1] += " " + strings.TrimSpace(line)
+			}
+			continue
+		}
+
+		tag, value := m[1], strings.TrimSpace(m[2])
+		switch tag {
+		case "TY":
+			flush()
+			tags = map[string][]string{"TY": {value}}
+		case "ER":
+			flush()
+		default:
+			if tags == nil {
+				continue
+			}
+			tags[tag] = append(tags[tag], value)
+			last = tag
+		}
+	}
+	flush()
+
+	return entries
+}
+
+// risEntry converte as tags de uma referência RIS em uma entrada
+func risEntry(tags map[string][]string) *Entry {
+	first := func(names ...string) string {
+		for _, name := range names {
+			for _, value := range tags[name] {
+				if value != "" {
+					return value
+				}
+			}
+		}
+		return ""
+	}
+
+	risType := strings.ToUpper(first("TY"))
+	e := &Entry{Type: "misc", Key: first("ID")}
+	if t, ok := risTypes[risType]; ok {
+		e.Type = t
+	}
+
+	addNames(e, "author", append(append([]string(nil), tags["AU"]...), tags["A1"]...))
+	editors := append(append([]string(nil), tags["ED"]...), tags["A3"]...)
+	if e.Type != "article" {
+		editors = append(editors, tags["A2"]...)
+	}
+	addNames(e, "editor", editors)
+
+	addField(e, "title", first("TI", "T1", "CT"))
+
+	container := first("T2", "JF", "JO", "JA", "J2", "BT")
+	switch e.Type {
+	case "article":
+		addField(e, "journal", container)
+	case "incollection", "inproceedings":
+		addField(e, "booktitle", container)
+	case "book", "techreport":
+		addField(e, "series", first("T3", "T2"))
+	default:
+		addField(e, "howpublished", container)
+	}
+
+	addField(e, "volume", first("VL"))
+	addField(e, "number", first("IS", "M1"))
+	if start, end := first("SP"), first("EP"); start != "" && end != "" && start != end {
+		addField(e, "pages", start+"--"+end)
+	} else {
+		addField(e, "pages", start)
+	}
+	addField(e, "edition", first("ET"))
+
+	publisher := first("PB")
+	switch e.Type {
+	case "phdthesis":
+		addField(e, "school", publisher)
+	case "techreport":
+		addField(e, "institution", publisher)
+	default:
+		addField(e, "publisher", publisher)
+	}
+	addField(e, "address", first("CY", "PP"))
+
+	addDate(e, first("PY", "Y1", "DA", "Y2"))
+
+	if isbn := first("SN"); isbn != "" {
+		if e.Type == "article" {
+			addField(e, "issn", isbn)
+		} else {
+			addField(e, "isbn", isbn)
+		}
+	}
+	addField(e, "doi", first("DO"))
+	addField(e, "url", first("UR", "L2"))
+	addField(e, "language", first("LA"))
+	addField(e, "keywords", strings.Join(tags["KW"], ", "))
+	addField(e, "abstract", first("AB", "N2"))
+	addField(e, "note", first("N1"))
+
+	return e
+}
@@ -0,0 +1,319 @@
+package bibtex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// accents mapeia cada comando de acento às letras acentuadas: a letra base
+// na posição i de accentBase vira a runa na posição i do valor
+var accents = map[string][2]string{
+	"'":  {"aeiouyAEIOUYcnszCNSZ", "áéíóúýÁÉÍÓÚÝćńśźĆŃŚŹ"},
+	"`":  {"aeiouAEIOU", "àèìòùÀÈÌÒÙ"},
+	"^":  {"aeiouAEIOU", "âêîôûÂÊÎÔÛ"},
+	"\"": {"aeiouyAEIOUY", "äëïöüÿÄËÏÖÜŸ"},
+	"~":  {"anoANO", "ãñõÃÑÕ"},
+	"c":  {"cCsStT", "çÇşŞţŢ"},
+	"v":  {"cCsSzZrReEnN", "čČšŠžŽřŘěĚňŇ"},
+	"u":  {"aAgG", "ăĂğĞ"},
+	"H":  {"oOuU", "őŐűŰ"},
+	"=":  {"aeiouAEIOU", "āēīōūĀĒĪŌŪ"},
+	".":  {"zZeE", "żŻėĖ"},
+	"r":  {"aAuU", "åÅůŮ"},
+	"k":  {"aAeE", "ąĄęĘ"},
+}
+
+// symbols são comandos sem argumento que produzem um caractere
+var symbols = map[string]string{
+	"ss": "ß", "o": "ø", "O": "Ø", "ae": "æ", "AE": "Æ", "oe": "œ", "OE": "Œ",
+	"aa": "å", "AA": "Å", "l": "ł", "L": "Ł", "i": "ı", "j": "ȷ",
+	"textendash": "–", "textemdash": "—", "textquoteleft": "‘", "textquoteright": "’",
+	"ldots": "…", "dots": "…", "textregistered": "®", "copyright": "©", "LaTeX": "LaTeX", "TeX": "TeX",
+}
+
+// accentFor retorna a letra acentuada, ou a letra seguida do acento combinante
+func accentFor(accent string, base string) string {
+	if table, ok := accents[accent]; ok {
+		if i := strings.Index(table[0], base); i >= 0 && len(base) == 1 {
+			return string([]rune(table[1])[i])
+		}
+	}
+	return base
+}
+
+// PlainText converte um valor em LaTeX para texto: acentos viram caracteres
+// Unicode, comandos de formatação são removidos (mantendo o argumento), as
+// chaves desaparecem e os espaços são normalizados
+func PlainText(s string) string {
+	var out strings.Builder
+
+	for i := 0; i < len(s); {
+		c := s[i]
+		switch {
+		case c == '\\' && i+1 < len(s):
+			next := s[i+1]
+			if _, ok := accents[string(next)]; ok && !isLetter(next) {
+				base, end := accentArg(s, i+2)
+				out.WriteString(accentFor(string(next), base))
+				i = end
+				continue
+			}
+
+			name := ""
+			j := i + 1
+			for j < len(s) && isLetter(s[j]) {
+				j++
+			}
+			name = s[i+1 : j]
+
+			switch {
+			case name == "":
+				// Símbolo escapado: \& \% \$ \_ \# \{ \}
+				if strings.IndexByte(`&%$_#{}`, next) >= 0 {
+					out.WriteByte(next)
+				} else if next == '\\' || next == ' ' {
+					out.WriteByte(' ')
+				}
+				i += 2
+			case len(name) == 1 && accents[name][0] != "" && j < len(s) && (s[j] == '{' || s[j] == ' '):
+				// \c{c}, \v s
+				base, end := accentArg(s, j)
+				out.WriteString(accentFor(name, base))
+				i = end
+			case symbols[name] != "":
+				out.WriteString(symbols[name])
+				i = j
+				if i < len(s) && s[i] == ' ' {
+					i++ // \ss x: o espaço termina o comando
+				}
+			default:
+				// Outros comandos: descartar o nome e manter os argumentos
+				i = j
+			}
+
+		case c == '{' || c == '}':
+			i++
+
+		case c == '~':
+			out.WriteByte(' ')
+			i++
+
+		case strings.HasPrefix(s[i:], "---"):
+			out.WriteString("—")
+			i += 3
+
+		case strings.HasPrefix(s[i:], "--"):
+			out.WriteString("–")
+			i += 2
+
+		case strings.HasPrefix(s[i:], "``"):
+			out.WriteString("“")
+			i += 2
+
+		case strings.HasPrefix(s[i:], "''"):
+			out.WriteString("”")
+			i += 2
+
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	return strings.Join(strings.Fields(out.String()), " ")
+}
+
+// accentArg lê a letra acentuada a partir de i: "e", "{e}", " e", "{\i}" ou "\i"
+func accentArg(s string, i int) (string, int) {
+	for i < len(s) && s[i] == ' ' {
+		i++
+	}
+	braced := i < len(s) && s[i] == '{'
+	if braced {
+		i++
+	}
+	if i >= len(s) {
+		return "", i
+	}
+
+	var base string
+	if s[i] == '\\' && i+1 < len(s) && (s[i+1] == 'i' || s[i+1] == 'j') {
+		base = string(s[i+1]) // \'\i = í
+		i += 2
+		if !braced && i < len(s) && s[i] == ' ' {
+			i++ // o espaço termina o comando \i
+		}
+	} else {
+		_, size := utf8.DecodeRuneInString(s[i:])
+		base = s[i : i+size]
+		i += size
+	}
+
+	if braced && i < len(s) && s[i] == '}' {
+		i++
+	}
+	return base, i
+}
+
+// folds são as letras que não se decompõem em letra base + acento
+var folds = map[rune]string{
+	'ß': "ss", 'ø': "o", 'Ø': "O", 'æ': "ae", 'Æ': "AE", 'œ': "oe", 'Œ': "OE",
+	'ł': "l", 'Ł': "L", 'ı': "i", 'ȷ': "j",
+}
+
+// Fold converte o texto para ASCII, removendo acentos (útil para chaves)
+func Fold(s string) string {
+	var out strings.Builder
+	for _, r := range s {
+		if r < utf8.RuneSelf {
+			out.WriteRune(r)
+			continue
+		}
+		if folded, ok := folds[r]; ok {
+			out.WriteString(folded)
+			continue
+		}
+		for _, table := range accents {
+			if i := strings.IndexRune(table[1], r); i >= 0 {
+				out.WriteByte(table[0][utf8.RuneCountInString(table[1][:i])])
+				break
+			}
+		}
+	}
+	return out.String()
+}
+
+// Name é um nome de pessoa de um campo author ou editor
+type Name struct {
+	Given    string // nome
+	Particle string // von, da, de
+	Family   string // sobrenome
+	Suffix   string // Jr.
+	Literal  string // nome institucional entre chaves: {Organização Mundial da Saúde}
+}
+
+// ParseNames separa uma lista de nomes do BibTeX ("Silva, Ana and João de
+// Souza and {IEEE}"). "others" (et al.) é ignorado.
+func ParseNames(s string) []Name {
+	var names []Name
+	for _, raw := range splitTopLevel(s, " and ") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || raw == "others" {
+			continue
+		}
+		names = append(names, parseName(raw))
+	}
+	return names
+}
+
+func parseName(raw string) Name {
+	if strings.HasPrefix(raw, "{") && matchBrace(raw, 0) == len(raw)-1 {
+		return Name{Literal: PlainText(raw)}
+	}
+
+	parts := splitTopLevel(raw, ",")
+	for i := range parts {
+		parts[i] = strings.TrimSpace(parts[i])
+	}
+
+	var name Name
+	var lastPart string
+	switch len(parts) {
+	case 1:
+		// Nome von Sobrenome
+		words := nameWords(parts[0])
+		if len(words) == 1 {
+			return Name{Family: PlainText(words[0])}
+		}
+
+		von := -1
+		for i := 0; i < len(words)-1; i++ {
+			if isLowerWord(words[i]) {
+				von = i
+				break
+			}
+		}
+		if von < 0 {
+			name.Given = PlainText(strings.Join(words[:len(words)-1], " "))
+			name.Family = PlainText(words[len(words)-1])
+			return name
+		}
+		name.Given = PlainText(strings.Join(words[:von], " "))
+		lastPart = strings.Join(words[von:], " ")
+	case 2:
+		// von Sobrenome, Nome
+		lastPart, name.Given = parts[0], PlainText(parts[1])
+	default:
+		// von Sobrenome, Jr, Nome
+		lastPart, name.Suffix, name.Given = parts[0], PlainText(parts[1]), PlainText(strings.Join(parts[2:], ", "))
+	}
+
+	// Separa as partículas (palavras minúsculas iniciais) do sobrenome
+	words := nameWords(lastPart)
+	i := 0
+	for i < len(words)-1 && isLowerWord(words[i]) {
+		i++
+	}
+	name.Particle = PlainText(strings.Join(words[:i], " "))
+	name.Family = PlainText(strings.Join(words[i:], " "))
+	return name
+}
+
+// String retorna o nome na forma "Sobrenome, Nome"
+func (n Name) String() string {
+	if n.Literal != "" {
+		return n.Literal
+	}
+	family := strings.TrimSpace(n.Particle + " " + n.Family)
+	if n.Given == "" {
+		return family
+	}
+	return family + ", " + n.Given
+}
+
+// nameWords separa as palavras de um nome, sem quebrar grupos entre chaves
+func nameWords(s string) []string {
+	var words []string
+	for _, word := range splitTopLevel(strings.Join(strings.Fields(s), " "), " ") {
+		if word != "" {
+			words = append(words, word)
+		}
+	}
+	return words
+}
+
+// isLowerWord indica se a palavra começa com minúscula fora de chaves (von, da)
+func isLowerWord(word string) bool {
+	for _, r := range word {
+		if r == '{' || r == '\\' {
+			return false
+		}
+		if unicode.IsLetter(r) {
+			return unicode.IsLower(r)
+		}
+	}
+	return false
+}
+
+// splitTopLevel separa s pelo separador fora de chaves, sem diferenciar
+// maiúsculas (" AND ")
+func splitTopLevel(s, sep string) []string {
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+		default:
+			if depth == 0 && i+len(sep) <= len(s) && strings.EqualFold(s[i:i+len(sep)], sep) {
+				parts = append(parts, s[start:i])
+				start = i + len(sep)
+				i += len(sep) - 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
@@ -0,0 +1,50 @@
+package bibtex
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{`Jos{\'e} da Concei\c{c}\~ao`, "José da Conceição"},
+		{`M\"{u}ller and \AE{}sir \ss`, "Müller and Æsir ß"},
+		{`Na\"\i ve \'\i ndice`, "Naïve índice"},
+		{`The {\LaTeX} \emph{Companion}`, "The LaTeX Companion"},
+		{`P\&D: 10\% --- 20--30`, "P&D: 10% — 20–30"},
+		{"Quebra\n   de~linha", "Quebra de linha"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			if got := PlainText(tt.input); got != tt.expected {
+				t.Errorf("PlainText() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFold(t *testing.T) {
+	if got := Fold("Conceição Müller Łukasz Strauß"); got != "Conceicao Muller Lukasz Strauss" {
+		t.Errorf("Fold() = %q", got)
+	}
+}
+
+func TestParseNames(t *testing.T) {
+	names := ParseNames(`Silva, Ana Maria and Ludwig van Beethoven and de la Fontaine, Jean and King, Jr, Martin Luther and {World Health Organization} and Jos{\'e} Sousa and others`)
+
+	expected := []Name{
+		{Given: "Ana Maria", Family: "Silva"},
+		{Given: "Ludwig", Particle: "van", Family: "Beethoven"},
+		{Given: "Jean", Particle: "de la", Family: "Fontaine"},
+		{Given: "Martin Luther", Family: "King", Suffix: "Jr"},
+		{Literal: "World Health Organization"},
+		{Given: "José", Family: "Sousa"},
+	}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("ParseNames() = %+v, expected %+v", names, expected)
+	}
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
 )
 
 var (
@@ -22,6 +23,7 @@ var BackupCmd = &cobra.Command{
 
 - Copia toda a pasta src/ (arquivos LaTeX)
 - Copia PDFs da pasta dist/
+- Copia os logs da última compilação (AUX_DIR, padrão tmp/)
 - Salva em uma pasta um nível acima do repositório
 - Nome automático com timestamp ou nome customizado
 
@@ -77,15 +79,16 @@ func createBackup() error {
 		backupCount++
 	}
 
-	// 2. Copiar PDFs da pasta dist/
-	if _, err := os.Stat("dist"); err == nil {
+	// 2. Copiar PDFs do diretório de saída (OUTPUT_DIR, padrão dist/)
+	outputDir := config.GetOutputDir()
+	if _, err := os.Stat(outputDir); err == nil {
 		distBackupPath := filepath.Join(backupPath, "dist")
 		if err := os.MkdirAll(distBackupPath, 0755); err != nil {
 			return fmt.Errorf("erro ao criar pasta dist no backup: %w", err)
 		}
 
 		// Copiar apenas arquivos PDF
-		pdfFiles, err := filepath.Glob("dist/*.pdf")
+		pdfFiles, err := filepath.Glob(filepath.Join(outputDir, "*.pdf"))
 		if err == nil && len(pdfFiles) > 0 {
 			for _, pdfFile := range pdfFiles {
 				fileName := filepath.Base(pdfFile)
@@ -100,7 +103,28 @@ func createBackup() error {
 		}
 	}
 
-	// 3. Criar arquivo de informações do backup
+	// 3. Copiar os logs da última compilação, que ficam com os auxiliares
+	auxPath := mainAuxDir()
+	logFiles, _ := filepath.Glob(filepath.Join(auxPath, "*.log"))
+	blgFiles, _ := filepath.Glob(filepath.Join(auxPath, "*.blg"))
+	if logFiles = append(logFiles, blgFiles...); len(logFiles) > 0 {
+		logsBackupPath := filepath.Join(backupPath, "logs")
+		if err := os.MkdirAll(logsBackupPath, 0755); err != nil {
+			return fmt.Errorf("erro ao criar pasta logs no backup: %w", err)
+		}
+
+		for _, logFile := range logFiles {
+			fileName := filepath.Base(logFile)
+			destPath := filepath.Join(logsBackupPath, fileName)
+			if err := copyFile(logFile, destPath); err != nil {
+				colors.PrintWarning(fmt.Sprintf("Aviso: não foi possível copiar %s", fileName))
+			} else {
+				colors.Printf("[COPIED] %s → %s\n", logFile, destPath)
+			}
+		}
+	}
+
+	// 4. Criar arquivo de informações do backup
 	if err := createBackupInfo(backupPath); err != nil {
 		colors.PrintWarning(fmt.Sprintf("Aviso: não foi possível criar arquivo de informações: %v", err))
 	}
@@ -122,7 +146,7 @@ func hasContentToBackup() bool {
 	}
 
 	// Verificar se existe pasta dist com PDFs
-	if pdfFiles, err := filepath.Glob("dist/*.pdf"); err == nil && len(pdfFiles) > 0 {
+	if pdfFiles, err := filepath.Glob(filepath.Join(config.GetOutputDir(), "*.pdf")); err == nil && len(pdfFiles) > 0 {
 		return true
 	}
 
@@ -243,6 +267,7 @@ Diretório Original: %s
 Conteúdo do Backup:
 - src/: Arquivos LaTeX do projeto
 - dist/: PDFs compilados
+- logs/: logs da última compilação (quando existem)
 
 Para restaurar:
 1. Copie o conteúdo de src/ de volta para o projeto
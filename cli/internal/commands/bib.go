@@ -0,0 +1,507 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lint"
+)
+
+var (
+	bibLintFormat   string
+	bibLintDisable  []string
+	bibFormatCheck  bool
+	bibFormatKeys   string
+	bibFormatSort   bool
+	bibFormatIndent int
+	bibUnusedJSON   bool
+	bibMergeOutput  string
+	bibExportOutput string
+	bibExportFormat string
+	bibImportFormat string
+	bibImportKeys   string
+	bibImportTo     string
+	bibImportDryRun bool
+	bibImportJSON   bool
+)
+
+var BibCmd = &cobra.Command{
+	Use:   "bib",
+	Short: "Ferramentas para os arquivos de referências (.bib)",
+	Long: `Verifica, formata, junta e converte os arquivos .bib do projeto.
+
+Sem arquivos como argumento, os comandos usam os .bib referenciados pelo
+documento (\bibliography e \addbibresource em src/main.tex).`,
+}
+
+var bibLintCmd = &cobra.Command{
+	Use:   "lint [arquivo.bib...]",
+	Short: "Verifica os arquivos .bib",
+	Long: `Verifica erros de sintaxe, chaves duplicadas, campos obrigatórios ausentes
+para o tipo de cada entrada (BibTeX e BibLaTeX), DOIs repetidos e títulos iguais
+ou quase iguais em entradas diferentes.
+
+Regras: bib-syntax, bib-duplicate-key, bib-missing-field, bib-unknown-type,
+bib-duplicate-doi e bib-similar-title. O comando falha quando há resultados de
+nível error.`,
+	Example: `  ltx bib lint
+  ltx bib lint src/refs.bib --disable bib-similar-title`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibLint(args, bibLintFormat)
+	},
+}
+
+var bibFormatCmd = &cobra.Command{
+	Use:   "format [arquivo.bib...]",
+	Short: "Formata os arquivos .bib em forma canônica",
+	Long: `Reescreve os arquivos .bib com tipos e campos em minúsculas, campos em ordem
+canônica (author, editor, title, ..., year, doi, url, ...), "=" alinhados e
+valores entre chaves. @string e @preamble vão para o início e @comment para o
+fim; texto solto fora de entradas é descartado.
+
+Com --keys, as chaves são regeradas a partir do primeiro autor e do ano
+(authoryear: silva2020) e, opcionalmente, da primeira palavra do título
+(authoryeartitle: silva2020aprendizado). As citações nos fontes do documento
+são atualizadas com as novas chaves.
+
+Com --check nada é alterado: o comando falha se algum arquivo não estiver
+formatado (útil em CI).`,
+	Example: `  ltx bib format
+  ltx bib format --check
+  ltx bib format --keys authoryear --sort`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibFormat(args)
+	},
+}
+
+var bibUnusedCmd = &cobra.Command{
+	Use:   "unused [arquivo.bib...]",
+	Short: "Lista as entradas nunca citadas no documento",
+	Long: `Lista as entradas dos arquivos .bib que não são citadas (\cite e variantes,
+\nocite) em nenhum arquivo do documento. Com \nocite{*} todas as entradas
+são consideradas usadas.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibUnused(args, bibUnusedJSON)
+	},
+}
+
+var bibMergeCmd = &cobra.Command{
+	Use:   "merge <arquivo.bib> <arquivo.bib>...",
+	Short: "Junta vários arquivos .bib",
+	Long: `Junta os arquivos na ordem informada, em forma canônica. Entradas com a mesma
+chave e o mesmo conteúdo são unificadas; quando os valores diferem, a primeira
+definição é mantida e o conflito é relatado com os campos divergentes.`,
+	Example: `  ltx bib merge src/refs.bib ~/zotero.bib -o src/refs.bib`,
+	Args:         cobra.MinimumNArgs(2),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibMerge(args, bibMergeOutput)
+	},
+}
+
+var bibExportCmd = &cobra.Command{
+	Use:   "export [arquivo.bib...]",
+	Short: "Converte as referências para CSL-JSON",
+	Long: `Converte as entradas para CSL-JSON, o formato usado pelo pandoc, Zotero e
+citeproc. Acentos e comandos do LaTeX são convertidos para texto Unicode.`,
+	Example: `  ltx bib export -o referencias.json`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibExport(args, bibExportFormat, bibExportOutput)
+	},
+}
+
+var bibImportCmd = &cobra.Command{
+	Use:   "import <arquivo>...",
+	Short: "Importa referências de RIS, EndNote XML, CSL-JSON ou BibTeX",
+	Long: `Converte referências exportadas pelo Zotero, Mendeley ou EndNote (RIS,
+EndNote XML e CSL-JSON) ou outros arquivos .bib em entradas BibTeX
+normalizadas e as acrescenta ao .bib do projeto, sem acesso à rede.
+
+O formato é detectado pela extensão (.ris, .json, .bib) ou pelo conteúdo. As
+chaves seguem BIB_KEY_PATTERN (ou --keys), com os campos {author}, {authors},
+{year}, {firstword} e {shorttitle}; keep mantém as chaves do arquivo de
+origem. Chaves repetidas recebem os sufixos a, b, c...
+
+Referências que já existem no projeto (mesmo DOI ou mesmo título e ano) são
+ignoradas e relatadas. O destino padrão é o primeiro .bib do documento ou
+src/references.bib.`,
+	Example: `  ltx bib import ~/Downloads/zotero.ris
+  ltx bib import mendeley.json --keys "{authors}{year}"
+  ltx bib import export.xml --to src/extra.bib --dry-run`,
+	Args:         cobra.MinimumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runBibImport(args)
+	},
+}
+
+func init() {
+	BibCmd.AddCommand(bibLintCmd)
+	BibCmd.AddCommand(bibFormatCmd)
+	BibCmd.AddCommand(bibUnusedCmd)
+	BibCmd.AddCommand(bibMergeCmd)
+	BibCmd.AddCommand(bibExportCmd)
+	BibCmd.AddCommand(bibImportCmd)
+
+	bibLintCmd.Flags().StringVar(&bibLintFormat, "format", "text", "Formato da saída: text ou json")
+	bibLintCmd.Flags().StringSliceVar(&bibLintDisable, "disable", nil, "Regras a desativar")
+	bibFormatCmd.Flags().BoolVar(&bibFormatCheck, "check", false, "Apenas verifica se os arquivos estão formatados")
+	bibFormatCmd.Flags().StringVar(&bibFormatKeys, "keys", bibtex.KeysKeep, "Estilo das chaves: keep, authoryear ou authoryeartitle")
+	bibFormatCmd.Flags().BoolVar(&bibFormatSort, "sort", false, "Ordena as entradas pela chave")
+	bibFormatCmd.Flags().IntVar(&bibFormatIndent, "indent", 2, "Espaços de indentação dos campos")
+	bibUnusedCmd.Flags().BoolVar(&bibUnusedJSON, "json", false, "Saída em JSON")
+	bibMergeCmd.Flags().StringVarP(&bibMergeOutput, "output", "o", "", "Arquivo de saída (padrão: saída padrão)")
+	bibExportCmd.Flags().StringVarP(&bibExportOutput, "output", "o", "", "Arquivo de saída (padrão: saída padrão)")
+	bibExportCmd.Flags().StringVar(&bibExportFormat, "format", "csl-json", "Formato: csl-json")
+	bibImportCmd.Flags().StringVar(&bibImportFormat, "format", bibtex.ImportAuto, "Formato: auto, bibtex, ris, endnote ou csl-json")
+	bibImportCmd.Flags().StringVar(&bibImportKeys, "keys", "", "Padrão das chaves (padrão: BIB_KEY_PATTERN da configuração)")
+	bibImportCmd.Flags().StringVar(&bibImportTo, "to", "", "Arquivo .bib de destino (padrão: primeiro .bib do documento)")
+	bibImportCmd.Flags().BoolVar(&bibImportDryRun, "dry-run", false, "Mostra o que seria importado sem gravar")
+	bibImportCmd.Flags().BoolVar(&bibImportJSON, "json", false, "Saída em JSON")
+}
+
+// mainProject carrega o documento principal do projeto
+func mainProject() (*latex.Project, error) {
+	target := filepath.Join(config.GetSourceDir(), "main.tex")
+	project, err := latex.Load(target)
+	if err != nil {
+		return nil, fmt.Errorf("arquivo %s não encontrado", target)
+	}
+	return project, nil
+}
+
+// bibPaths retorna os arquivos informados ou, sem argumentos, os .bib do documento
+func bibPaths(args []string) ([]string, error) {
+	if len(args) > 0 {
+		return args, nil
+	}
+
+	project, err := mainProject()
+	if err != nil {
+		return nil, fmt.Errorf("%w; informe os arquivos .bib", err)
+	}
+	paths := project.BibFiles()
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("nenhum arquivo .bib referenciado pelo documento; informe os arquivos")
+	}
+	return paths, nil
+}
+
+// loadBib lê os arquivos e retorna cada banco e a união das entradas
+func loadBib(paths []string) ([]*bibtex.Database, *bibtex.Database, error) {
+	all := &bibtex.Database{}
+	var dbs []*bibtex.Database
+
+	for _, path := range paths {
+		db, err := bibtex.ParseFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+		}
+		dbs = append(dbs, db)
+		all.Entries = append(all.Entries, db.Entries...)
+		all.Errors = append(all.Errors, db.Errors...)
+	}
+	return dbs, all, nil
+}
+
+func runBibLint(args []string, format string) error {
+	if format != "text" && format != "json" {
+		return fmt.Errorf("formato inválido: %q (use text ou json)", format)
+	}
+
+	disabled := make(map[string]bool)
+	for _, rule := range bibLintDisable {
+		if !isBibRule(rule) {
+			return fmt.Errorf("regra desconhecida: %q", rule)
+		}
+		disabled[rule] = true
+	}
+
+	paths, err := bibPaths(args)
+	if err != nil {
+		return err
+	}
+	_, db, err := loadBib(paths)
+	if err != nil {
+		return err
+	}
+
+	report := lintReport{Target: strings.Join(paths, ", "), Findings: lint.CheckBib(db, disabled)}
+	if report.Findings == nil {
+		report.Findings = []lint.Finding{}
+	}
+
+	if format == "json" {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		printLintReport(os.Stdout, report)
+	}
+
+	if errorCount := countLevel(report.Findings, lint.LevelError); errorCount > 0 {
+		return fmt.Errorf("bib lint encontrou %d erro(s)", errorCount)
+	}
+	return nil
+}
+
+func isBibRule(id string) bool {
+	for _, rule := range lint.BibRules {
+		if rule.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+func runBibFormat(args []string) error {
+	style, err := bibtex.ParseKeyStyle(bibFormatKeys)
+	if err != nil {
+		return err
+	}
+	if bibFormatIndent < 0 {
+		return fmt.Errorf("--indent deve ser positivo")
+	}
+
+	paths, err := bibPaths(args)
+	if err != nil {
+		return err
+	}
+	dbs, all, err := loadBib(paths)
+	if err != nil {
+		return err
+	}
+	if len(all.Errors) > 0 {
+		return fmt.Errorf("%v (corrija os erros de sintaxe antes de formatar: 'ltx bib lint')", all.Errors[0])
+	}
+
+	// As chaves são geradas sobre todos os arquivos para não repetir entre eles
+	renames := bibtex.RenameKeys(all, style)
+	opts := bibtex.FormatOptions{Indent: strings.Repeat(" ", bibFormatIndent), Sort: bibFormatSort}
+
+	var unformatted []string
+	for i, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		formatted := bibtex.Format(dbs[i], opts)
+		if formatted == string(data) {
+			continue
+		}
+		unformatted = append(unformatted, path)
+
+		if bibFormatCheck {
+			continue
+		}
+		if err := os.WriteFile(path, []byte(formatted), 0644); err != nil {
+			return fmt.Errorf("erro ao gravar %s: %w", path, err)
+		}
+		colors.PrintSuccess(fmt.Sprintf("Formatado: %s", path))
+	}
+
+	if bibFormatCheck {
+		if len(unformatted) > 0 {
+			for _, path := range unformatted {
+				fmt.Printf("✗ %s\n", path)
+			}
+			return fmt.Errorf("%d arquivo(s) .bib fora do formato; execute 'ltx bib format'", len(unformatted))
+		}
+		colors.PrintSuccess("Arquivos .bib já formatados")
+		return nil
+	}
+
+	if len(renames) > 0 {
+		return renameCitations(renames)
+	}
+	if len(unformatted) == 0 {
+		colors.PrintInfo("Arquivos .bib já formatados")
+	}
+	return nil
+}
+
+// renameCitations atualiza as citações dos fontes do documento após a troca
+// de chaves
+func renameCitations(renames map[string]string) error {
+	olds := make([]string, 0, len(renames))
+	for old := range renames {
+		olds = append(olds, old)
+	}
+	sort.Strings(olds)
+	for _, old := range olds {
+		colors.PrintChange(fmt.Sprintf("%s -> %s", old, renames[old]))
+	}
+
+	project, err := mainProject()
+	if err != nil {
+		colors.PrintWarn(fmt.Sprintf("Citações não atualizadas: %v", err))
+		return nil
+	}
+
+	for _, f := range project.Files {
+		updated, count := latex.ReplaceCitationKeys(f.Source, renames)
+		if count == 0 {
+			continue
+		}
+		if err := os.WriteFile(f.Path, []byte(updated), 0644); err != nil {
+			return fmt.Errorf("erro ao gravar %s: %w", f.Path, err)
+		}
+		colors.PrintSuccess(fmt.Sprintf("%d citação(ões) atualizada(s) em %s", count, f.Path))
+	}
+	return nil
+}
+
+// unusedEntry é uma entrada nunca citada (saída de --json)
+type unusedEntry struct {
+	Key  string `json:"key"`
+	Type string `json:"type"`
+	File string `json:"file"`
+	Line int    `json:"line"`
+}
+
+func runBibUnused(args []string, asJSON bool) error {
+	project, err := mainProject()
+	if err != nil {
+		return err
+	}
+	paths := args
+	if len(paths) == 0 {
+		if paths = project.BibFiles(); len(paths) == 0 {
+			return fmt.Errorf("nenhum arquivo .bib referenciado pelo documento; informe os arquivos")
+		}
+	}
+	_, db, err := loadBib(paths)
+	if err != nil {
+		return err
+	}
+
+	unused := unusedEntries(project, db)
+	if asJSON {
+		data, err := json.MarshalIndent(unused, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(unused) == 0 {
+		fmt.Println("✓ Todas as entradas são citadas")
+		return nil
+	}
+	for _, e := range unused {
+		fmt.Printf("%s:%d: %s (@%s)\n", e.File, e.Line, e.Key, e.Type)
+	}
+	fmt.Printf("\n%d de %d entrada(s) nunca citada(s)\n", len(unused), len(db.Entries))
+	return nil
+}
+
+// unusedEntries lista as entradas não citadas pelo documento
+func unusedEntries(project *latex.Project, db *bibtex.Database) []unusedEntry {
+	cited := make(map[string]bool)
+	for _, key := range project.CitedKeys() {
+		cited[strings.ToLower(key)] = true
+	}
+	for _, cmd := range project.Commands() {
+		if cmd.Name != "nocite" {
+			continue
+		}
+		for _, key := range cmd.Keys() {
+			if key == "*" {
+				return []unusedEntry{}
+			}
+			cited[strings.ToLower(key)] = true
+		}
+	}
+
+	unused := []unusedEntry{}
+	for _, e := range db.Entries {
+		if !cited[strings.ToLower(e.Key)] {
+			unused = append(unused, unusedEntry{Key: e.Key, Type: e.Type, File: e.File, Line: e.Line})
+		}
+	}
+	return unused
+}
+
+func runBibMerge(paths []string, output string) error {
+	dbs, all, err := loadBib(paths)
+	if err != nil {
+		return err
+	}
+	if len(all.Errors) > 0 {
+		return fmt.Errorf("%v (corrija os erros de sintaxe antes de juntar: 'ltx bib lint')", all.Errors[0])
+	}
+
+	merged, conflicts := bibtex.Merge(dbs, paths)
+	for _, c := range conflicts {
+		message := fmt.Sprintf("Conflito em %s: %s difere entre %s e %s (mantido de %s)", c.Key, strings.Join(c.Fields, ", "), c.Kept, c.Other, c.Kept)
+		fmt.Fprintln(os.Stderr, colors.Colorize("[WARN] "+message))
+	}
+
+	if err := writeOutput(output, bibtex.Format(merged, bibtex.FormatOptions{})); err != nil {
+		return err
+	}
+	if output != "" {
+		colors.PrintSuccess(fmt.Sprintf("%d entrada(s) gravada(s) em %s (%d conflito(s))", len(merged.Entries), output, len(conflicts)))
+	}
+	return nil
+}
+
+func runBibExport(args []string, format, output string) error {
+	if format != "csl-json" {
+		return fmt.Errorf("formato inválido: %q (use csl-json)", format)
+	}
+
+	paths, err := bibPaths(args)
+	if err != nil {
+		return err
+	}
+	_, db, err := loadBib(paths)
+	if err != nil {
+		return err
+	}
+	for _, syntaxErr := range db.Errors {
+		fmt.Fprintln(os.Stderr, colors.Colorize("[WARN] "+syntaxErr.Error()))
+	}
+
+	data, err := json.MarshalIndent(bibtex.ToCSL(db), "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := writeOutput(output, string(data)+"\n"); err != nil {
+		return err
+	}
+	if output != "" {
+		colors.PrintSuccess(fmt.Sprintf("%d referência(s) exportada(s) para %s", len(db.Entries), output))
+	}
+	return nil
+}
+
+// writeOutput grava em um arquivo ou, sem nome, na saída padrão
+func writeOutput(path, content string) error {
+	if path == "" {
+		_, err := io.WriteString(os.Stdout, content)
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return nil
+}
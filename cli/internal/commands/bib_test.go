@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+func TestUnusedEntries(t *testing.T) {
+	tests := []struct {
+		name     string
+		main     string
+		expected []string
+	}{
+		{name: "citadas e nocite", main: "\\cite{a}\\textcite{B}\\nocite{c}", expected: []string{"d"}},
+		{name: "comentário não conta", main: "\\cite{a}\n% \\cite{b}", expected: []string{"b", "c", "d"}},
+		{name: "nocite{*}", main: "\\nocite{*}", expected: nil},
+	}
+
+	db := bibtex.Parse("@misc{a,}\n@misc{b,}\n@misc{c,}\n@misc{d,}")
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "main.tex")
+			if err := os.WriteFile(path, []byte(tt.main), 0644); err != nil {
+				t.Fatal(err)
+			}
+			project, err := latex.Load(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var keys []string
+			for _, e := range unusedEntries(project, db) {
+				keys = append(keys, e.Key)
+			}
+			if len(keys) != len(tt.expected) {
+				t.Fatalf("unusedEntries() = %v, expected %v", keys, tt.expected)
+			}
+			for i := range keys {
+				if keys[i] != tt.expected[i] {
+					t.Errorf("unusedEntries() = %v, expected %v", keys, tt.expected)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanImport(t *testing.T) {
+	existing := bibtex.Parse(`@article{silva2020redes, author = {Silva, Ana}, year = 2020, title = {Redes}, doi = {10.1/A}}`)
+	for _, e := range existing.Entries {
+		e.File = "src/references.bib"
+	}
+
+	imported, err := bibtex.Import([]byte("TY  - JOUR\nAU  - Silva, Ana\nTI  - Redes\nPY  - 2020\nDO  - 10.1/a\nER  - \n"+
+		"TY  - JOUR\nAU  - Silva, Ana\nTI  - Redes complexas\nPY  - 2020\nER  - \n"+
+		"TY  - BOOK\nAU  - Silva, Ana\nTI  - Redes complexas\nPY  - 2020\nER  - \n"+
+		"TY  - JOUR\nAU  - Silva, Ana\nTI  - Redes\nPY  - 2020\nDO  - 10.2/b\nER  - \n"), bibtex.ImportRIS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range imported {
+		e.File = "zotero.ris"
+	}
+
+	report := planImport(imported, existing, bibtex.DefaultKeyPattern)
+
+	var added []string
+	for _, ref := range report.Added {
+		added = append(added, ref.Key)
+	}
+	expectedAdded := []string{"silva2020redesa", "silva2020redesb"}
+	if !reflect.DeepEqual(added, expectedAdded) {
+		t.Errorf("planImport() added = %v, expected %v", added, expectedAdded)
+	}
+
+	var skipped []string
+	for _, ref := range report.Skipped {
+		skipped = append(skipped, ref.Reason+":"+ref.Existing+":"+ref.File)
+	}
+	expectedSkipped := []string{"doi:silva2020redes:src/references.bib", "title:silva2020redesa:zotero.ris"}
+	if !reflect.DeepEqual(skipped, expectedSkipped) {
+		t.Errorf("planImport() skipped = %v, expected %v", skipped, expectedSkipped)
+	}
+}
@@ -0,0 +1,255 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+)
+
+// importedRef é uma referência acrescentada (saída de --json)
+type importedRef struct {
+	Key    string `json:"key"`
+	Type   string `json:"type"`
+	Title  string `json:"title,omitempty"`
+	Source string `json:"source"`
+}
+
+// skippedRef é uma referência ignorada por já existir
+type skippedRef struct {
+	Title    string `json:"title,omitempty"`
+	Source   string `json:"source"`
+	Reason   string `json:"reason"` // doi ou title
+	Existing string `json:"existing"`
+	File     string `json:"file,omitempty"` // arquivo da entrada existente
+}
+
+// bibImportReport é o resultado de 'ltx bib import'
+type bibImportReport struct {
+	Target  string        `json:"target"`
+	Added   []importedRef `json:"added"`
+	Skipped []skippedRef  `json:"skipped"`
+	DryRun  bool          `json:"dry_run,omitempty"`
+
+	entries []*bibtex.Entry
+}
+
+func runBibImport(args []string) error {
+	format, err := bibtex.ParseImportFormat(bibImportFormat)
+	if err != nil {
+		return err
+	}
+	keys := bibImportKeys
+	if keys == "" {
+		keys = config.GetBibKeyPattern()
+	}
+	pattern, err := bibtex.ParseKeyPattern(keys)
+	if err != nil {
+		return err
+	}
+
+	var imported []*bibtex.Entry
+	for _, path := range args {
+		entries, err := readImport(path, format)
+		if err != nil {
+			return err
+		}
+		imported = append(imported, entries...)
+	}
+
+	target, existingPaths, referenced := importTarget(bibImportTo)
+	_, existing, err := loadBib(existingPaths)
+	if err != nil {
+		return err
+	}
+	if len(existing.Errors) > 0 {
+		return fmt.Errorf("%v (corrija os erros de sintaxe antes de importar: 'ltx bib lint')", existing.Errors[0])
+	}
+
+	report := planImport(imported, existing, pattern)
+	report.Target = target
+	report.DryRun = bibImportDryRun
+
+	if !bibImportDryRun && len(report.entries) > 0 {
+		if err := appendEntries(target, report.entries); err != nil {
+			return err
+		}
+	}
+
+	if bibImportJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	printImportReport(report)
+	if !referenced && len(report.Added) > 0 {
+		colors.PrintWarn(fmt.Sprintf("%s não é referenciado pelo documento; inclua-o com \\addbibresource ou \\bibliography", target))
+	}
+	return nil
+}
+
+// readImport lê e converte um arquivo de referências
+func readImport(path, format string) ([]*bibtex.Entry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	if format == bibtex.ImportAuto {
+		if format, err = bibtex.DetectFormat(path, data); err != nil {
+			return nil, err
+		}
+	}
+
+	entries, err := bibtex.Import(data, format)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao importar %s (%s): %w", path, format, err)
+	}
+	for _, e := range entries {
+		e.File = path
+	}
+	return entries, nil
+}
+
+// importTarget escolhe o .bib de destino e os arquivos usados na verificação
+// de repetidas (os .bib do documento e o destino, se existir). Indica também
+// se o destino é referenciado pelo documento.
+func importTarget(to string) (string, []string, bool) {
+	var documentBibs []string
+	if project, err := mainProject(); err == nil {
+		documentBibs = project.BibFiles()
+	}
+
+	target := to
+	if target == "" {
+		if len(documentBibs) > 0 {
+			target = documentBibs[0]
+		} else {
+			target = filepath.Join(config.GetSourceDir(), "references.bib")
+		}
+	}
+
+	referenced := false
+	for _, path := range documentBibs {
+		if filepath.Clean(path) == filepath.Clean(target) {
+			referenced = true
+		}
+	}
+	candidates := append([]string(nil), documentBibs...)
+	if !referenced {
+		candidates = append(candidates, target)
+	}
+
+	var paths []string
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err == nil {
+			paths = append(paths, path)
+		}
+	}
+	return target, paths, referenced
+}
+
+// planImport separa as referências novas das que já existem (no banco ou
+// antes no próprio lote) e gera as chaves das novas
+func planImport(imported []*bibtex.Entry, existing *bibtex.Database, pattern string) bibImportReport {
+	report := bibImportReport{Added: []importedRef{}, Skipped: []skippedRef{}}
+
+	index := bibtex.NewIndex(existing.Entries)
+	type skipped struct {
+		entry *bibtex.Entry
+		dup   bibtex.Duplicate
+	}
+	var skips []skipped
+	for _, e := range imported {
+		if dup, ok := index.Find(e); ok {
+			skips = append(skips, skipped{e, dup})
+			continue
+		}
+		index.Add(e)
+		report.entries = append(report.entries, e)
+	}
+
+	used := make(map[string]bool)
+	for _, e := range existing.Entries {
+		used[strings.ToLower(e.Key)] = true
+	}
+	bibtex.AssignKeys(report.entries, pattern, used)
+
+	for _, e := range report.entries {
+		report.Added = append(report.Added, importedRef{Key: e.Key, Type: e.Type, Title: bibtex.PlainText(e.Get("title")), Source: e.File})
+	}
+	for _, s := range skips {
+		report.Skipped = append(report.Skipped, skippedRef{
+			Title:    bibtex.PlainText(s.entry.Get("title")),
+			Source:   s.entry.File,
+			Reason:   s.dup.Reason,
+			Existing: s.dup.Entry.Key,
+			File:     s.dup.Entry.File,
+		})
+	}
+	return report
+}
+
+// appendEntries acrescenta as entradas, em forma canônica, ao fim do arquivo
+func appendEntries(path string, entries []*bibtex.Entry) error {
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+
+	blocks := make([]string, len(entries))
+	for i, e := range entries {
+		blocks[i] = bibtex.FormatEntry(e, "  ")
+	}
+
+	content := strings.TrimRight(string(data), "\n\r\t ")
+	if content != "" {
+		content += "\n\n"
+	}
+	content += strings.Join(blocks, "\n\n") + "\n"
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return nil
+}
+
+func printImportReport(report bibImportReport) {
+	for _, ref := range report.Added {
+		fmt.Printf("✓ %s (@%s) %s\n", ref.Key, ref.Type, ref.Title)
+	}
+	for _, ref := range report.Skipped {
+		reason := "mesmo título e ano"
+		if ref.Reason == "doi" {
+			reason = "mesmo DOI"
+		}
+		where := ref.Existing
+		if ref.File != "" {
+			where += " em " + ref.File
+		}
+		fmt.Printf("⚠ Ignorada: %s (%s de %s)\n", valueOr(ref.Title, "sem título"), reason, where)
+	}
+
+	if report.DryRun {
+		colors.PrintInfo(fmt.Sprintf("--dry-run: nada foi gravado (%d referência(s) a importar para %s, %d ignorada(s))", len(report.Added), report.Target, len(report.Skipped)))
+		return
+	}
+
+	summary := fmt.Sprintf("%d referência(s) adicionada(s) a %s, %d ignorada(s)", len(report.Added), report.Target, len(report.Skipped))
+	if len(report.Added) > 0 {
+		colors.PrintSuccess(summary)
+	} else {
+		colors.PrintInfo(summary)
+	}
+}
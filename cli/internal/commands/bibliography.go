@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+// bibliographyPlan é o processamento da bibliografia de uma compilação
+type bibliographyPlan struct {
+	Backend  string // bibtex, biber ou none
+	Detected latex.Bibliography
+	Warning  string // configuração em desacordo com os fontes
+}
+
+// planBibliography combina BIBLIOGRAPHY_BACKEND com o que os fontes declaram
+func planBibliography(mainTexPath string) (bibliographyPlan, error) {
+	setting, err := config.GetBibliographyBackend()
+	if err != nil {
+		return bibliographyPlan{}, err
+	}
+
+	var plan bibliographyPlan
+	if project, err := latex.Load(mainTexPath); err == nil {
+		plan.Detected = project.Bibliography()
+	}
+
+	switch {
+	case setting == config.BibBackendNone:
+		plan.Backend = config.BibBackendNone
+	case setting == config.BibBackendAuto:
+		plan.Backend = plan.Detected.Backend
+		if plan.Backend == "" {
+			plan.Backend = config.BibBackendNone
+		}
+	default:
+		plan.Backend = setting
+		if plan.Detected.Backend != "" && plan.Detected.Backend != setting {
+			// Quem escolhe entre BibTeX e biber é o documento (arquivo .bcf do biblatex)
+			plan.Warning = fmt.Sprintf("BIBLIOGRAPHY_BACKEND=%s, mas o documento usa %s (%s); o latexmk seguirá o documento",
+				setting, plan.Detected.Backend, plan.Detected.Evidence)
+		}
+	}
+	return plan, nil
+}
+
+// bibtexUse é o $bibtex_use do latexmk: 2 executa o BibTeX ou o biber sempre
+// que a bibliografia mudar, mesmo sem localizar os .bib (eles são encontrados
+// pelo BIBINPUTS); 0 desativa o processamento
+func (p bibliographyPlan) bibtexUse() int {
+	if p.Backend == config.BibBackendNone {
+		return 0
+	}
+	return 2
+}
+
+// reportBibliographyLog exibe os avisos e erros do BibTeX ou do biber do
+// documento, a partir do .blg nos auxiliares, da compilação iniciada em since
+func reportBibliographyLog(mainTexPath, auxDir string, since time.Time) {
+	path := filepath.Join(auxDir, targetName(mainTexPath)+".blg")
+	info, err := os.Stat(path)
+	if err != nil || info.ModTime().Before(since) {
+		return
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+
+	report := texlive.ParseBlg(string(data))
+	if len(report.Messages) == 0 {
+		return
+	}
+
+	colors.Printf("[INFO] Bibliografia (%s): %d erro(s), %d aviso(s)\n",
+		report.Backend, report.Count("error"), report.Count("warning"))
+	for _, m := range report.Messages {
+		location := ""
+		if m.File != "" {
+			location = m.File
+			if m.Line > 0 {
+				location += fmt.Sprintf(":%d", m.Line)
+			}
+			location += ": "
+		}
+
+		if m.Level == "error" {
+			colors.PrintError(location + m.Message)
+		} else {
+			colors.PrintWarn(location + m.Message)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+)
+
+func TestPlanBibliography(t *testing.T) {
+	biblatex := "\\usepackage{biblatex}\n\\addbibresource{refs.bib}\n"
+	natbib := "\\usepackage{natbib}\n\\bibliography{refs}\n"
+
+	tests := []struct {
+		name         string
+		setting      string
+		source       string
+		expected     string
+		bibtexUse    int
+		warning      bool
+	}{
+		{
+			name:         "auto com biblatex",
+			setting:      "auto",
+			source:       biblatex,
+			expected:     config.BibBackendBiber,
+			bibtexUse:    2,
+		},
+		{
+			name:         "auto com natbib",
+			setting:      "auto",
+			source:       natbib,
+			expected:     config.BibBackendBibtex,
+			bibtexUse:    2,
+		},
+		{
+			name:         "auto sem bibliografia",
+			setting:      "auto",
+			source:       "Texto\n",
+			expected:     config.BibBackendNone,
+			bibtexUse:    0,
+		},
+		{
+			name:         "configuração diferente do documento",
+			setting:      "biber",
+			source:       natbib,
+			expected:     config.BibBackendBiber,
+			bibtexUse:    2,
+			warning:      true,
+		},
+		{
+			name:         "desativada",
+			setting:      "none",
+			source:       biblatex,
+			expected:     config.BibBackendNone,
+			bibtexUse:    0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			viper.Set("bibliography_backend", tt.setting)
+
+			mainTex := filepath.Join(t.TempDir(), "main.tex")
+			content := "\\documentclass{article}\n" + tt.source + "\\begin{document}\n\\end{document}\n"
+			if err := os.WriteFile(mainTex, []byte(content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			plan, err := planBibliography(mainTex)
+			if err != nil {
+				t.Fatalf("planBibliography() error = %v", err)
+			}
+			if plan.Backend != tt.expected {
+				t.Errorf("planBibliography() backend = %q, expected %q", plan.Backend, tt.expected)
+			}
+			if (plan.Warning != "") != tt.warning {
+				t.Errorf("planBibliography() warning = %q, expected warning %v", plan.Warning, tt.warning)
+			}
+			if use := plan.bibtexUse(); use != tt.bibtexUse {
+				t.Errorf("bibtexUse() = %d, expected %d", use, tt.bibtexUse)
+			}
+		})
+	}
+
+	t.Run("valor inválido", func(t *testing.T) {
+		viper.Reset()
+		defer viper.Reset()
+		viper.Set("bibliography_backend", "biblatex")
+
+		if _, err := planBibliography("main.tex"); err == nil {
+			t.Error("planBibliography() deveria falhar com valor inválido")
+		}
+	})
+}
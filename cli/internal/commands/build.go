@@ -2,23 +2,38 @@ package commands
 
 import (
 	"bufio"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"time"
 
+	units "github.com/docker/go-units"
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/history"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
 )
 
 var (
-	buildEngine   string
-	buildClean    bool
-	buildVerbose  bool
+	buildEngine      string
+	buildClean       bool
+	buildVerbose     bool
+	buildAutoInstall bool
+	buildShellEscape string
+	buildCheckLimits bool
 )
 
+// maxInstallRounds limita as recompilações após instalar pacotes ausentes
+const maxInstallRounds = 3
+
 var BuildCmd = &cobra.Command{
 	Use:   "build",
 	Short: "Compila o documento LaTeX",
@@ -28,16 +43,23 @@ O comando irá:
 1. Verificar se o ambiente Docker está ativo
 2. Compilar o documento principal (main.tex)
 3. Processar bibliografia se necessário
-4. Gerar o PDF final na pasta dist/`,
+4. Gerar o PDF final na pasta dist/
+
+Se a compilação falhar por arquivos ausentes (.sty, .cls, fontes), o ltx
+descobre o pacote do TeX Live correspondente, oferece instalá-lo (ou instala
+direto com --auto-install), registra-o em ltx-packages.txt e recompila.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return buildProject()
 	},
 }
 
 func init() {
-	BuildCmd.Flags().StringVar(&buildEngine, "engine", "pdflatex", "Engine LaTeX a usar (pdflatex, xelatex, lualatex)")
+	BuildCmd.Flags().StringVar(&buildEngine, "engine", "", "Engine LaTeX a usar (pdflatex, xelatex, lualatex; padrão: LATEX_ENGINE da configuração)")
 	BuildCmd.Flags().BoolVar(&buildClean, "clean", false, "Limpar arquivos temporários antes de compilar")
 	BuildCmd.Flags().BoolVarP(&buildVerbose, "verbose", "v", false, "Saída detalhada")
+	BuildCmd.Flags().BoolVar(&buildAutoInstall, "auto-install", false, "Instala sem perguntar os pacotes LaTeX ausentes")
+	BuildCmd.Flags().StringVar(&buildShellEscape, "shell-escape", "", "Política de shell-escape desta compilação (off, restricted, on)")
+	BuildCmd.Flags().BoolVar(&buildCheckLimits, "check-limits", false, "Falhar se o documento exceder WORD_LIMIT")
 }
 
 func buildProject() error {
@@ -50,8 +72,7 @@ func buildProject() error {
 	}
 
 	// Verificar se existe main.tex
-	sourceDir := "src"
-	mainTexPath := filepath.Join(sourceDir, "main.tex")
+	mainTexPath := projectMainFile()
 
 	if _, err := os.Stat(mainTexPath); os.IsNotExist(err) {
 		return fmt.Errorf("arquivo %s não encontrado. Execute 'ltx init' primeiro", mainTexPath)
@@ -69,97 +90,497 @@ func buildProject() error {
 		return fmt.Errorf("docker não está disponível: %w", err)
 	}
 
-	// Verificar se container está rodando
+	// Saídas de compilações antigas (como latexuser) impedem o latexmk de sobrescrevê-las
+	checkOwnership()
+
+	mode, err := config.GetContainerMode()
+	if err != nil {
+		return err
+	}
+	if _, err := config.GetResourceLimits(); err != nil {
+		return err
+	}
+	if _, err := config.GetBuildTimeout(); err != nil {
+		return err
+	}
+	if _, err := config.GetBibliographyBackend(); err != nil {
+		return err
+	}
+	if _, err := config.GetLatexmkMaxRepeat(); err != nil {
+		return err
+	}
+	if buildCheckLimits {
+		if _, _, err := wordLimitConfig(); err != nil {
+			return err
+		}
+	}
+	shellEscape, err := resolveShellEscape(mainTexPath)
+	if err != nil {
+		return err
+	}
+	if shellEscape == config.ShellEscapeOn {
+		colors.PrintWarn("SHELL_ESCAPE=on: o documento pode executar qualquer comando no container. A compilação será feita sem acesso à rede.")
+	}
+
+	// Preparar o ambiente: container persistente ou imagem dos containers descartáveis
 	colors.PrintInfo("Iniciando compilação...")
-	if err := ensureContainerRunning(); err != nil {
-		return fmt.Errorf("erro ao garantir que container esteja rodando: %w", err)
+	timings := buildTimings{Mode: mode}
+	envStart := time.Now()
+
+	envImage, err := prepareBuildEnv(mode)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar ambiente de compilação: %w", err)
+	}
+
+	// Instalar pacotes registrados pelo projeto
+	if err := syncRecordedPackages(); err != nil {
+		colors.Printf("[WARN] Não foi possível sincronizar pacotes registrados: %v\n", err)
 	}
+	timings.Env = time.Since(envStart)
 
 	// Compilar documento
-	if err := compileDocument(mainTexPath); err != nil {
+	compileStart := time.Now()
+	if err := compileWithPackageRecovery(mainTexPath, envImage); err != nil {
 		return fmt.Errorf("erro na compilação: %w", err)
 	}
+	timings.Compile = time.Since(compileStart)
 
 	duration := time.Since(start)
+	timings.Total = duration
 	colors.Printf("[SUCCESS] Compilação concluída em %v\n", duration.Round(time.Second))
-	colors.PrintInfo("PDF gerado: dist/main.pdf")
+	colors.PrintInfo("PDF gerado: " + filepath.Join(config.GetOutputDir(), targetName(mainTexPath)+".pdf"))
+
+	if buildVerbose {
+		reportBuildTimings(timings)
+	}
+
+	if buildCheckLimits {
+		return checkWordLimit(mainTexPath)
+	}
 
 	return nil
 }
 
+// prepareBuildEnv prepara o ambiente conforme container_mode. No modo
+// persistente garante o container do projeto e retorna ""; no efêmero retorna
+// a imagem dos containers descartáveis.
+func prepareBuildEnv(mode string) (string, error) {
+	if mode == config.ContainerModePersistent {
+		return "", ensureContainerRunning()
+	}
+
+	warnLockDrift()
+	return resolveBuildImage()
+}
+
+// warnLockDrift avisa quando a configuração divergiu do ltx.lock
+func warnLockDrift() {
+	if l := loadProjectLock(); l != nil {
+		for _, d := range lockDrift(l) {
+			colors.PrintWarn(fmt.Sprintf("%s (usando o digest do %s)", d, lock.File))
+		}
+	}
+}
+
+// runInEnv executa um comando no ambiente do projeto: via exec no container
+// persistente (envImage vazia) ou em um container descartável da imagem
+// informada, removido ao final. Com isolated o comando roda sem rede. Retorna
+// o código de saída do comando.
+func runInEnv(ctx context.Context, client *docker.Client, envImage string, isolated bool, opts docker.ExecOptions) (int, error) {
+	if envImage == "" {
+		name := config.GetContainerName()
+		if isolated {
+			// O container persistente é desconectado das redes durante o comando
+			restore, err := client.IsolateNetwork(ctx, name)
+			if err != nil {
+				return -1, err
+			}
+			defer func() {
+				if err := restore(); err != nil {
+					colors.PrintWarn(err.Error())
+				}
+			}()
+		}
+		return client.Exec(ctx, name, opts)
+	}
+
+	run := ephemeralRunOptions(envImage)
+	run.Cmd = opts.Cmd
+	run.Env = append(run.Env, opts.Env...)
+	run.User = opts.User
+	run.NoNetwork = isolated
+	run.Stdout = opts.Stdout
+	run.Stderr = opts.Stderr
+
+	return client.Run(ctx, run)
+}
+
+// ExitError carrega o código de saída de um comando executado no ambiente,
+// usado como código de saída do ltx
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string { return e.Err.Error() }
+func (e *ExitError) Unwrap() error { return e.Err }
+
 func ensureContainerRunning() error {
-	// Verificar se o container existe e está rodando usando docker-compose
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "ps", "-q", "latex-env")
-	output, err := cmd.Output()
-	if err != nil || len(output) == 0 {
-		colors.PrintInfo("Iniciando ambiente Docker...")
+	// Com ltx.lock a imagem é sempre o digest travado; avisar se a configuração divergiu
+	warnLockDrift()
+
+	// Imagem do ambiente: derivada, travada, configurada ou a padrão
+	buildImage, err := resolveBuildImage()
+	if err != nil {
+		return fmt.Errorf("erro ao preparar imagem do ambiente: %w", err)
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	// Container exclusivo do projeto, (re)criado se a imagem mudou
+	started, err := ensureProjectEnv(context.Background(), client, buildImage)
+	if err != nil {
+		return err
+	}
+
+	// Aguardar o healthcheck (imediato se o container já estiver saudável)
+	return waitForContainer(started)
+}
+
+// waitForContainer aguarda o container ficar saudável, até START_TIMEOUT. Em
+// terminais exibe um indicador de progresso; announce força a mensagem final
+// mesmo quando o container já estava pronto.
+func waitForContainer(announce bool) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	timeout := config.GetStartTimeout()
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	tty := stdoutIsTerminal()
+	start := time.Now()
+	polls := 0
 
-		// Iniciar container
-		cmd = exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "up", "-d")
-		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
+	state, err := client.WaitHealthy(ctx, config.GetContainerName(), 500*time.Millisecond, func(s *docker.HealthState) {
+		polls++
+		if s.Ready() {
+			return
+		}
+		if polls == 1 && !tty {
+			colors.PrintInfo(fmt.Sprintf("Aguardando container ficar saudável (até %v)...", timeout))
+		}
+		if tty {
+			health := s.Health
+			if health == "" {
+				health = s.Status
+			}
+			fmt.Printf("\r%s Aguardando container ficar saudável... %v (%s) ", spinnerFrames[polls%len(spinnerFrames)], time.Since(start).Round(time.Second), health)
+		}
+	})
+	if tty && polls > 1 {
+		fmt.Print("\r\033[K")
+	}
 
-		if err := cmd.Run(); err != nil {
-			return fmt.Errorf("erro ao iniciar container: %w", err)
+	if err != nil {
+		if state != nil && state.LastOutput != "" {
+			colors.Println("[ERROR] Saída da última verificação do healthcheck:")
+			for _, line := range strings.Split(state.LastOutput, "\n") {
+				fmt.Printf("   %s\n", line)
+			}
 		}
+		colors.PrintInfo("Veja os logs do container com 'ltx logs' ou aumente START_TIMEOUT na configuração")
+		return err
+	}
+
+	if announce || polls > 1 {
+		colors.PrintSuccess(fmt.Sprintf("Container está saudável (%v)", time.Since(start).Round(100*time.Millisecond)))
+	}
+
+	return nil
+}
+
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+func stdoutIsTerminal() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// effectiveEngine retorna a engine da flag --engine ou, sem ela, a da configuração
+func effectiveEngine() string {
+	if buildEngine != "" {
+		return buildEngine
+	}
+	return config.GetLatexEngine()
+}
+
+// latexmkEngineFlag traduz a engine na opção do latexmk que gera o PDF com ela
+func latexmkEngineFlag(engine string) (string, error) {
+	switch strings.ToLower(engine) {
+	case "pdflatex":
+		return "-pdf", nil
+	case "xelatex":
+		return "-pdfxe", nil
+	case "lualatex":
+		return "-pdflua", nil
+	default:
+		return "", fmt.Errorf("engine LaTeX não suportada: %q (use pdflatex, xelatex ou lualatex)", engine)
+	}
+}
+
+func compileDocument(mainTexPath, envImage string) error {
+	engine := effectiveEngine()
+	if _, err := latexmkEngineFlag(engine); err != nil {
+		return err
+	}
+
+	bibliography, err := planBibliography(mainTexPath)
+	if err != nil {
+		return err
+	}
+	if bibliography.Warning != "" {
+		colors.PrintWarn(bibliography.Warning)
+	}
+
+	if bibliography.Backend == config.BibBackendNone {
+		colors.Printf("[INFO] Compilando %s com %s...\n", mainTexPath, engine)
+	} else {
+		colors.Printf("[INFO] Compilando %s com %s e %s...\n", mainTexPath, engine, bibliography.Backend)
+	}
+
+	// Engine, opções do TeX, shell-escape e bibliografia vão no latexmkrc gerado
+	rc, err := buildLatexmkrc(mainTexPath, bibliography)
+	if err != nil {
+		return err
+	}
+	rcPath, err := writeLatexmkrc(mainTexPath, rc)
+	if err != nil {
+		return err
+	}
+	if tools := rc.Indexing.Tools(); len(tools) > 0 {
+		colors.Printf("[INFO] Glossários e índices: %s\n", strings.Join(tools, ", "))
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	cmd := []string{"latexmk", "-r", filepath.ToSlash(rcPath), rc.Target}
 
-		colors.PrintSuccess("Ambiente Docker iniciado com sucesso")
+	// Só o modo on precisa de tratamento fora do latexmkrc (compilação sem rede)
+	shellEscape, _ := resolveShellEscape(mainTexPath)
+
+	// Com BUILD_TIMEOUT o processo é encerrado dentro do próprio container; o
+	// prazo do contexto é só uma garantia caso o Docker deixe de responder
+	ctx := context.Background()
+	timeout, _ := config.GetBuildTimeout()
+	if timeout > 0 {
+		cmd = append([]string{"timeout", "--kill-after=10s", fmt.Sprintf("%ds", int(timeout.Seconds()))}, cmd...)
+
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout+30*time.Second)
+		defer cancel()
+	}
+
+	started := time.Now()
+	defer reportBibliographyLog(mainTexPath, rc.AuxDir, started)
+
+	output := &tailBuffer{max: 64 * 1024}
+	progress := &latexmkProgress{}
+	stdout, stderr := progress.streams(io.MultiWriter(os.Stdout, output), os.Stderr)
+	// Compilar como o usuário do host, para que dist/ não fique com outro dono.
+	// HOME aponta para /tmp porque o UID do host não existe na imagem.
+	exitCode, err := runInEnv(ctx, client, envImage, shellEscape == config.ShellEscapeOn, docker.ExecOptions{
+		Cmd:    cmd,
+		Env:    []string{"HOME=/tmp"},
+		User:   hostUser(),
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	oomKilled := errors.Is(err, docker.ErrOOMKilled)
+	if oomKilled {
+		err = nil
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		abortCompilation(client, envImage)
+		exitCode, err = timeoutExitCode, nil
+	}
+	if err != nil {
+		return err
+	}
+	// O container do projeto continua ativo; o Docker marca nele o OOM do exec
+	if exitCode == killedExitCode && envImage == "" {
+		oomKilled, _ = client.OOMKilled(context.Background(), config.GetContainerName())
+	}
 
-		// Aguardar container ficar saudável
-		colors.PrintInfo("Aguardando container ficar saudável...")
-		time.Sleep(2 * time.Second)
-		colors.PrintSuccess("Container está saudável")
+	status := compileStatus(exitCode, oomKilled, time.Since(started), timeout)
+	recordCompilation(mainTexPath, rc, started, exitCode, status, progress)
+
+	switch status {
+	case history.StatusSuccess:
+	case history.StatusTimeout:
+		return &ExitError{Code: timeoutExitCode, Err: timeoutError(timeout, output.String(), filepath.Join(rc.AuxDir, targetName(mainTexPath)+".log"))}
+	case history.StatusOOM:
+		return &ExitError{Code: exitCode, Err: oomError()}
+	default:
+		return &ExitError{Code: exitCode, Err: fmt.Errorf("latexmk terminou com código %d", exitCode)}
 	}
 
+	if config.GetSynctexInOutput() {
+		moveSynctex(targetName(mainTexPath), rc.AuxDir, rc.OutDir)
+	}
 	return nil
 }
 
-func compileDocument(mainTexPath string) error {
-	engine := buildEngine
-	if engine == "" {
-		engine = "pdflatex"
+// moveSynctex leva o .synctex.gz dos auxiliares para junto do PDF, onde os
+// editores e visualizadores o procuram
+func moveSynctex(name, auxDir, outDir string) {
+	if filepath.Clean(auxDir) == filepath.Clean(outDir) {
+		return
+	}
+	src := filepath.Join(auxDir, name+".synctex.gz")
+	if _, err := os.Stat(src); err != nil {
+		return
+	}
+	dst := filepath.Join(outDir, name+".synctex.gz")
+	if err := os.Rename(src, dst); err != nil {
+		colors.Printf("[WARN] Não foi possível mover %s para %s: %v\n", src, outDir, err)
+	}
+}
+
+// Códigos de saída do timeout(1): prazo esgotado e processo morto com SIGKILL
+const (
+	timeoutExitCode = 124
+	killedExitCode  = 137
+)
+
+// compileStatus classifica o término do latexmk. Só o 124 do timeout(1) indica
+// prazo esgotado, exceto quando o processo ignorou o TERM e o --kill-after o
+// matou já depois do prazo (137). Um 137 antes disso é um SIGKILL de fora, em
+// geral o limite de memória do container, confirmado pelo estado do Docker.
+func compileStatus(exitCode int, oomKilled bool, elapsed, timeout time.Duration) string {
+	switch {
+	case exitCode == 0:
+		return history.StatusSuccess
+	case timeout > 0 && exitCode == timeoutExitCode:
+		return history.StatusTimeout
+	case timeout > 0 && exitCode == killedExitCode && elapsed >= timeout:
+		return history.StatusTimeout
+	case oomKilled:
+		return history.StatusOOM
+	default:
+		return history.StatusFailure
+	}
+}
+
+// oomError descreve a compilação encerrada por esgotar MEMORY_LIMIT
+func oomError() error {
+	if limits, err := config.GetResourceLimits(); err == nil && limits.Memory > 0 {
+		return fmt.Errorf("compilação encerrada por falta de memória (MEMORY_LIMIT de %s); aumente o limite ou reduza o documento", units.BytesSize(float64(limits.Memory)))
+	}
+	return fmt.Errorf("compilação encerrada por falta de memória; verifique a memória disponível para o Docker")
+}
+
+// timeoutError descreve a compilação interrompida por BUILD_TIMEOUT, com o
+// arquivo e a linha que o TeX processava por último
+func timeoutError(timeout time.Duration, output, logPath string) error {
+	file, line := texlive.LastPosition(output)
+	if file == "" {
+		// A saída pode ter sido cortada; o log nos auxiliares traz a transcrição completa
+		if data, err := os.ReadFile(logPath); err == nil {
+			file, line = texlive.LastPosition(string(data))
+		}
+	}
+
+	switch {
+	case file != "" && line > 0:
+		return fmt.Errorf("compilação interrompida após %v (BUILD_TIMEOUT); último trecho processado: %s:%d", timeout, file, line)
+	case file != "":
+		return fmt.Errorf("compilação interrompida após %v (BUILD_TIMEOUT); último arquivo processado: %s", timeout, file)
+	default:
+		return fmt.Errorf("compilação interrompida após %v (BUILD_TIMEOUT)", timeout)
 	}
+}
 
-	colors.Printf("[INFO] Compilando %s com %s...\n", mainTexPath, engine)
+// abortCompilation encerra uma compilação que não respondeu ao timeout
+func abortCompilation(client *docker.Client, envImage string) {
+	ctx := context.Background()
 
-	// Comando para executar latexmk no container com TEXINPUTS configurado
-	args := []string{
-		"compose", "-f", "config/docker/docker-compose.yml",
-		"exec", "-T",
-		"-e", "TEXINPUTS=./src//:", // Configurar TEXINPUTS para src/ e subdirs
-		"latex-env",
-		"latexmk",
-		"-pdf",
-		"-interaction=nonstopmode",
-		"-file-line-error",
-		"-synctex=1",
-		"-recorder",
-		"-output-directory=dist",
-		"src/main.tex",
+	if envImage != "" {
+		if running, err := projectEphemeralContainers(ctx, client); err == nil {
+			for _, name := range running {
+				_ = client.RemoveContainer(ctx, name)
+			}
+		}
+		return
 	}
 
-	cmd := exec.Command("docker", args...)
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
-	return cmd.Run()
+	_, _ = client.Exec(ctx, config.GetContainerName(), docker.ExecOptions{
+		Cmd: []string{"pkill", "-KILL", "-f", "latexmk|pdflatex|xelatex|lualatex"},
+	})
+}
+
+// tailBuffer guarda apenas os últimos max bytes escritos
+type tailBuffer struct {
+	buf []byte
+	max int
+}
+
+func (b *tailBuffer) Write(p []byte) (int, error) {
+	b.buf = append(b.buf, p...)
+	if len(b.buf) > b.max {
+		b.buf = b.buf[len(b.buf)-b.max:]
+	}
+	return len(p), nil
+}
+
+func (b *tailBuffer) String() string { return string(b.buf) }
+
+// compileWithPackageRecovery compila o documento e, se a falha for causada por
+// pacotes ausentes, instala-os e tenta novamente
+func compileWithPackageRecovery(mainTexPath, envImage string) error {
+	attempted := make(map[string]bool)
+	logPath := filepath.Join(auxDir(mainTexPath), targetName(mainTexPath)+".log")
+
+	for round := 0; ; round++ {
+		err := compileDocument(mainTexPath, envImage)
+		if err == nil || round >= maxInstallRounds {
+			return err
+		}
+
+		installed, installErr := installMissingFromLog(logPath, buildAutoInstall, attempted)
+		if installErr != nil {
+			colors.Printf("[WARN] Falha ao instalar pacotes ausentes: %v\n", installErr)
+			return err
+		}
+		if !installed {
+			return err
+		}
+
+		colors.PrintInfo("Recompilando após instalar pacotes...")
+	}
 }
 
 func cleanTempFiles() error {
 	colors.PrintInfo("Limpando arquivos temporários...")
 
-	// Padrões de arquivos temporários
-	patterns := []string{
-		"dist/*.aux",
-		"dist/*.log",
-		"dist/*.bbl",
-		"dist/*.blg",
-		"dist/*.fls",
-		"dist/*.fdb_latexmk",
-		"dist/*.synctex.gz",
-		"dist/*.out",
-		"dist/*.toc",
-		"dist/*.lot",
-		"dist/*.lof",
+	// Os auxiliares ficam em AUX_DIR; OUTPUT_DIR pode ter sobras de compilações antigas
+	var patterns []string
+	for _, dir := range tempFileDirs() {
+		patterns = append(patterns, tempFilePatterns(dir)...)
 	}
 
 	for _, pattern := range patterns {
@@ -178,32 +599,64 @@ func cleanTempFiles() error {
 	return nil
 }
 
-// checkRunningCompilation verifica se há uma compilação em andamento
+// checkRunningCompilation verifica se há uma compilação em andamento no projeto:
+// latexmk no container persistente ou containers descartáveis ativos
 func checkRunningCompilation() (bool, error) {
-	// Verificar se há processos latexmk rodando no container
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml",
-		"exec", "-T", "latex-env", "pgrep", "-f", "latexmk")
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return false, nil
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	if running, err := projectEphemeralContainers(ctx, client); err == nil && len(running) > 0 {
+		return true, nil
+	}
 
-	output, err := cmd.Output()
+	// pgrep retorna 0 quando encontra processos latexmk
+	exitCode, err := client.Exec(ctx, config.GetContainerName(), docker.ExecOptions{
+		Cmd: []string{"pgrep", "-f", "latexmk"},
+	})
 	if err != nil {
-		// Se não encontrou processos ou houve erro, assume que não há compilação
+		// Container parado ou inexistente: não há compilação
 		return false, nil
 	}
 
-	// Se há output, significa que há processos latexmk rodando
-	return len(strings.TrimSpace(string(output))) > 0, nil
+	return exitCode == 0, nil
 }
 
 // killRunningCompilation mata processos de compilação em andamento
 func killRunningCompilation() error {
 	colors.PrintInfo("Encerrando processos de compilação em andamento...")
 
-	// Matar processos latexmk no container
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml",
-		"exec", "-T", "latex-env", "pkill", "-f", "latexmk")
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	killed := false
+
+	// Containers descartáveis do projeto são removidos
+	if running, err := projectEphemeralContainers(ctx, client); err == nil {
+		for _, name := range running {
+			if err := client.RemoveContainer(ctx, name); err == nil {
+				killed = true
+			}
+		}
+	}
+
+	// Matar processos latexmk apenas no container deste projeto
+	exitCode, err := client.Exec(ctx, config.GetContainerName(), docker.ExecOptions{
+		Cmd: []string{"pkill", "-f", "latexmk"},
+	})
+	if err == nil && exitCode == 0 {
+		killed = true
+	}
 
-	if err := cmd.Run(); err != nil {
-		// Ignorar erro se não houver processos para matar
+	if !killed {
+		// Ignorar se não houver processos para matar
 		colors.PrintWarn("Nenhum processo de compilação encontrado para encerrar")
 	}
 
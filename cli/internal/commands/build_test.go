@@ -4,6 +4,9 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/history"
 )
 
 func TestBuildCommand(t *testing.T) {
@@ -127,11 +130,11 @@ func TestCleanTempFiles(t *testing.T) {
 		t.Fatalf("Erro ao mudar para diretório temporário: %v", err)
 	}
 
-	// Setup: criar pasta dist com arquivos temporários
-	distDir := "dist"
-	err := os.MkdirAll(distDir, 0755)
-	if err != nil {
-		t.Fatalf("Erro ao criar diretório dist: %v", err)
+	// Setup: criar pastas dist e tmp (auxiliares) com arquivos temporários
+	for _, dir := range []string{"dist", "tmp"} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Erro ao criar diretório %s: %v", dir, err)
+		}
 	}
 
 	// Criar arquivos temporários
@@ -145,7 +148,14 @@ func TestCleanTempFiles(t *testing.T) {
 		"dist/main.synctex.gz",
 		"dist/main.out",
 		"dist/main.toc",
+		"dist/main.glo",
+		"dist/main.acn",
+		"dist/main.nls",
+		"dist/main.ist",
 		"dist/main.pdf", // Este deve ser mantido
+		"tmp/main.aux",
+		"tmp/main.log",
+		"tmp/main.fdb_latexmk",
 	}
 
 	for _, file := range tempFiles {
@@ -156,7 +166,7 @@ func TestCleanTempFiles(t *testing.T) {
 	}
 
 	// Executar limpeza
-	err = cleanTempFiles()
+	err := cleanTempFiles()
 	if err != nil {
 		t.Errorf("cleanTempFiles() error = %v", err)
 	}
@@ -166,8 +176,12 @@ func TestCleanTempFiles(t *testing.T) {
 		t.Errorf("Arquivo main.pdf não deveria ter sido removido")
 	}
 
-	// Verificar se pelo menos alguns arquivos temporários foram removidos
-	// (Nota: a função cleanTempFiles usa padrões glob, então pode não remover todos em ambiente de teste)
+	// Glossários, siglas, nomenclaturas e os auxiliares em tmp/ também são temporários
+	for _, file := range []string{"dist/main.glo", "dist/main.acn", "dist/main.nls", "dist/main.ist", "tmp/main.aux", "tmp/main.log"} {
+		if _, err := os.Stat(file); err == nil {
+			t.Errorf("Arquivo %s deveria ter sido removido", file)
+		}
+	}
 }
 
 func TestBuildFlags(t *testing.T) {
@@ -212,3 +226,59 @@ func TestBuildFlags(t *testing.T) {
 		})
 	}
 }
+
+func TestLatexmkEngineFlag(t *testing.T) {
+	tests := []struct {
+		engine   string
+		expected string
+		wantErr  bool
+	}{
+		{engine: "pdflatex", expected: "-pdf"},
+		{engine: "XeLaTeX", expected: "-pdfxe"},
+		{engine: "lualatex", expected: "-pdflua"},
+		{engine: "context", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.engine, func(t *testing.T) {
+			flag, err := latexmkEngineFlag(tt.engine)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("latexmkEngineFlag(%q) error = %v, wantErr %v", tt.engine, err, tt.wantErr)
+			}
+			if flag != tt.expected {
+				t.Errorf("latexmkEngineFlag(%q) = %q, expected %q", tt.engine, flag, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCompileStatus(t *testing.T) {
+	timeout := 10 * time.Minute
+
+	tests := []struct {
+		name      string
+		exitCode  int
+		oomKilled bool
+		elapsed   time.Duration
+		timeout   time.Duration
+		expected  string
+	}{
+		{name: "sucesso", exitCode: 0, elapsed: time.Minute, timeout: timeout, expected: history.StatusSuccess},
+		{name: "erro do latexmk", exitCode: 12, elapsed: time.Minute, timeout: timeout, expected: history.StatusFailure},
+		{name: "prazo esgotado", exitCode: timeoutExitCode, elapsed: timeout, timeout: timeout, expected: history.StatusTimeout},
+		{name: "kill-after após o prazo", exitCode: killedExitCode, elapsed: timeout + 10*time.Second, timeout: timeout, expected: history.StatusTimeout},
+		{name: "sem memória", exitCode: killedExitCode, oomKilled: true, elapsed: time.Minute, timeout: timeout, expected: history.StatusOOM},
+		{name: "sem memória sem BUILD_TIMEOUT", exitCode: killedExitCode, oomKilled: true, elapsed: time.Hour, expected: history.StatusOOM},
+		{name: "SIGKILL externo", exitCode: killedExitCode, elapsed: time.Minute, timeout: timeout, expected: history.StatusFailure},
+		{name: "124 sem BUILD_TIMEOUT", exitCode: timeoutExitCode, elapsed: time.Minute, expected: history.StatusFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := compileStatus(tt.exitCode, tt.oomKilled, tt.elapsed, tt.timeout)
+			if result != tt.expected {
+				t.Errorf("compileStatus(%d, %v, %v, %v) = %q, expected %q", tt.exitCode, tt.oomKilled, tt.elapsed, tt.timeout, result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,293 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+var (
+	countJSON    bool
+	countMax     int
+	countScanner bool
+)
+
+var CountCmd = &cobra.Command{
+	Use:   "count [arquivo]",
+	Short: "Conta as palavras do documento",
+	Long: `Conta as palavras do documento (por padrão src/main.tex) por arquivo e por
+seção, separando texto, títulos, legendas e notas de rodapé.
+
+A contagem é feita pelo texcount no ambiente do projeto, seguindo \input e
+\include. Se o texcount não estiver disponível (ou com --scanner), é usado o
+contador interno do ltx, que lê os fontes sem Docker.
+
+Com WORD_LIMIT (ou --max) o comando falha quando a soma das categorias de
+WORD_LIMIT_COUNTS (padrão: texto, títulos e legendas) excede o limite.`,
+	Example: `  ltx count
+  ltx count src/chapters/introducao.tex
+  ltx count --max 80000 --json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := filepath.Join(config.GetSourceDir(), "main.tex")
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runCount(target, countJSON)
+	},
+}
+
+func init() {
+	CountCmd.Flags().BoolVar(&countJSON, "json", false, "Saída em JSON")
+	CountCmd.Flags().IntVar(&countMax, "max", 0, "Limite de palavras (padrão: WORD_LIMIT da configuração)")
+	CountCmd.Flags().BoolVar(&countScanner, "scanner", false, "Usar o contador interno em vez do texcount")
+}
+
+// wordReport é o resultado de uma contagem (saída de --json)
+type wordReport struct {
+	Target   string            `json:"target"`
+	Counter  string            `json:"counter"` // texcount ou scanner
+	Files    []latex.FileWords `json:"files"`
+	Total    latex.WordCount   `json:"total"`
+	Limit    *wordLimit        `json:"limit,omitempty"`
+	Warnings []string          `json:"warnings,omitempty"`
+}
+
+// wordLimit compara a contagem com o limite configurado
+type wordLimit struct {
+	Max      int      `json:"max"`
+	Counts   []string `json:"counts"`
+	Words    int      `json:"words"`
+	Exceeded bool     `json:"exceeded"`
+}
+
+func runCount(target string, asJSON bool) error {
+	limit, counts, err := wordLimitConfig()
+	if err != nil {
+		return err
+	}
+
+	report, err := countWords(target, !countScanner)
+	if err != nil {
+		return err
+	}
+	applyWordLimit(&report, limit, counts)
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		printWordReport(report)
+	}
+
+	return wordLimitError(report)
+}
+
+// wordLimitConfig retorna o limite de palavras (--max ou WORD_LIMIT) e as
+// categorias somadas para ele
+func wordLimitConfig() (int, []string, error) {
+	counts, err := config.GetWordLimitCounts()
+	if err != nil {
+		return 0, nil, err
+	}
+	if countMax > 0 {
+		return countMax, counts, nil
+	}
+
+	limit, err := config.GetWordLimit()
+	if err != nil {
+		return 0, nil, err
+	}
+	return limit, counts, nil
+}
+
+// countWords conta as palavras de um documento com o texcount ou, sem ele,
+// com o scanner interno
+func countWords(target string, useTexcount bool) (wordReport, error) {
+	report := wordReport{Target: target, Counter: "scanner"}
+
+	project, err := latex.Load(target)
+	if err != nil {
+		return report, fmt.Errorf("arquivo %s não encontrado", target)
+	}
+	for _, missing := range project.Missing {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Arquivo incluído não encontrado: %s", missing))
+	}
+
+	scanned := project.Words()
+	report.Files = scanned
+
+	if useTexcount {
+		files, err := runTexcount(target)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("texcount indisponível (%v); usando o contador interno", err))
+		} else {
+			report.Counter = "texcount"
+			report.Files = mergeFootnotes(files, scanned)
+		}
+	}
+
+	for _, f := range report.Files {
+		report.Total.Add(f.WordCount)
+	}
+	return report, nil
+}
+
+// runTexcount executa o texcount no ambiente do projeto (ou no TeX Live local)
+func runTexcount(target string) ([]latex.FileWords, error) {
+	runner, closeRunner, err := newTexRunner(false)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRunner()
+
+	return texlive.Texcount(context.Background(), runner, filepath.ToSlash(target))
+}
+
+// mergeFootnotes separa as notas de rodapé na contagem do texcount, que as
+// soma ao texto. As notas vêm do scanner interno, por arquivo e, quando as
+// seções coincidem, por seção (junto com as linhas de cada seção).
+func mergeFootnotes(files, scanned []latex.FileWords) []latex.FileWords {
+	byPath := make(map[string]latex.FileWords, len(scanned))
+	for _, f := range scanned {
+		byPath[filepath.Clean(f.Path)] = f
+	}
+
+	for i := range files {
+		f := &files[i]
+		s, ok := byPath[filepath.Clean(f.Path)]
+		if !ok {
+			continue
+		}
+
+		f.Footnotes = s.Footnotes
+		f.Text = max(f.Text-s.Footnotes, 0)
+
+		if len(f.Sections) != len(s.Sections) {
+			continue
+		}
+		for j := range f.Sections {
+			section := &f.Sections[j]
+			section.Line = s.Sections[j].Line
+			section.Footnotes = s.Sections[j].Footnotes
+			section.Text = max(section.Text-section.Footnotes, 0)
+		}
+	}
+
+	return files
+}
+
+// applyWordLimit compara o total com o limite (0 = sem limite)
+func applyWordLimit(report *wordReport, limit int, counts []string) {
+	if limit <= 0 {
+		return
+	}
+
+	words := wordsIn(report.Total, counts)
+	report.Limit = &wordLimit{Max: limit, Counts: counts, Words: words, Exceeded: words > limit}
+}
+
+// wordsIn soma as categorias informadas de uma contagem
+func wordsIn(w latex.WordCount, counts []string) int {
+	total := 0
+	for _, count := range counts {
+		switch count {
+		case config.WordsText:
+			total += w.Text
+		case config.WordsHeaders:
+			total += w.Headers
+		case config.WordsCaptions:
+			total += w.Captions
+		case config.WordsFootnotes:
+			total += w.Footnotes
+		}
+	}
+	return total
+}
+
+func wordLimitError(report wordReport) error {
+	if report.Limit == nil || !report.Limit.Exceeded {
+		return nil
+	}
+	return fmt.Errorf("limite de palavras excedido: %d de %d (%s)",
+		report.Limit.Words, report.Limit.Max, strings.Join(report.Limit.Counts, ", "))
+}
+
+func printWordReport(report wordReport) {
+	for _, warning := range report.Warnings {
+		colors.PrintWarn(warning)
+	}
+
+	fmt.Printf("=== Contagem de palavras (%s) ===\n\n", report.Counter)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ARQUIVO / SEÇÃO\tTEXTO\tTÍTULOS\tLEGENDAS\tNOTAS")
+	for _, f := range report.Files {
+		fmt.Fprintf(w, "%s\t%s\n", f.Path, wordColumns(f.WordCount))
+		for _, s := range f.Sections {
+			title := s.Title
+			if s.Level == "" {
+				title = "(início)"
+			}
+			fmt.Fprintf(w, "  %s\t%s\n", title, wordColumns(s.WordCount))
+		}
+	}
+	fmt.Fprintf(w, "TOTAL\t%s\n", wordColumns(report.Total))
+	_ = w.Flush()
+
+	fmt.Printf("\nTotal: %d palavras\n", report.Total.Total())
+
+	if l := report.Limit; l != nil {
+		symbol := "✓"
+		if l.Exceeded {
+			symbol = "✗"
+		}
+		fmt.Printf("%s Limite: %d de %d palavras (%s)\n", symbol, l.Words, l.Max, strings.Join(l.Counts, ", "))
+	}
+}
+
+func wordColumns(w latex.WordCount) string {
+	return fmt.Sprintf("%d\t%d\t%d\t%d", w.Text, w.Headers, w.Captions, w.Footnotes)
+}
+
+// checkWordLimit verifica o limite de palavras após a compilação (build
+// --check-limits)
+func checkWordLimit(mainTexPath string) error {
+	limit, counts, err := wordLimitConfig()
+	if err != nil {
+		return err
+	}
+	if limit == 0 {
+		colors.PrintWarn("--check-limits sem limite configurado: defina WORD_LIMIT na configuração")
+		return nil
+	}
+
+	report, err := countWords(mainTexPath, true)
+	if err != nil {
+		return err
+	}
+	for _, warning := range report.Warnings {
+		colors.PrintWarn(warning)
+	}
+	applyWordLimit(&report, limit, counts)
+
+	if err := wordLimitError(report); err != nil {
+		return err
+	}
+	colors.PrintSuccess(fmt.Sprintf("Palavras: %d de %d (%s, %s)", report.Limit.Words, limit, strings.Join(counts, ", "), report.Counter))
+	return nil
+}
@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+func TestCountWordsScanner(t *testing.T) {
+	dir := t.TempDir()
+	main := filepath.Join(dir, "main.tex")
+	chapter := filepath.Join(dir, "cap1.tex")
+
+	if err := os.WriteFile(main, []byte("\\begin{document}\nUma frase curta.\n\\input{cap1}\n\\end{document}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(chapter, []byte("\\chapter{Um}\nMais texto\\footnote{Uma nota}."), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := countWords(main, false)
+	if err != nil {
+		t.Fatalf("countWords() error = %v", err)
+	}
+
+	expected := latex.WordCount{Text: 5, Headers: 1, Footnotes: 2}
+	if report.Counter != "scanner" || report.Total != expected {
+		t.Errorf("countWords() = %s %+v, expected scanner %+v", report.Counter, report.Total, expected)
+	}
+	if len(report.Files) != 2 {
+		t.Errorf("Files = %d, expected 2", len(report.Files))
+	}
+}
+
+func TestApplyWordLimit(t *testing.T) {
+	total := latex.WordCount{Text: 90, Headers: 5, Captions: 10, Footnotes: 20}
+
+	tests := []struct {
+		name     string
+		limit    int
+		counts   []string
+		words    int
+		exceeded bool
+	}{
+		{name: "texto, títulos e legendas", limit: 100, counts: []string{"text", "headers", "captions"}, words: 105, exceeded: true},
+		{name: "apenas texto", limit: 100, counts: []string{"text"}, words: 90, exceeded: false},
+		{name: "com notas de rodapé", limit: 200, counts: []string{"text", "footnotes"}, words: 110, exceeded: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := wordReport{Total: total}
+			applyWordLimit(&report, tt.limit, tt.counts)
+
+			if report.Limit == nil || report.Limit.Words != tt.words || report.Limit.Exceeded != tt.exceeded {
+				t.Errorf("Limit = %+v, expected %d palavras (excedido: %v)", report.Limit, tt.words, tt.exceeded)
+			}
+			if (wordLimitError(report) != nil) != tt.exceeded {
+				t.Errorf("wordLimitError() = %v, expected erro: %v", wordLimitError(report), tt.exceeded)
+			}
+		})
+	}
+
+	report := wordReport{Total: total}
+	applyWordLimit(&report, 0, []string{"text"})
+	if report.Limit != nil {
+		t.Errorf("Limit = %+v, expected nil sem limite", report.Limit)
+	}
+}
+
+func TestMergeFootnotes(t *testing.T) {
+	texcount := []latex.FileWords{{
+		Path:      "./src/main.tex",
+		WordCount: latex.WordCount{Text: 50, Headers: 2},
+		Sections: []latex.SectionWords{
+			{WordCount: latex.WordCount{Text: 10}},
+			{Level: "section", Title: "A", WordCount: latex.WordCount{Text: 40, Headers: 2}},
+		},
+	}}
+	scanned := []latex.FileWords{{
+		Path:      "src/main.tex",
+		WordCount: latex.WordCount{Text: 45, Headers: 2, Footnotes: 5},
+		Sections: []latex.SectionWords{
+			{Line: 3, WordCount: latex.WordCount{Text: 10}},
+			{Level: "section", Title: "A", Line: 7, WordCount: latex.WordCount{Text: 35, Headers: 2, Footnotes: 5}},
+		},
+	}}
+
+	files := mergeFootnotes(texcount, scanned)
+
+	if files[0].Text != 45 || files[0].Footnotes != 5 {
+		t.Errorf("arquivo = %+v, expected 45 de texto e 5 de notas", files[0].WordCount)
+	}
+	if s := files[0].Sections[1]; s.Text != 35 || s.Footnotes != 5 || s.Line != 7 {
+		t.Errorf("seção = %+v, expected 35 de texto, 5 de notas, linha 7", s)
+	}
+}
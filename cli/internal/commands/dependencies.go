@@ -0,0 +1,229 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+	"github.com/martinsmiguel/latex-docker-env/cli/pkg/types"
+)
+
+// newTexRunner escolhe onde executar as ferramentas do TeX Live: no container,
+// se estiver rodando (ou se startContainer for verdadeiro), ou na instalação local.
+// A função retornada libera os recursos do runner.
+func newTexRunner(startContainer bool) (texlive.Runner, func(), error) {
+	containerName := config.GetContainerName()
+
+	// No modo efêmero cada comando roda em um container descartável
+	if mode, err := config.GetContainerMode(); err == nil && mode == config.ContainerModeEphemeral {
+		client, closeClient, err := newDockerClient()
+		if err == nil {
+			image, err := resolveBuildImage()
+			if err == nil {
+				return &texlive.EphemeralRunner{Client: client, Options: ephemeralRunOptions(image)}, closeClient, nil
+			}
+			closeClient()
+			colors.PrintWarn(fmt.Sprintf("Não foi possível preparar a imagem do ambiente: %v", err))
+		}
+	} else if client, err := docker.NewClient(); err == nil {
+		status, err := client.GetContainerStatus(context.Background(), containerName)
+		if err == nil && status != "running" && startContainer {
+			if err := ensureContainerRunning(); err == nil {
+				status = "running"
+			}
+		}
+
+		if status == "running" {
+			closer := func() {
+				if err := client.Close(); err != nil {
+					colors.PrintWarn(fmt.Sprintf("Erro ao fechar cliente Docker: %v", err))
+				}
+			}
+			return &texlive.ContainerRunner{Client: client, Container: containerName}, closer, nil
+		}
+
+		if err := client.Close(); err != nil {
+			colors.PrintWarn(fmt.Sprintf("Erro ao fechar cliente Docker: %v", err))
+		}
+	}
+
+	if texlive.LocalAvailable() {
+		return &texlive.LocalRunner{}, func() {}, nil
+	}
+
+	return nil, nil, fmt.Errorf("container %s não está rodando e não há TeX Live instalado localmente", containerName)
+}
+
+// checkTemplateDependencies verifica os pacotes LaTeX declarados no template.
+// Com install, os pacotes ausentes são instalados na árvore de usuário do TeX Live.
+// Retorna os arquivos que continuam ausentes.
+func checkTemplateDependencies(tmpl *types.Template, install bool, startContainer bool) ([]string, error) {
+	deps := tmpl.Metadata.Dependencies
+	if len(deps) == 0 {
+		colors.PrintInfo("Template não declara dependências")
+		return nil, nil
+	}
+
+	runner, closeRunner, err := newTexRunner(startContainer)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRunner()
+
+	ctx := context.Background()
+	colors.Printf("[INFO] Verificando %d dependência(s) via kpsewhich (%s)...\n", len(deps), runner.Name())
+
+	missing, err := texlive.CheckDependencies(ctx, runner, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) == 0 {
+		colors.PrintSuccess("Todas as dependências do template estão instaladas")
+		return nil, nil
+	}
+
+	colors.PrintWarn(fmt.Sprintf("Dependências ausentes: %s", strings.Join(missing, ", ")))
+
+	if !install {
+		colors.PrintInfo("Use --install-deps para instalá-las com tlmgr no volume latex-cache")
+		return missing, nil
+	}
+
+	packages, unresolved := texlive.ResolvePackages(ctx, runner, missing)
+	if len(unresolved) > 0 {
+		colors.PrintWarn(fmt.Sprintf("Nenhum pacote do TeX Live encontrado para: %s", strings.Join(unresolved, ", ")))
+	}
+
+	if len(packages) > 0 {
+		colors.Printf("[INFO] Instalando com tlmgr: %s\n", strings.Join(packages, " "))
+		if err := texlive.Install(ctx, runner, packages); err != nil {
+			return missing, err
+		}
+	}
+
+	// Verificar novamente após a instalação
+	missing, err = texlive.CheckDependencies(ctx, runner, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(missing) == 0 {
+		colors.PrintSuccess("Dependências instaladas com sucesso")
+	} else {
+		colors.PrintWarn(fmt.Sprintf("Ainda ausentes após instalação: %s", strings.Join(missing, ", ")))
+	}
+
+	return missing, nil
+}
+
+// syncRecordedPackages instala no container os pacotes registrados em ltx-packages.txt,
+// para que todos os colaboradores compilem com os mesmos extras
+func syncRecordedPackages() error {
+	packages, err := texlive.LoadRecord(texlive.RecordFile)
+	if err != nil {
+		return fmt.Errorf("erro ao ler %s: %w", texlive.RecordFile, err)
+	}
+	if len(packages) == 0 {
+		return nil
+	}
+
+	runner, closeRunner, err := newTexRunner(false)
+	if err != nil {
+		return err
+	}
+	defer closeRunner()
+
+	installed, err := texlive.SyncRecord(context.Background(), runner, packages)
+	if err != nil {
+		return err
+	}
+	if installed {
+		colors.Printf("[INFO] Pacotes de %s sincronizados: %s\n", texlive.RecordFile, strings.Join(packages, " "))
+	}
+
+	return nil
+}
+
+// installMissingFromLog procura no log da compilação arquivos não encontrados,
+// descobre os pacotes do TeX Live correspondentes e os instala (com confirmação,
+// a menos que auto seja verdadeiro). Os pacotes instalados ficam registrados em
+// ltx-packages.txt. Retorna true se algum pacote novo foi instalado.
+func installMissingFromLog(logPath string, auto bool, attempted map[string]bool) (bool, error) {
+	content, err := os.ReadFile(logPath)
+	if err != nil {
+		return false, nil
+	}
+
+	missing := texlive.MissingFiles(string(content))
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	colors.PrintWarn(fmt.Sprintf("Arquivos não encontrados pelo TeX: %s", strings.Join(missing, ", ")))
+
+	runner, closeRunner, err := newTexRunner(true)
+	if err != nil {
+		return false, err
+	}
+	defer closeRunner()
+
+	ctx := context.Background()
+	packages, unresolved := texlive.ResolvePackages(ctx, runner, missing)
+	if len(unresolved) > 0 {
+		colors.PrintWarn(fmt.Sprintf("Nenhum pacote do TeX Live fornece: %s", strings.Join(unresolved, ", ")))
+	}
+
+	var pending []string
+	for _, pkg := range packages {
+		if !attempted[pkg] {
+			pending = append(pending, pkg)
+		}
+	}
+	if len(pending) == 0 {
+		return false, nil
+	}
+
+	colors.Printf("[INFO] Pacotes necessários: %s\n", strings.Join(pending, " "))
+
+	if !auto {
+		if !isInteractive() {
+			colors.PrintInfo("Use 'ltx build --auto-install' para instalá-los automaticamente")
+			return false, nil
+		}
+		if !askUserConfirmation("Deseja instalar estes pacotes?") {
+			return false, nil
+		}
+	}
+
+	for _, pkg := range pending {
+		attempted[pkg] = true
+	}
+
+	colors.Printf("[INFO] Instalando com tlmgr: %s\n", strings.Join(pending, " "))
+	added, err := texlive.InstallRecorded(ctx, runner, texlive.RecordFile, pending)
+	if err != nil {
+		return false, err
+	}
+
+	colors.PrintSuccess("Pacotes instalados no volume latex-cache")
+	if len(added) > 0 {
+		colors.Printf("[INFO] Registrados em %s (adicione-o ao controle de versão)\n", texlive.RecordFile)
+	}
+
+	return true, nil
+}
+
+// isInteractive indica se a entrada padrão é um terminal
+func isInteractive() bool {
+	info, err := os.Stdin.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
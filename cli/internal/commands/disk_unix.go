@@ -0,0 +1,14 @@
+//go:build !windows
+
+package commands
+
+import "syscall"
+
+// diskFree retorna o espaço livre, em bytes, no sistema de arquivos de path
+func diskFree(path string) (uint64, bool) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, false
+	}
+	return uint64(stat.Bavail) * uint64(stat.Bsize), true
+}
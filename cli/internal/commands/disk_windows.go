@@ -0,0 +1,8 @@
+//go:build windows
+
+package commands
+
+// diskFree não é verificado no Windows
+func diskFree(path string) (uint64, bool) {
+	return 0, false
+}
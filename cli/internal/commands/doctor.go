@@ -0,0 +1,609 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+)
+
+var doctorJSON bool
+
+var DoctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Diagnostica o ambiente",
+	Long: `Executa uma bateria de verificações do ambiente e informa, para cada uma,
+se passou (✓), merece atenção (⚠) ou falhou (✗), com uma dica de correção:
+
+- Docker: daemon acessível e versão da API
+- Imagem presente e digest conforme o ltx.lock
+- Saúde e montagens do container do projeto
+- Propriedade dos arquivos em dist/ e tmp/ (UID do host)
+- Espaço livre em disco
+- Configuração válida e templates encontrados
+- latexmk e biber disponíveis no container
+- Diferença entre os relógios do host e do container
+
+Retorna erro se alguma verificação falhar.`,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runDoctor(doctorJSON)
+	},
+}
+
+func init() {
+	DoctorCmd.Flags().BoolVar(&doctorJSON, "json", false, "Saída em JSON")
+}
+
+// Resultados de uma verificação do doctor
+const (
+	checkPass = "pass"
+	checkWarn = "warn"
+	checkFail = "fail"
+)
+
+// doctorCheck é o resultado de uma verificação
+type doctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+	Hint    string `json:"hint,omitempty"`
+}
+
+// doctorReport reúne as verificações e o resumo (saída de --json)
+type doctorReport struct {
+	Checks   []doctorCheck `json:"checks"`
+	Passed   int           `json:"passed"`
+	Warnings int           `json:"warnings"`
+	Failures int           `json:"failures"`
+}
+
+func newDoctorReport(checks []doctorCheck) doctorReport {
+	report := doctorReport{Checks: checks}
+	for _, c := range checks {
+		switch c.Status {
+		case checkPass:
+			report.Passed++
+		case checkWarn:
+			report.Warnings++
+		case checkFail:
+			report.Failures++
+		}
+	}
+	return report
+}
+
+func runDoctor(asJSON bool) error {
+	report := newDoctorReport(doctorChecks())
+
+	if asJSON {
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else {
+		printDoctorReport(report)
+	}
+
+	if report.Failures > 0 {
+		return fmt.Errorf("%d verificação(ões) falharam", report.Failures)
+	}
+	return nil
+}
+
+func printDoctorReport(report doctorReport) {
+	fmt.Println("=== Diagnóstico do ambiente ===")
+	fmt.Println()
+
+	for _, c := range report.Checks {
+		symbol := "✓"
+		switch c.Status {
+		case checkWarn:
+			symbol = "⚠"
+		case checkFail:
+			symbol = "✗"
+		}
+
+		fmt.Printf("%s %s: %s\n", symbol, c.Name, c.Message)
+		if c.Hint != "" && c.Status != checkPass {
+			fmt.Printf("    → %s\n", c.Hint)
+		}
+	}
+
+	fmt.Println()
+	fmt.Printf("Resumo: %d ok, %d aviso(s), %d falha(s)\n", report.Passed, report.Warnings, report.Failures)
+}
+
+// doctorEnv guarda o que as verificações do Docker compartilham
+type doctorEnv struct {
+	ctx     context.Context
+	client  *docker.Client
+	mode    string
+	image   string // imagem usada nas compilações
+	name    string // container do projeto
+	running bool   // container do projeto em execução
+}
+
+// doctorChecks executa as verificações na ordem em que são exibidas
+func doctorChecks() []doctorCheck {
+	checks := []doctorCheck{
+		checkConfigValid(),
+		checkTemplateRoots(),
+		checkDiskSpace(),
+		checkFileOwnership(),
+	}
+
+	client, err := docker.NewClient()
+	if err != nil {
+		checks = append(checks, doctorCheck{
+			Name:    "Docker",
+			Status:  checkFail,
+			Message: err.Error(),
+			Hint:    "Inicie o Docker Desktop ou o serviço docker (sudo systemctl start docker) e verifique DOCKER_HOST",
+		})
+		for _, name := range []string{"API do Docker", "Imagem", "Container", "Montagens", "Ferramentas", "Relógio"} {
+			checks = append(checks, doctorCheck{Name: name, Status: checkWarn, Message: "não verificado (Docker indisponível)"})
+		}
+		return checks
+	}
+	defer client.Close()
+
+	checks = append(checks, doctorCheck{Name: "Docker", Status: checkPass, Message: "daemon acessível"})
+
+	mode, err := config.GetContainerMode()
+	if err != nil {
+		mode = config.ContainerModePersistent
+	}
+
+	env := &doctorEnv{
+		ctx:    context.Background(),
+		client: client,
+		mode:   mode,
+		name:   config.GetContainerName(),
+	}
+
+	checks = append(checks,
+		env.checkAPIVersion(),
+		env.checkImage(),
+		env.checkContainer(),
+		env.checkMounts(),
+		env.checkTools(),
+		env.checkClock(),
+	)
+
+	return checks
+}
+
+// checkConfigValid valida o arquivo de configuração e o ltx.lock
+func checkConfigValid() doctorCheck {
+	check := doctorCheck{Name: "Configuração"}
+
+	var problems []string
+	if _, err := config.GetContainerMode(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetBuildTimeout(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetResourceLimits(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetShellEscape(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetShellEscapeTargets(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetBibliographyBackend(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := config.GetLatexmkMaxRepeat(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if _, err := lock.Load(lock.File); err != nil {
+		problems = append(problems, err.Error())
+	}
+
+	if len(problems) > 0 {
+		check.Status = checkFail
+		check.Message = strings.Join(problems, "; ")
+		check.Hint = "Corrija os valores em config/latex-cli.conf (ou no ltx.lock, com 'ltx lock update')"
+		return check
+	}
+
+	file := viper.ConfigFileUsed()
+	if _, err := os.Stat(file); file == "" || err != nil {
+		check.Status = checkWarn
+		check.Message = "arquivo de configuração não encontrado; usando valores padrão"
+		check.Hint = "Execute 'ltx setup' para criar config/latex-cli.conf"
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = "valores válidos em " + file
+	return check
+}
+
+// checkTemplateRoots verifica se há diretórios de templates
+func checkTemplateRoots() doctorCheck {
+	check := doctorCheck{Name: "Templates"}
+
+	var found []string
+	count := 0
+	for _, path := range templatePaths() {
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			continue
+		}
+		found = append(found, path)
+		for _, entry := range entries {
+			if entry.IsDir() {
+				count++
+			}
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		check.Status = checkFail
+		check.Message = "nenhum diretório de templates encontrado"
+		check.Hint = "Execute o ltx na raiz do latex-docker-env ou a partir do executável em bin/"
+	case count == 0:
+		check.Status = checkWarn
+		check.Message = "diretórios encontrados, mas sem templates: " + strings.Join(found, ", ")
+		check.Hint = "Adicione templates em templates/ ou user-templates/"
+	default:
+		check.Status = checkPass
+		check.Message = fmt.Sprintf("%d template(s) em %s", count, strings.Join(found, ", "))
+	}
+	return check
+}
+
+// checkDiskSpace verifica o espaço livre no diretório do projeto
+func checkDiskSpace() doctorCheck {
+	check := doctorCheck{Name: "Espaço em disco"}
+
+	free, ok := diskFree(config.ProjectRoot())
+	if !ok {
+		check.Status = checkWarn
+		check.Message = "não foi possível verificar o espaço livre"
+		return check
+	}
+
+	check.Message = units.HumanSize(float64(free)) + " livres no diretório do projeto"
+	switch {
+	case free < 200*units.MB:
+		check.Status = checkFail
+		check.Hint = "Libere espaço: o latexmk precisa gravar os auxiliares e o PDF"
+	case free < units.GB:
+		check.Status = checkWarn
+		check.Hint = "Pouco espaço livre; 'ltx clean' e 'ltx image prune' liberam arquivos e imagens antigas"
+	default:
+		check.Status = checkPass
+	}
+	return check
+}
+
+// checkFileOwnership verifica se dist/ e tmp/ pertencem ao usuário do host
+func checkFileOwnership() doctorCheck {
+	check := doctorCheck{Name: "UID"}
+
+	user := hostUser()
+	if user == "" {
+		check.Status = checkPass
+		check.Message = "não se aplica neste sistema"
+		return check
+	}
+
+	foreign, err := foreignOwned(generatedDirs()...)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("erro ao verificar dist/ e tmp/: %v", err)
+		return check
+	}
+	if len(foreign) > 0 {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("%d arquivo(s) em dist/ ou tmp/ pertencem a outro usuário (ex.: %s)", len(foreign), foreign[0])
+		check.Hint = "Execute 'ltx clean' ou 'ltx build', que oferecem corrigir a propriedade"
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = "compilações rodam como " + user + "; dist/ e tmp/ pertencem ao seu usuário"
+	return check
+}
+
+func (e *doctorEnv) checkAPIVersion() doctorCheck {
+	check := doctorCheck{Name: "API do Docker"}
+
+	info, err := e.client.ServerInfo(e.ctx)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("erro ao consultar a versão: %v", err)
+		return check
+	}
+
+	check.Message = fmt.Sprintf("Docker %s, API %s (cliente %s)", info.Version, info.APIVersion, info.ClientAPIVersion)
+	if !docker.APISupported(info.APIVersion) {
+		check.Status = checkFail
+		check.Hint = fmt.Sprintf("Atualize o Docker: é necessária a API %s ou superior (Docker 25+)", docker.MinAPIVersion)
+		return check
+	}
+
+	check.Status = checkPass
+	return check
+}
+
+func (e *doctorEnv) checkImage() doctorCheck {
+	check := doctorCheck{Name: "Imagem"}
+
+	l, err := lock.Load(lock.File)
+	if err != nil {
+		check.Status = checkFail
+		check.Message = err.Error()
+		check.Hint = "Recrie o lock com 'ltx lock update'"
+		return check
+	}
+
+	e.image = currentImageRef()
+	if e.image == "" {
+		e.image = defaultEnvImage
+	}
+
+	exists, err := e.client.ImageExists(e.ctx, e.image)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("erro ao verificar %s: %v", e.image, err)
+		return check
+	}
+	if !exists {
+		check.Status = checkWarn
+		check.Message = e.image + " não está disponível localmente"
+		check.Hint = "Será baixada ou construída no próximo 'ltx build' (ou use 'ltx image import' sem internet)"
+		return check
+	}
+
+	if l == nil {
+		check.Status = checkPass
+		check.Message = e.image + " presente (sem ltx.lock)"
+		return check
+	}
+
+	if drift := lockDrift(l); len(drift) > 0 {
+		check.Status = checkWarn
+		check.Message = e.image + " presente, mas " + strings.Join(drift, "; ")
+		check.Hint = "Execute 'ltx lock update' para adotar a configuração atual"
+		return check
+	}
+
+	if exists, err := e.client.ImageExists(e.ctx, lockedRef(l)); err != nil || !exists {
+		check.Status = checkWarn
+		check.Message = "a imagem travada " + l.Digest + " não está disponível localmente"
+		check.Hint = "Será baixada no próximo 'ltx build'"
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = e.image + " presente, digest conforme " + lock.File
+	return check
+}
+
+func (e *doctorEnv) checkContainer() doctorCheck {
+	check := doctorCheck{Name: "Container"}
+
+	if e.mode == config.ContainerModeEphemeral {
+		check.Status = checkPass
+		check.Message = "container_mode=ephemeral: cada compilação usa um container descartável"
+		return check
+	}
+
+	state, err := e.client.ContainerHealth(e.ctx, e.name)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = e.name + " ainda não foi criado"
+		check.Hint = "Execute 'ltx build' para criar o ambiente do projeto"
+		return check
+	}
+
+	e.running = state.Running
+	switch {
+	case !state.Running:
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("%s está parado (%s)", e.name, state.Status)
+		check.Hint = "'ltx build' inicia o container"
+	case state.Health == "healthy":
+		check.Status = checkPass
+		check.Message = e.name + " em execução e saudável"
+	case state.Health == "starting":
+		check.Status = checkWarn
+		check.Message = e.name + " iniciando (healthcheck ainda não concluído)"
+	case state.Health == "":
+		check.Status = checkWarn
+		check.Message = e.name + " em execução, sem healthcheck"
+		check.Hint = "Recrie o container com 'ltx env rm' e 'ltx build'"
+	default:
+		check.Status = checkFail
+		check.Message = fmt.Sprintf("%s está %s", e.name, state.Health)
+		if state.LastOutput != "" {
+			check.Message += ": " + strings.TrimSpace(state.LastOutput)
+		}
+		check.Hint = "Veja 'ltx logs' e recrie o container com 'ltx env rm' e 'ltx build'"
+	}
+	return check
+}
+
+func (e *doctorEnv) checkMounts() doctorCheck {
+	check := doctorCheck{Name: "Montagens"}
+
+	if e.mode == config.ContainerModeEphemeral {
+		check.Status = checkPass
+		check.Message = "definidas a cada compilação (projeto e volume " + config.CacheVolume + ")"
+		return check
+	}
+
+	mounts, err := e.client.ContainerMounts(e.ctx, e.name)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = "não verificado (container inexistente)"
+		return check
+	}
+
+	root := config.ProjectRoot()
+	var problems []string
+
+	if m, ok := findMount(mounts, docker.WorkspaceDir); !ok {
+		problems = append(problems, docker.WorkspaceDir+" não está montado")
+	} else if m.Type != "bind" || !sameHostPath(m.Source, root) {
+		problems = append(problems, fmt.Sprintf("%s aponta para %s, não para %s", docker.WorkspaceDir, m.Source, root))
+	}
+
+	if m, ok := findMount(mounts, docker.CacheDir); !ok {
+		problems = append(problems, docker.CacheDir+" não está montado")
+	} else if m.Type != "volume" || m.Name != config.CacheVolume {
+		problems = append(problems, fmt.Sprintf("%s não usa o volume %s", docker.CacheDir, config.CacheVolume))
+	}
+
+	if len(problems) > 0 {
+		check.Status = checkFail
+		check.Message = strings.Join(problems, "; ")
+		check.Hint = "Recrie o container com 'ltx env rm' e 'ltx build'"
+		return check
+	}
+
+	check.Status = checkPass
+	check.Message = fmt.Sprintf("projeto em %s e cache no volume %s", docker.WorkspaceDir, config.CacheVolume)
+	return check
+}
+
+func findMount(mounts []docker.MountInfo, destination string) (docker.MountInfo, bool) {
+	for _, m := range mounts {
+		if m.Destination == destination {
+			return m, true
+		}
+	}
+	return docker.MountInfo{}, false
+}
+
+// sameHostPath compara caminhos do host; o Docker Desktop pode prefixá-los
+// (ex.: /host_mnt/Users/...)
+func sameHostPath(source, root string) bool {
+	return source == root || strings.HasSuffix(source, root)
+}
+
+// output executa um comando no ambiente do projeto e retorna a saída padrão.
+// Sem container em execução (ou imagem, no modo efêmero) retorna ok=false.
+func (e *doctorEnv) output(cmd []string) (string, bool, error) {
+	var stdout bytes.Buffer
+	var code int
+	var err error
+
+	switch {
+	case e.mode == config.ContainerModeEphemeral:
+		if exists, _ := e.client.ImageExists(e.ctx, e.image); !exists {
+			return "", false, nil
+		}
+		code, err = e.client.Run(e.ctx, docker.RunOptions{Image: e.image, Cmd: cmd, Stdout: &stdout})
+	case e.running:
+		code, err = e.client.Exec(e.ctx, e.name, docker.ExecOptions{Cmd: cmd, Stdout: &stdout})
+	default:
+		return "", false, nil
+	}
+
+	if err != nil {
+		return "", true, err
+	}
+	if code != 0 {
+		return "", true, fmt.Errorf("comando terminou com código %d", code)
+	}
+	return stdout.String(), true, nil
+}
+
+func (e *doctorEnv) checkTools() doctorCheck {
+	check := doctorCheck{Name: "Ferramentas"}
+
+	out, ok, err := e.output([]string{"sh", "-c", "for t in latexmk biber; do command -v $t >/dev/null || echo $t; done"})
+	switch {
+	case !ok:
+		check.Status = checkWarn
+		check.Message = "não verificado (ambiente não está em execução)"
+		check.Hint = "Execute 'ltx build' e rode o doctor novamente"
+		return check
+	case err != nil:
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("erro ao verificar: %v", err)
+		return check
+	}
+
+	missing := strings.Fields(out)
+	switch {
+	case len(missing) == 0:
+		check.Status = checkPass
+		check.Message = "latexmk e biber disponíveis no container"
+	case slices.Contains(missing, "latexmk"):
+		check.Status = checkFail
+		check.Message = "latexmk não encontrado no container"
+		check.Hint = "Use uma imagem com TeX Live completo ou adicione latexmk a TEX_PACKAGES e execute 'ltx image build'"
+	default:
+		check.Status = checkWarn
+		check.Message = "biber não encontrado no container (necessário para biblatex)"
+		check.Hint = "Adicione biber a TEX_PACKAGES e execute 'ltx image build'"
+	}
+	return check
+}
+
+func (e *doctorEnv) checkClock() doctorCheck {
+	check := doctorCheck{Name: "Relógio"}
+
+	before := time.Now()
+	out, ok, err := e.output([]string{"date", "+%s"})
+	host := before.Add(time.Since(before) / 2)
+
+	switch {
+	case !ok:
+		check.Status = checkWarn
+		check.Message = "não verificado (ambiente não está em execução)"
+		return check
+	case err != nil:
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("erro ao ler o relógio do container: %v", err)
+		return check
+	}
+
+	seconds, err := strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+	if err != nil {
+		check.Status = checkWarn
+		check.Message = fmt.Sprintf("saída inesperada de date: %q", strings.TrimSpace(out))
+		return check
+	}
+
+	skew := host.Sub(time.Unix(seconds, 0)).Round(time.Second)
+	if skew < 0 {
+		skew = -skew
+	}
+
+	check.Message = fmt.Sprintf("diferença de %v entre host e container", skew)
+	switch {
+	case skew > time.Minute:
+		check.Status = checkFail
+		check.Hint = "Sincronize o relógio do host; no Docker Desktop, reinicie a VM. O latexmk usa as datas dos arquivos para decidir o que recompilar"
+	case skew > 2*time.Second:
+		check.Status = checkWarn
+		check.Hint = "Relógios fora de sincronia podem fazer o latexmk recompilar sem necessidade ou deixar de recompilar"
+	default:
+		check.Status = checkPass
+	}
+	return check
+}
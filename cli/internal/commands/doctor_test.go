@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestNewDoctorReport(t *testing.T) {
+	report := newDoctorReport([]doctorCheck{
+		{Name: "a", Status: checkPass},
+		{Name: "b", Status: checkWarn},
+		{Name: "c", Status: checkFail},
+		{Name: "d", Status: checkPass},
+	})
+
+	if report.Passed != 2 || report.Warnings != 1 || report.Failures != 1 {
+		t.Errorf("newDoctorReport() = %d ok, %d avisos, %d falhas, expected 2, 1, 1", report.Passed, report.Warnings, report.Failures)
+	}
+}
+
+func TestCheckConfigValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		status   string
+		contains string
+	}{
+		{
+			name:     "container_mode inválido",
+			values:   map[string]string{"container_mode": "temporario"},
+			status:   checkFail,
+			contains: "container_mode",
+		},
+		{
+			name:     "vários erros",
+			values:   map[string]string{"memory_limit": "muito", "shell_escape": "sim"},
+			status:   checkFail,
+			contains: "shell_escape",
+		},
+		{
+			name:     "sem arquivo de configuração",
+			values:   nil,
+			status:   checkWarn,
+			contains: "não encontrado",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			defer viper.Reset()
+			for k, v := range tt.values {
+				viper.Set(k, v)
+			}
+
+			check := checkConfigValid()
+			if check.Status != tt.status {
+				t.Errorf("checkConfigValid() status = %s, expected %s (%s)", check.Status, tt.status, check.Message)
+			}
+			if !strings.Contains(check.Message, tt.contains) {
+				t.Errorf("checkConfigValid() message = %q, expected conter %q", check.Message, tt.contains)
+			}
+		})
+	}
+}
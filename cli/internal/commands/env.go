@@ -0,0 +1,362 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+var (
+	envStopAll bool
+	envRmAll   bool
+	envRmForce bool
+)
+
+var EnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Gerencia os containers de ambiente",
+	Long: `Cada projeto usa um container próprio, com nome derivado da raiz do
+projeto (ltx-<diretório>-<hash>) e identificado pelo label io.ltx.managed.
+Estes comandos gerenciam todos os containers do ltx no host.`,
+}
+
+var envListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista os containers de ambiente do host",
+	Long:  `Lista os containers criados pelo ltx em todos os projetos. O container do projeto atual é marcado com '*'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listEnvs()
+	},
+}
+
+var envStopCmd = &cobra.Command{
+	Use:   "stop [container...]",
+	Short: "Para containers de ambiente",
+	Long:  `Para o container do projeto atual, os containers informados ou, com --all, todos os containers do ltx.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return stopEnvs(args, envStopAll)
+	},
+}
+
+var envRmCmd = &cobra.Command{
+	Use:   "rm [container...]",
+	Short: "Remove containers de ambiente",
+	Long: `Remove o container do projeto atual, os containers informados ou, com --all,
+todos os containers do ltx. O volume com os pacotes instalados é preservado.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return removeEnvs(args, envRmAll)
+	},
+}
+
+func init() {
+	EnvCmd.AddCommand(envListCmd)
+	EnvCmd.AddCommand(envStopCmd)
+	EnvCmd.AddCommand(envRmCmd)
+
+	envStopCmd.Flags().BoolVar(&envStopAll, "all", false, "Para todos os containers do ltx")
+	envRmCmd.Flags().BoolVar(&envRmAll, "all", false, "Remove todos os containers do ltx")
+	envRmCmd.Flags().BoolVarP(&envRmForce, "force", "f", false, "Não pede confirmação")
+}
+
+// ensureProjectEnv garante que o container do projeto exista, use a imagem
+// informada e esteja em execução. Retorna se foi preciso criá-lo ou iniciá-lo.
+func ensureProjectEnv(ctx context.Context, client *docker.Client, image string) (bool, error) {
+	name := config.GetContainerName()
+
+	exists, err := client.ContainerExists(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	opts := projectEnvOptions(name, image)
+
+	if exists {
+		reason := ""
+		if current, err := client.ContainerImage(ctx, name); err == nil && current != image {
+			reason = "Imagem do ambiente mudou"
+		} else if labels, err := client.ContainerLabels(ctx, name); err == nil && labels[docker.LabelLimits] != opts.Limits.String() {
+			reason = "Limites de recursos mudaram"
+		}
+
+		if reason != "" {
+			colors.PrintInfo(reason + ", recriando container...")
+			if err := client.RemoveContainer(ctx, name); err != nil {
+				return false, fmt.Errorf("erro ao remover container %s: %w", name, err)
+			}
+			exists = false
+		}
+	}
+
+	if !exists {
+		colors.PrintInfo(fmt.Sprintf("Criando ambiente %s...", name))
+		if err := client.CreateEnv(ctx, opts); err != nil {
+			return false, err
+		}
+		colors.PrintSuccess("Ambiente Docker iniciado com sucesso")
+		return true, nil
+	}
+
+	state, err := client.ContainerHealth(ctx, name)
+	if err != nil {
+		return false, err
+	}
+
+	// Uma compilação com shell_escape=on interrompida pode ter deixado o container sem rede
+	if err := client.EnsureNetwork(ctx, name); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível reconectar %s à rede: %v", name, err))
+	}
+	if !state.Running {
+		colors.PrintInfo("Iniciando ambiente Docker...")
+		if err := client.StartContainer(ctx, name); err != nil {
+			return false, fmt.Errorf("erro ao iniciar container %s: %w", name, err)
+		}
+		return true, nil
+	}
+
+	return false, nil
+}
+
+// projectEnvOptions descreve o container do projeto atual
+func projectEnvOptions(name, image string) docker.EnvOptions {
+	root := config.ProjectRoot()
+
+	return docker.EnvOptions{
+		Name:        name,
+		Image:       image,
+		ProjectDir:  root,
+		CacheVolume: config.CacheVolume,
+		Env:         []string{"TEXMFHOME=" + texlive.UserTree},
+		Labels:      projectLabels(root),
+		Limits:      containerLimits(),
+	}
+}
+
+// ephemeralRunOptions descreve um container descartável do projeto atual
+// (container_mode=ephemeral), com o projeto e o volume de cache montados
+func ephemeralRunOptions(image string) docker.RunOptions {
+	root := config.ProjectRoot()
+
+	labels := projectLabels(root)
+	labels[docker.LabelEphemeral] = "true"
+
+	return docker.RunOptions{
+		Image:      image,
+		Env:        []string{"TEXMFHOME=" + texlive.UserTree},
+		WorkingDir: docker.WorkspaceDir,
+		Binds: []string{
+			root + ":" + docker.WorkspaceDir,
+			config.CacheVolume + ":" + docker.CacheDir,
+		},
+		Labels: labels,
+		Limits: containerLimits(),
+	}
+}
+
+// containerLimits converte cpu_limit, memory_limit e pids_limit para o Docker.
+// Configurações inválidas são ignoradas com um aviso; o build as valida antes.
+func containerLimits() docker.Limits {
+	limits, err := config.GetResourceLimits()
+	if err != nil {
+		colors.PrintWarn(fmt.Sprintf("%v; containers sem limites de recursos", err))
+		return docker.Limits{}
+	}
+
+	return docker.Limits{
+		NanoCPUs:  int64(limits.CPUs * 1e9),
+		Memory:    limits.Memory,
+		PidsLimit: limits.Pids,
+	}
+}
+
+// projectLabels identifica os containers do projeto
+func projectLabels(root string) map[string]string {
+	return map[string]string{
+		docker.LabelManaged:     "true",
+		docker.LabelProject:     root,
+		docker.LabelProjectHash: config.ProjectHash(root),
+	}
+}
+
+// projectEphemeralContainers lista os containers descartáveis do projeto em execução
+func projectEphemeralContainers(ctx context.Context, client *docker.Client) ([]string, error) {
+	envs, err := client.ListEnvs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hash := config.ProjectHash(config.ProjectRoot())
+
+	var names []string
+	for _, env := range envs {
+		if env.Labels[docker.LabelEphemeral] == "true" && env.Labels[docker.LabelProjectHash] == hash && env.State == "running" {
+			names = append(names, env.Name)
+		}
+	}
+	return names, nil
+}
+
+// ensureDefaultImage constrói a imagem padrão a partir do Dockerfile do
+// devcontainer, se ela ainda não existir
+func ensureDefaultImage(ctx context.Context, client *docker.Client) error {
+	exists, err := client.ImageExists(ctx, defaultEnvImage)
+	if err != nil || exists {
+		return err
+	}
+
+	dockerfile, err := os.ReadFile(filepath.Join("config", "docker", "devcontainer", "Dockerfile"))
+	if err != nil {
+		return fmt.Errorf("erro ao ler o Dockerfile da imagem padrão: %w", err)
+	}
+
+	colors.Printf(">> Construindo imagem padrão %s...\n", defaultEnvImage)
+	return client.BuildImage(ctx, dockerfile, defaultEnvImage, nil, os.Stdout)
+}
+
+func listEnvs() error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	envs, err := client.ListEnvs(context.Background())
+	if err != nil {
+		return fmt.Errorf("erro ao listar containers: %w", err)
+	}
+
+	if len(envs) == 0 {
+		colors.PrintInfo("Nenhum container do ltx encontrado")
+		return nil
+	}
+
+	current := config.GetContainerName()
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  CONTAINER\tESTADO\tIMAGEM\tCRIADO\tPROJETO")
+	for _, env := range envs {
+		marker := " "
+		if env.Name == current {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%s\n",
+			marker, env.Name, env.Status, env.Image, units.HumanDuration(time.Since(env.Created))+" atrás", env.Project)
+	}
+
+	return w.Flush()
+}
+
+// envTargets resolve os containers afetados por stop/rm. Só são aceitos
+// containers criados pelo ltx (label io.ltx.managed=true), para que um nome
+// digitado errado não pare ou remova um container alheio.
+func envTargets(ctx context.Context, client *docker.Client, names []string, all bool) ([]string, error) {
+	envs, err := client.ListEnvs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao listar containers: %w", err)
+	}
+	return selectEnvTargets(envs, names, all, config.GetContainerName())
+}
+
+// selectEnvTargets escolhe, entre os containers do ltx, os informados, todos
+// (all) ou o do projeto atual
+func selectEnvTargets(envs []docker.EnvContainer, names []string, all bool, current string) ([]string, error) {
+	managed := make(map[string]bool, len(envs))
+	targets := make([]string, 0, len(envs))
+	for _, env := range envs {
+		managed[env.Name] = true
+		targets = append(targets, env.Name)
+	}
+
+	if all {
+		return targets, nil
+	}
+
+	if len(names) > 0 {
+		for _, name := range names {
+			if !managed[name] {
+				return nil, fmt.Errorf("%s não é um container do ltx (veja 'ltx env list')", name)
+			}
+		}
+		return names, nil
+	}
+
+	if !managed[current] {
+		return nil, nil
+	}
+	return []string{current}, nil
+}
+
+func stopEnvs(names []string, all bool) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	targets, err := envTargets(ctx, client, names, all)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		colors.PrintInfo("Nenhum container do ltx encontrado")
+		return nil
+	}
+
+	for _, name := range targets {
+		if err := client.StopContainer(ctx, name); err != nil {
+			colors.Printf("[WARN] Não foi possível parar %s: %v\n", name, err)
+		} else {
+			colors.Printf("[STOPPED] %s\n", name)
+		}
+	}
+
+	return nil
+}
+
+func removeEnvs(names []string, all bool) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	targets, err := envTargets(ctx, client, names, all)
+	if err != nil {
+		return err
+	}
+
+	if len(targets) == 0 {
+		colors.PrintInfo("Nenhum container do ltx encontrado")
+		return nil
+	}
+
+	if all && !envRmForce {
+		colors.Printf(">> %d container(s) serão removidos\n", len(targets))
+		if !askUserConfirmation("Deseja continuar?") {
+			colors.PrintInfo("Operação cancelada")
+			return nil
+		}
+	}
+
+	for _, name := range targets {
+		if err := client.RemoveContainer(ctx, name); err != nil {
+			colors.Printf("[WARN] Não foi possível remover %s: %v\n", name, err)
+		} else {
+			colors.Printf("[REMOVED] %s\n", name)
+		}
+	}
+
+	return nil
+}
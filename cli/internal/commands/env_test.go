@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+)
+
+func TestSelectEnvTargets(t *testing.T) {
+	envs := []docker.EnvContainer{{Name: "ltx-tese-1a2b3c"}, {Name: "ltx-artigo-4d5e6f"}}
+
+	tests := []struct {
+		name     string
+		names    []string
+		all      bool
+		current  string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "todos", all: true, expected: []string{"ltx-tese-1a2b3c", "ltx-artigo-4d5e6f"}},
+		{name: "informados", names: []string{"ltx-artigo-4d5e6f"}, expected: []string{"ltx-artigo-4d5e6f"}},
+		{name: "container alheio", names: []string{"ltx-tese-1a2b3c", "postgres"}, wantErr: true},
+		{name: "projeto atual", current: "ltx-tese-1a2b3c", expected: []string{"ltx-tese-1a2b3c"}},
+		{name: "projeto atual sem container do ltx", current: "postgres", expected: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			targets, err := selectEnvTargets(envs, tt.names, tt.all, tt.current)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("selectEnvTargets() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(targets, tt.expected) {
+				t.Errorf("selectEnvTargets() = %v, expected %v", targets, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,198 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+var graphFormat string
+
+var GraphCmd = &cobra.Command{
+	Use:   "graph [arquivo]",
+	Short: "Mostra a árvore de inclusões do projeto",
+	Long: `Percorre o documento a partir do arquivo principal (por padrão src/main.tex),
+seguindo \input, \include, \subfile, \import, \includegraphics, \bibliography e
+\addbibresource, e exibe a árvore de arquivos.
+
+Também lista os arquivos de src/ (.tex, .bib e imagens) que nunca são
+referenciados e as referências a arquivos que não existem.
+
+Formatos (--format): text (padrão), dot (Graphviz) e json.`,
+	Example: `  ltx graph
+  ltx graph --format dot | dot -Tsvg > grafo.svg
+  ltx graph --format json`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := filepath.Join(config.GetSourceDir(), "main.tex")
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runGraph(target, graphFormat)
+	},
+}
+
+func init() {
+	GraphCmd.Flags().StringVar(&graphFormat, "format", "text", "Formato da saída: text, dot ou json")
+}
+
+// graphReport é o grafo do projeto (saída de --format json)
+type graphReport struct {
+	Main    string            `json:"main"`
+	Tree    *latex.Node       `json:"tree"`
+	Orphans []string          `json:"orphans"`
+	Missing []latex.Reference `json:"missing"`
+}
+
+func runGraph(target, format string) error {
+	switch format {
+	case "text", "dot", "json":
+	default:
+		return fmt.Errorf("formato inválido: %q (use text, dot ou json)", format)
+	}
+
+	project, err := latex.Load(target)
+	if err != nil {
+		return fmt.Errorf("arquivo %s não encontrado", target)
+	}
+
+	orphans, err := project.Orphans(config.GetSourceDir())
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("erro ao listar %s: %w", config.GetSourceDir(), err)
+	}
+
+	report := graphReport{
+		Main:    target,
+		Tree:    project.Tree(),
+		Orphans: orphans,
+		Missing: project.MissingReferences(),
+	}
+	if report.Orphans == nil {
+		report.Orphans = []string{}
+	}
+	if report.Missing == nil {
+		report.Missing = []latex.Reference{}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "dot":
+		writeGraphDOT(os.Stdout, report)
+	default:
+		printGraph(report)
+	}
+	return nil
+}
+
+// printGraph exibe a árvore em texto, seguida dos órfãos e das referências ausentes
+func printGraph(r graphReport) {
+	fmt.Println(r.Tree.Path)
+	printGraphChildren(r.Tree, "")
+
+	fmt.Println()
+	if len(r.Orphans) == 0 {
+		fmt.Printf("✓ Nenhum arquivo órfão em %s\n", config.GetSourceDir())
+	} else {
+		fmt.Printf("⚠ Arquivos em %s nunca referenciados (%d):\n", config.GetSourceDir(), len(r.Orphans))
+		for _, orphan := range r.Orphans {
+			fmt.Printf("  %s\n", orphan)
+		}
+	}
+
+	if len(r.Missing) == 0 {
+		fmt.Println("✓ Todas as referências apontam para arquivos existentes")
+	} else {
+		fmt.Printf("✗ Referências a arquivos inexistentes (%d):\n", len(r.Missing))
+		for _, ref := range r.Missing {
+			fmt.Printf("  %s:%d \\%s{%s}\n", ref.From, ref.Line, ref.Command, ref.Target)
+		}
+	}
+}
+
+func printGraphChildren(node *latex.Node, prefix string) {
+	for i, child := range node.Children {
+		branch, indent := "├── ", "│   "
+		if i == len(node.Children)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		note := child.Command
+		switch {
+		case child.Missing:
+			note += ", não encontrado"
+		case child.Repeated:
+			note += ", já listado"
+		}
+
+		marker := ""
+		if child.Missing {
+			marker = "✗ "
+		}
+		fmt.Printf("%s%s%s%s (%s)\n", prefix, branch, marker, child.Path, note)
+		printGraphChildren(child, prefix+indent)
+	}
+}
+
+// writeGraphDOT escreve o grafo no formato DOT do Graphviz
+func writeGraphDOT(w io.Writer, r graphReport) {
+	fmt.Fprintln(w, "digraph ltx {")
+	fmt.Fprintln(w, "  rankdir=LR;")
+	fmt.Fprintln(w, "  node [shape=box, fontname=\"Helvetica\"];")
+
+	declared := make(map[string]bool)
+	edges := make(map[string]bool)
+
+	var walk func(node *latex.Node)
+	walk = func(node *latex.Node) {
+		if !declared[node.Path] {
+			declared[node.Path] = true
+			fmt.Fprintf(w, "  %s [%s];\n", dotQuote(node.Path), dotNodeStyle(node))
+		}
+		for _, child := range node.Children {
+			walk(child)
+			edge := node.Path + "\x00" + child.Path
+			if !edges[edge] {
+				edges[edge] = true
+				fmt.Fprintf(w, "  %s -> %s [label=%s];\n", dotQuote(node.Path), dotQuote(child.Path), dotQuote(child.Command))
+			}
+		}
+	}
+	walk(r.Tree)
+
+	for _, orphan := range r.Orphans {
+		fmt.Fprintf(w, "  %s [style=dotted, color=gray, fontcolor=gray];\n", dotQuote(orphan))
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func dotNodeStyle(node *latex.Node) string {
+	shape := "box"
+	switch node.Kind {
+	case latex.KindGraphic:
+		shape = "note"
+	case latex.KindBib:
+		shape = "cylinder"
+	}
+
+	if node.Missing {
+		return fmt.Sprintf("shape=%s, style=dashed, color=red, fontcolor=red", shape)
+	}
+	return "shape=" + shape
+}
+
+func dotQuote(s string) string {
+	return `"` + strings.ReplaceAll(strings.ReplaceAll(s, `\`, `\\`), `"`, `\"`) + `"`
+}
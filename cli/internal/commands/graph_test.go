@@ -0,0 +1,39 @@
+package commands
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+func TestWriteGraphDOT(t *testing.T) {
+	tree := &latex.Node{Path: "src/main.tex", Kind: latex.KindTeX, Children: []*latex.Node{
+		{Path: "src/cap1.tex", Kind: latex.KindTeX, Command: "input", Children: []*latex.Node{
+			{Path: "src/main.tex", Kind: latex.KindTeX, Command: "input", Repeated: true},
+		}},
+		{Path: "src/cap1.tex", Kind: latex.KindTeX, Command: "input", Repeated: true},
+		{Path: `fig "a"`, Kind: latex.KindGraphic, Command: "includegraphics", Missing: true},
+	}}
+
+	var out bytes.Buffer
+	writeGraphDOT(&out, graphReport{Tree: tree, Orphans: []string{"src/velho.tex"}})
+	dot := out.String()
+
+	expected := []string{
+		`"src/main.tex" -> "src/cap1.tex" [label="input"];`,
+		`"src/cap1.tex" -> "src/main.tex" [label="input"];`,
+		`"fig \"a\"" [shape=note, style=dashed, color=red, fontcolor=red];`,
+		`"src/velho.tex" [style=dotted, color=gray, fontcolor=gray];`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(dot, line) {
+			t.Errorf("DOT sem %q:\n%s", line, dot)
+		}
+	}
+
+	if n := strings.Count(dot, `"src/main.tex" -> "src/cap1.tex"`); n != 1 {
+		t.Errorf("aresta main -> cap1 aparece %d vezes, expected 1", n)
+	}
+}
@@ -0,0 +1,288 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/history"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+// compileSource identifica no histórico quem disparou a compilação
+var compileSource = history.SourceBuild
+
+var (
+	historyTarget string
+	historyEngine string
+	historyStatus string
+	historySource string
+	historySince  string
+	historyLast   int
+	historyChart  string
+	historyJSON   bool
+)
+
+var HistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Histórico e estatísticas das compilações",
+	Long: `Lista as compilações registradas por 'ltx build' e 'ltx watch' em
+.ltx/history: data, documento, engine, duração, passadas do latexmk, resultado,
+avisos e erros do log, páginas e tamanho do PDF.
+
+Com --chart exibe a evolução de uma grandeza em barras no terminal, para ver
+quando as compilações ficaram lentas ou os avisos começaram a se acumular.`,
+	Example: `  ltx history
+  ltx history --since 7d --chart duration
+  ltx history --status failure --last 0
+  ltx history --json > historico.json`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runHistory()
+	},
+}
+
+func init() {
+	HistoryCmd.Flags().StringVar(&historyTarget, "target", "", "Apenas o documento informado (caminho ou nome, ex.: main)")
+	HistoryCmd.Flags().StringVar(&historyEngine, "engine", "", "Apenas compilações com a engine informada")
+	HistoryCmd.Flags().StringVar(&historyStatus, "status", "", "Apenas compilações com o resultado informado (success, failure, timeout, oom)")
+	HistoryCmd.Flags().StringVar(&historySource, "source", "", "Apenas compilações de build ou de watch")
+	HistoryCmd.Flags().StringVar(&historySince, "since", "", "Apenas compilações a partir de uma data ou período (2026-10-01, 7d, 12h)")
+	HistoryCmd.Flags().IntVarP(&historyLast, "last", "n", 20, "Número de compilações mais recentes (0 = todas)")
+	HistoryCmd.Flags().StringVar(&historyChart, "chart", "", "Gráfico de uma grandeza (duration, passes, warnings, errors, pages, size)")
+	HistoryCmd.Flags().BoolVar(&historyJSON, "json", false, "Saída em JSON")
+}
+
+func runHistory() error {
+	filter, err := historyFilter(time.Now())
+	if err != nil {
+		return err
+	}
+
+	var metric history.Metric
+	if historyChart != "" {
+		if metric, err = history.FindMetric(historyChart); err != nil {
+			return err
+		}
+	}
+
+	records, err := history.Load(history.Dir)
+	if err != nil {
+		return fmt.Errorf("erro ao ler o histórico: %w", err)
+	}
+	records = filter.Apply(records)
+
+	if historyJSON {
+		if records == nil {
+			records = []history.Record{}
+		}
+		data, err := json.MarshalIndent(records, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if len(records) == 0 {
+		colors.PrintInfo("Nenhuma compilação registrada (execute 'ltx build' ou 'ltx watch')")
+		return nil
+	}
+
+	if historyChart != "" {
+		printHistoryChart(records, metric)
+	} else {
+		printHistoryTable(records)
+	}
+	printHistorySummary(records)
+	return nil
+}
+
+// historyFilter monta o filtro a partir das flags
+func historyFilter(now time.Time) (history.Filter, error) {
+	filter := history.Filter{
+		Target: historyTarget,
+		Engine: historyEngine,
+		Status: historyStatus,
+		Source: historySource,
+		Last:   historyLast,
+	}
+
+	switch historyStatus {
+	case "", history.StatusSuccess, history.StatusFailure, history.StatusTimeout, history.StatusOOM:
+	default:
+		return filter, fmt.Errorf("status inválido: %q (use %s, %s, %s ou %s)", historyStatus, history.StatusSuccess, history.StatusFailure, history.StatusTimeout, history.StatusOOM)
+	}
+	switch historySource {
+	case "", history.SourceBuild, history.SourceWatch:
+	default:
+		return filter, fmt.Errorf("origem inválida: %q (use %s ou %s)", historySource, history.SourceBuild, history.SourceWatch)
+	}
+	if historyLast < 0 {
+		return filter, fmt.Errorf("--last deve ser 0 ou positivo")
+	}
+
+	since, err := history.ParseSince(historySince, now)
+	if err != nil {
+		return filter, err
+	}
+	filter.Since = since
+	return filter, nil
+}
+
+func printHistoryTable(records []history.Record) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "DATA\tDOCUMENTO\tENGINE\tDURAÇÃO\tPASSADAS\tRESULTADO\tAVISOS\tERROS\tPÁGINAS\tPDF")
+	for _, r := range records {
+		size := "-"
+		if r.PDFSize > 0 {
+			size = units.HumanSize(float64(r.PDFSize))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%v\t%d\t%s\t%d\t%d\t%d\t%s\n",
+			r.At.Local().Format("2006-01-02 15:04"), r.Target, r.Engine, r.Duration().Round(100*time.Millisecond),
+			r.Passes, statusSymbol(r.Status), r.Warnings, r.Errors, r.Pages, size)
+	}
+	w.Flush()
+}
+
+func printHistoryChart(records []history.Record, metric history.Metric) {
+	values := make([]float64, len(records))
+	for i, r := range records {
+		values[i] = metric.Value(r)
+	}
+
+	fmt.Printf("%s por compilação:\n", metric.Label)
+	for i, bar := range history.Bars(values, 40) {
+		r := records[i]
+		mark := " "
+		if r.Status != history.StatusSuccess {
+			mark = "✗"
+		}
+		fmt.Printf("  %s %s %-40s %s\n", r.At.Local().Format("2006-01-02 15:04"), mark, bar, metric.Format(values[i]))
+	}
+}
+
+// printHistorySummary resume as compilações listadas e compara a duração das
+// mais recentes com a das anteriores
+func printHistorySummary(records []history.Record) {
+	var failures int
+	var total time.Duration
+	for _, r := range records {
+		if r.Status != history.StatusSuccess {
+			failures++
+		}
+		total += r.Duration()
+	}
+
+	fmt.Println()
+	colors.Printf("[INFO] %d compilação(ões), %d com falha, duração média %v\n",
+		len(records), failures, (total / time.Duration(len(records))).Round(100*time.Millisecond))
+
+	if change, ok := durationTrend(records); ok && change >= 0.2 {
+		colors.PrintWarn(fmt.Sprintf("As últimas compilações estão %.0f%% mais lentas que as anteriores", change*100))
+	}
+}
+
+// durationTrend compara a duração média da metade mais recente das
+// compilações bem-sucedidas com a da metade anterior (0.25 = 25% mais lenta)
+func durationTrend(records []history.Record) (float64, bool) {
+	var durations []float64
+	for _, r := range records {
+		if r.Status == history.StatusSuccess {
+			durations = append(durations, float64(r.DurationMS))
+		}
+	}
+	if len(durations) < 4 {
+		return 0, false
+	}
+
+	half := len(durations) / 2
+	mean := func(values []float64) float64 {
+		var sum float64
+		for _, v := range values {
+			sum += v
+		}
+		return sum / float64(len(values))
+	}
+
+	before, after := mean(durations[:half]), mean(durations[half:])
+	if before <= 0 {
+		return 0, false
+	}
+	return after/before - 1, true
+}
+
+func statusSymbol(status string) string {
+	switch status {
+	case history.StatusSuccess:
+		return "✓ " + status
+	case history.StatusTimeout, history.StatusOOM:
+		return "⚠ " + status
+	default:
+		return "✗ " + status
+	}
+}
+
+// latexmkProgress acompanha a saída do latexmk (passadas e "up-to-date"),
+// com um contador por fluxo
+type latexmkProgress struct {
+	stdout texlive.PassCounter
+	stderr texlive.PassCounter
+}
+
+// streams acrescenta os contadores aos destinos de stdout e stderr
+func (p *latexmkProgress) streams(stdout, stderr io.Writer) (io.Writer, io.Writer) {
+	return io.MultiWriter(stdout, &p.stdout), io.MultiWriter(stderr, &p.stderr)
+}
+
+func (p *latexmkProgress) passes() int {
+	return p.stdout.Passes + p.stderr.Passes
+}
+
+func (p *latexmkProgress) upToDate() bool {
+	return p.stdout.UpToDate || p.stderr.UpToDate
+}
+
+// recordCompilation registra no histórico uma execução do latexmk, com o
+// resultado (veja compileStatus), o resumo do log (nos auxiliares) e o tamanho
+// do PDF. Execuções em que o latexmk informou que tudo estava atualizado não
+// são registradas.
+func recordCompilation(mainTexPath string, rc texlive.Latexmkrc, started time.Time, exitCode int, status string, progress *latexmkProgress) {
+	if progress.upToDate() && exitCode == 0 {
+		return
+	}
+
+	record := history.Record{
+		At:         started.UTC().Truncate(time.Second),
+		Source:     compileSource,
+		Target:     rc.Target,
+		Engine:     rc.Engine,
+		DurationMS: time.Since(started).Milliseconds(),
+		Passes:     progress.passes(),
+		Status:     status,
+		ExitCode:   exitCode,
+	}
+
+	name := targetName(mainTexPath)
+	if data, err := os.ReadFile(filepath.Join(rc.AuxDir, name+".log")); err == nil {
+		summary := texlive.SummarizeLog(string(data))
+		record.Warnings, record.Errors, record.Pages = summary.Warnings, summary.Errors, summary.Pages
+	}
+	if exitCode == 0 {
+		if info, err := os.Stat(filepath.Join(rc.OutDir, name+".pdf")); err == nil {
+			record.PDFSize = info.Size()
+		}
+	}
+
+	if err := history.Append(history.Dir, record); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível registrar a compilação no histórico: %v", err))
+	}
+}
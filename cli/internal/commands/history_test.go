@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestLatexmkProgress(t *testing.T) {
+	tests := []struct {
+		name           string
+		stdout         string
+		stderr         string
+		expectPasses   int
+		expectUpToDate bool
+	}{
+		{
+			name:         "passadas no stderr",
+			stdout:       "This is pdfTeX, Version 3.141592653\nOutput written on tmp/main.pdf (3 pages, 1024 bytes).\n",
+			stderr:       "Rc files read:\n  .ltx/main.latexmkrc\nRun number 1 of rule 'pdflatex'\nRun number 1 of rule 'biber'\nRun number 2 of rule 'pdflatex'\n",
+			expectPasses: 2,
+		},
+		{
+			name:           "nada a compilar",
+			stderr:         "Latexmk: All targets (dist/main.pdf) are up-to-date\n",
+			expectUpToDate: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var progress latexmkProgress
+			var outBuf, errBuf bytes.Buffer
+			stdout, stderr := progress.streams(&outBuf, &errBuf)
+
+			// Os fluxos chegam intercalados, em pedaços
+			for i := 0; i < len(tt.stdout) || i < len(tt.stderr); i += 7 {
+				if i < len(tt.stdout) {
+					_, _ = stdout.Write([]byte(tt.stdout[i:min(i+7, len(tt.stdout))]))
+				}
+				if i < len(tt.stderr) {
+					_, _ = stderr.Write([]byte(tt.stderr[i:min(i+7, len(tt.stderr))]))
+				}
+			}
+
+			if got := progress.passes(); got != tt.expectPasses {
+				t.Errorf("passes() = %d, expected %d", got, tt.expectPasses)
+			}
+			if got := progress.upToDate(); got != tt.expectUpToDate {
+				t.Errorf("upToDate() = %v, expected %v", got, tt.expectUpToDate)
+			}
+			if outBuf.String() != tt.stdout || errBuf.String() != tt.stderr {
+				t.Error("a saída do latexmk deve ser repassada sem alterações")
+			}
+		})
+	}
+}
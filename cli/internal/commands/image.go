@@ -0,0 +1,675 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	units "github.com/docker/go-units"
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/image"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+)
+
+// defaultEnvImage é a tag da imagem padrão, construída a partir do Dockerfile do
+// devcontainer quando nenhuma imagem foi configurada
+const defaultEnvImage = image.DefaultEnvImage
+
+var (
+	imageBuildForce  bool
+	imageBuildDryRun bool
+	imageListAll     bool
+	imagePruneForce  bool
+	imageUseNoPull   bool
+)
+
+var ImageCmd = &cobra.Command{
+	Use:   "image",
+	Short: "Gerencia a imagem Docker do LaTeX",
+	Long: `Comandos para baixar, construir, inspecionar e trocar a imagem Docker
+usada nas compilações.`,
+}
+
+var imageBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Constrói a imagem derivada com os pacotes extras",
+	Long: `Gera um Dockerfile derivado da imagem base configurada (latex_image),
+incorporando os pacotes listados na configuração do projeto:
+
+  TEX_PACKAGES  pacotes do TeX Live (tlmgr)
+  APT_PACKAGES  pacotes do sistema (apt-get)
+  PIP_PACKAGES  pacotes Python (pip)
+
+A imagem é construída pela API do Docker e marcada com um hash do conteúdo
+da especificação (ltx-local/latex:<hash>). As compilações usam essa tag
+automaticamente, e a imagem só é reconstruída quando a especificação muda.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return buildDerivedImage()
+	},
+}
+
+var imagePullCmd = &cobra.Command{
+	Use:   "pull [imagem]",
+	Short: "Baixa ou atualiza a imagem LaTeX",
+	Long: `Baixa a imagem informada (padrão: latex_image da configuração), exibindo
+o progresso de cada camada. Se a imagem já existir, verifica se há atualização.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := config.GetLatexImage()
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		return pullImage(ref)
+	},
+}
+
+var imageListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lista as imagens LaTeX locais",
+	Long: `Lista as imagens LaTeX disponíveis localmente, com o ano do TeX Live e o
+tamanho. A imagem em uso pelo projeto é marcada com '*'.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return listImages()
+	},
+}
+
+var imageInspectCmd = &cobra.Command{
+	Use:   "inspect [imagem]",
+	Short: "Mostra detalhes de uma imagem",
+	Long:  `Mostra digest, tamanho, ano do TeX Live e metadados de uma imagem local (padrão: a imagem em uso).`,
+	Args:  cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		ref := ""
+		if len(args) == 1 {
+			ref = args[0]
+		}
+		return inspectImage(ref)
+	},
+}
+
+var imagePruneCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "Remove imagens derivadas obsoletas",
+	Long: `Remove as imagens derivadas (ltx-local/latex) que não correspondem mais
+à especificação atual, além de imagens sem tag deixadas por builds anteriores.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return pruneImages()
+	},
+}
+
+var imageUseCmd = &cobra.Command{
+	Use:   "use <imagem>",
+	Short: "Troca a imagem LaTeX do projeto",
+	Long: `Define latex_image no arquivo de configuração do projeto, baixando a imagem
+se necessário. O container é recriado com a nova imagem no próximo build.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return useImage(args[0])
+	},
+}
+
+var imageExportCmd = &cobra.Command{
+	Use:   "export <arquivo>",
+	Short: "Exporta a imagem em uso para um arquivo",
+	Long: `Exporta a imagem usada nas compilações (e sua base, se houver pacotes
+extras) para um arquivo, junto com o ltx.lock do projeto, permitindo instalar
+o ambiente em máquinas sem rede com 'ltx image import' ou 'ltx setup --image-archive'.
+
+A compressão segue a extensão: .tar.zst (requer o zstd), .tar.gz ou .tar.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return exportImage(args[0])
+	},
+}
+
+var imageImportCmd = &cobra.Command{
+	Use:   "import <arquivo>",
+	Short: "Importa a imagem de um arquivo exportado",
+	Long: `Carrega no Docker a imagem de um arquivo gerado por 'ltx image export',
+sem acesso à rede. Se o projeto ainda não tiver ltx.lock, o lock contido no
+arquivo é restaurado.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return importImage(args[0])
+	},
+}
+
+func init() {
+	ImageCmd.AddCommand(imageBuildCmd)
+	ImageCmd.AddCommand(imagePullCmd)
+	ImageCmd.AddCommand(imageListCmd)
+	ImageCmd.AddCommand(imageInspectCmd)
+	ImageCmd.AddCommand(imagePruneCmd)
+	ImageCmd.AddCommand(imageUseCmd)
+	ImageCmd.AddCommand(imageExportCmd)
+	ImageCmd.AddCommand(imageImportCmd)
+
+	imageBuildCmd.Flags().BoolVar(&imageBuildForce, "force", false, "Reconstrói mesmo se a imagem já existir")
+	imageBuildCmd.Flags().BoolVar(&imageBuildDryRun, "dry-run", false, "Apenas mostra o Dockerfile gerado")
+	imageListCmd.Flags().BoolVar(&imageListAll, "all", false, "Lista todas as imagens locais, não apenas as de LaTeX")
+	imagePruneCmd.Flags().BoolVarP(&imagePruneForce, "force", "f", false, "Não pede confirmação")
+	imageUseCmd.Flags().BoolVar(&imageUseNoPull, "no-pull", false, "Não baixa a imagem se ela não existir localmente")
+}
+
+// newDockerClient cria um cliente Docker e a função que o fecha
+func newDockerClient() (*docker.Client, func(), error) {
+	client, err := docker.NewClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	closer := func() {
+		if err := client.Close(); err != nil {
+			colors.PrintWarn(fmt.Sprintf("Erro ao fechar cliente Docker: %v", err))
+		}
+	}
+
+	return client, closer, nil
+}
+
+func buildDerivedImage() error {
+	spec := effectiveSpec()
+
+	if spec.Empty() {
+		colors.PrintInfo("Nenhum pacote extra configurado (TEX_PACKAGES, APT_PACKAGES, PIP_PACKAGES)")
+		colors.Printf("[INFO] As compilações usam a imagem padrão do ambiente\n")
+		return nil
+	}
+
+	if imageBuildDryRun {
+		fmt.Print(string(spec.Dockerfile("<usuário da imagem base>")))
+		return nil
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	colors.Printf(">> Construindo imagem derivada de %s...\n", spec.Base)
+
+	tag, built, err := ensureDerivedImage(context.Background(), client, spec, imageBuildForce)
+	if err != nil {
+		return err
+	}
+
+	if built {
+		colors.PrintSuccess(fmt.Sprintf("Imagem %s construída", tag))
+	} else {
+		colors.Printf("[OK] Imagem %s já está atualizada\n", tag)
+	}
+
+	return nil
+}
+
+func pullImage(ref string) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	colors.Printf(">> Baixando imagem %s...\n", ref)
+
+	if err := client.Pull(ctx, ref, os.Stdout); err != nil {
+		return err
+	}
+
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	year, err := image.TexLiveYear(ctx, client, ref)
+	if err != nil || year == "" {
+		year = "desconhecido"
+	}
+
+	colors.PrintSuccess(fmt.Sprintf("Imagem %s pronta", ref))
+	colors.Printf("   TeX Live: %s\n", year)
+	colors.Printf("   Tamanho: %s\n", units.HumanSize(float64(info.Size)))
+
+	return nil
+}
+
+// isLatexImage identifica imagens relevantes para o ltx na listagem
+func isLatexImage(tag string) bool {
+	lower := strings.ToLower(tag)
+	return strings.HasPrefix(lower, image.DerivedRepository+":") ||
+		strings.Contains(lower, "latex") ||
+		strings.Contains(lower, "texlive") ||
+		tag == config.GetLatexImage()
+}
+
+func listImages() error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	images, err := client.ListImages(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao listar imagens: %w", err)
+	}
+
+	current := currentImageRef()
+
+	type row struct {
+		tag, id, year, size, created string
+		inUse                        bool
+	}
+
+	var rows []row
+	for _, img := range images {
+		for _, tag := range img.Tags {
+			if !imageListAll && !isLatexImage(tag) {
+				continue
+			}
+
+			year := "?"
+			if info, err := client.InspectImage(ctx, tag); err == nil {
+				if y := image.TexLiveYearFromEnv(info.Env); y != "" {
+					year = y
+				}
+			}
+
+			rows = append(rows, row{
+				tag:     tag,
+				id:      shortID(img.ID),
+				year:    year,
+				size:    units.HumanSize(float64(img.Size)),
+				created: img.Created.Format("2006-01-02"),
+				inUse:   tag == current,
+			})
+		}
+	}
+
+	if len(rows) == 0 {
+		colors.PrintInfo("Nenhuma imagem LaTeX encontrada localmente")
+		colors.Printf("[INFO] Use 'ltx image pull' para baixar %s\n", config.GetLatexImage())
+		return nil
+	}
+
+	sort.Slice(rows, func(i, j int) bool { return rows[i].tag < rows[j].tag })
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  IMAGEM\tID\tTEX LIVE\tTAMANHO\tCRIADA")
+	for _, r := range rows {
+		marker := " "
+		if r.inUse {
+			marker = "*"
+		}
+		fmt.Fprintf(w, "%s %s\t%s\t%s\t%s\t%s\n", marker, r.tag, r.id, r.year, r.size, r.created)
+	}
+
+	return w.Flush()
+}
+
+func inspectImage(ref string) error {
+	if ref == "" {
+		ref = currentImageRef()
+		if ref == "" {
+			ref = config.GetLatexImage()
+		}
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("imagem %s não encontrada localmente. Use 'ltx image pull %s'", ref, ref)
+	}
+
+	year, err := image.TexLiveYear(ctx, client, ref)
+	if err != nil || year == "" {
+		year = "desconhecido"
+	}
+
+	fmt.Printf("=== Imagem %s ===\n", ref)
+	fmt.Printf("ID: %s\n", info.ID)
+	if len(info.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(info.Tags, ", "))
+	}
+	if len(info.Digests) > 0 {
+		fmt.Printf("Digests: %s\n", strings.Join(info.Digests, ", "))
+	} else {
+		fmt.Println("Digests: nenhum (imagem construída localmente)")
+	}
+	fmt.Printf("TeX Live: %s\n", year)
+	fmt.Printf("Tamanho: %s\n", units.HumanSize(float64(info.Size)))
+	if !info.Created.IsZero() {
+		fmt.Printf("Criada em: %s\n", info.Created.Format("2006-01-02 15:04:05"))
+	}
+	if info.User != "" {
+		fmt.Printf("Usuário: %s\n", info.User)
+	}
+	if hash, ok := info.Labels[image.SpecLabel]; ok {
+		fmt.Printf("Imagem derivada de: %s\n", info.Labels[image.BaseLabel])
+		fmt.Printf("Especificação: %s\n", hash)
+	}
+	if ref == currentImageRef() {
+		fmt.Println("Em uso pelo projeto: sim")
+	}
+
+	return nil
+}
+
+func pruneImages() error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	images, err := client.ListImages(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao listar imagens: %w", err)
+	}
+
+	current := effectiveSpec().Tag()
+
+	var obsolete []string
+	for _, img := range images {
+		for _, tag := range img.Tags {
+			if strings.HasPrefix(tag, image.DerivedRepository+":") && tag != current {
+				obsolete = append(obsolete, tag)
+			}
+		}
+	}
+
+	if len(obsolete) > 0 {
+		colors.Println(">> Imagens derivadas obsoletas:")
+		for _, tag := range obsolete {
+			colors.Printf("   - %s\n", tag)
+		}
+
+		if !imagePruneForce && !askUserConfirmation("Deseja removê-las?") {
+			colors.PrintInfo("Operação cancelada")
+			return nil
+		}
+
+		for _, tag := range obsolete {
+			if err := client.RemoveImage(ctx, tag); err != nil {
+				colors.Printf("[WARN] Não foi possível remover %s: %v\n", tag, err)
+			} else {
+				colors.Printf("[REMOVED] %s\n", tag)
+			}
+		}
+	} else {
+		colors.PrintInfo("Nenhuma imagem derivada obsoleta")
+	}
+
+	reclaimed, err := client.PruneDanglingImages(ctx)
+	if err != nil {
+		return fmt.Errorf("erro ao remover imagens sem tag: %w", err)
+	}
+	colors.Printf("[SUCCESS] Espaço liberado por imagens sem tag: %s\n", units.HumanSize(float64(reclaimed)))
+
+	return nil
+}
+
+func useImage(ref string) error {
+	if !imageUseNoPull {
+		client, closeClient, err := newDockerClient()
+		if err != nil {
+			return err
+		}
+		err = client.PullImage(context.Background(), ref)
+		closeClient()
+		if err != nil {
+			return fmt.Errorf("erro ao obter imagem %s: %w", ref, err)
+		}
+	}
+
+	if err := config.SetValue("latex_image", ref); err != nil {
+		return fmt.Errorf("erro ao atualizar configuração: %w", err)
+	}
+
+	colors.PrintSuccess(fmt.Sprintf("Projeto configurado para usar %s", ref))
+	colors.Printf("[INFO] Configuração salva em %s\n", config.FilePath())
+
+	if loadProjectLock() != nil {
+		if imageUseNoPull {
+			colors.PrintWarn(fmt.Sprintf("%s não foi atualizado; execute 'ltx lock update'", lock.File))
+		} else if err := updateLock(); err != nil {
+			return fmt.Errorf("erro ao atualizar %s: %w", lock.File, err)
+		}
+	}
+
+	if !image.SpecFromConfig().Empty() {
+		colors.PrintInfo("Há pacotes extras configurados: a imagem derivada será reconstruída sobre a nova base no próximo build")
+	} else {
+		colors.PrintInfo("O container será recriado com a nova imagem no próximo build")
+	}
+
+	return nil
+}
+
+func exportImage(path string) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+
+	ref := currentImageRef()
+	if ref == "" {
+		ref = defaultEnvImage
+	}
+
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return fmt.Errorf("imagem %s não existe localmente (execute 'ltx build' ou 'ltx image pull' antes): %w", ref, err)
+	}
+
+	manifest := &image.Manifest{
+		Image:      ref,
+		ID:         info.ID,
+		Base:       ref,
+		BaseID:     info.ID,
+		Lock:       loadProjectLock(),
+		ExportedAt: time.Now().UTC().Truncate(time.Second),
+	}
+	manifest.Host, _ = os.Hostname()
+	refs := saveRefs(info)
+
+	// A imagem derivada é exportada junto com a base, para que 'ltx lock update'
+	// e reconstruções funcionem na máquina de destino
+	if spec := effectiveSpec(); !spec.Empty() {
+		base, err := client.InspectImage(ctx, spec.Base)
+		if err != nil {
+			return fmt.Errorf("imagem base %s não existe localmente: %w", spec.Base, err)
+		}
+		manifest.Base = spec.Base
+		manifest.BaseID = base.ID
+		refs = append(refs, saveRefs(base)...)
+		info = base
+	}
+
+	if manifest.Lock != nil {
+		manifest.Digest = manifest.Lock.Digest
+	} else if digest, err := lock.DigestFor(config.GetLatexImage(), info.Digests); err == nil {
+		manifest.Digest = digest
+	}
+
+	if year, err := image.TexLiveYear(ctx, client, ref); err == nil {
+		manifest.TexLive = year
+	}
+
+	colors.Printf(">> Exportando %s para %s...\n", ref, path)
+	if err := image.Export(ctx, client, refs, manifest, path); err != nil {
+		return err
+	}
+
+	if stat, err := os.Stat(path); err == nil {
+		colors.PrintSuccess(fmt.Sprintf("Imagem exportada (%s)", units.HumanSize(float64(stat.Size()))))
+	}
+	if manifest.Lock == nil {
+		colors.PrintInfo(fmt.Sprintf("O projeto não tem %s; o arquivo não fixa o digest da imagem", lock.File))
+	}
+
+	return nil
+}
+
+// saveRefs retorna as referências a exportar: as tags, para preservá-las no
+// destino, ou o ID quando a imagem não tiver tag
+func saveRefs(info *docker.ImageInfo) []string {
+	if len(info.Tags) > 0 {
+		return info.Tags
+	}
+	return []string{info.ID}
+}
+
+func importImage(path string) error {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	colors.Printf(">> Importando imagem de %s...\n", path)
+
+	manifest, err := image.Import(ctx, client, path, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	archive, err := filepath.Abs(path)
+	if err != nil {
+		archive = path
+	}
+	origin := &image.Origin{
+		Archive:    archive,
+		ImportedAt: time.Now().UTC().Truncate(time.Second),
+		Manifest:   *manifest,
+	}
+	if err := origin.Save(image.OriginFile); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível registrar a origem da imagem: %v", err))
+	}
+
+	colors.PrintSuccess(fmt.Sprintf("Imagem %s importada", manifest.Image))
+	if manifest.TexLive != "" {
+		colors.Printf("   TeX Live: %s\n", manifest.TexLive)
+	}
+
+	if manifest.Lock != nil {
+		current := loadProjectLock()
+		switch {
+		case current == nil:
+			if err := manifest.Lock.Save(lock.File); err != nil {
+				return fmt.Errorf("erro ao gravar %s: %w", lock.File, err)
+			}
+			colors.PrintInfo(fmt.Sprintf("%s restaurado a partir do arquivo", lock.File))
+		case current.Digest != manifest.Lock.Digest:
+			colors.PrintWarn(fmt.Sprintf("O arquivo foi exportado com outro lock (%s); %s do projeto mantido", manifest.Lock.Digest, lock.File))
+		}
+	}
+
+	ref := currentImageRef()
+	if ref == "" {
+		ref = defaultEnvImage
+	}
+	if exists, err := client.ImageExists(ctx, ref); err == nil && !exists {
+		colors.PrintWarn(fmt.Sprintf("O projeto usa %s, que não está no arquivo. Para usar a imagem importada: ltx image use --no-pull %s", ref, manifest.Image))
+	}
+
+	return nil
+}
+
+// currentImageRef retorna a imagem que as compilações usam, sem construir nada:
+// a derivada, se houver extras, o digest do ltx.lock ou a latex_image
+// configurada. Vazio indica a imagem padrão (defaultEnvImage).
+func currentImageRef() string {
+	spec := effectiveSpec()
+	if !spec.Empty() {
+		return spec.Tag()
+	}
+	if l := loadProjectLock(); l != nil {
+		return lockedRef(l)
+	}
+	if config.LatexImageConfigured() {
+		return config.GetLatexImage()
+	}
+	return ""
+}
+
+// resolveBuildImage retorna a imagem a ser usada nas compilações, garantindo que
+// ela exista localmente: a imagem derivada (construída se a especificação mudou)
+// ou a latex_image configurada (baixada se necessário). Sem configuração, usa a
+// imagem padrão, construída a partir do Dockerfile do devcontainer.
+func resolveBuildImage() (string, error) {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return "", err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+
+	ref := currentImageRef()
+	if ref == "" {
+		return defaultEnvImage, ensureDefaultImage(ctx, client)
+	}
+
+	spec := effectiveSpec()
+
+	if spec.Empty() {
+		// Verificação silenciosa: o pull (e sua saída) só quando a imagem falta
+		exists, err := client.ImageExists(ctx, ref)
+		if err != nil || exists {
+			return ref, err
+		}
+		return ref, client.PullImage(ctx, ref)
+	}
+
+	tag, built, err := ensureDerivedImage(ctx, client, spec, false)
+	if err != nil {
+		return "", err
+	}
+	if built {
+		colors.PrintSuccess(fmt.Sprintf("Imagem derivada %s construída (especificação alterada)", tag))
+	}
+
+	return tag, nil
+}
+
+// ensureDerivedImage garante a imagem derivada da especificação. A imagem padrão
+// não existe em registro: é construída antes de servir de base.
+func ensureDerivedImage(ctx context.Context, client *docker.Client, spec *image.Spec, force bool) (string, bool, error) {
+	if spec.Base == defaultEnvImage {
+		if err := ensureDefaultImage(ctx, client); err != nil {
+			return "", false, err
+		}
+	}
+	return image.Ensure(ctx, client, spec, force, os.Stdout)
+}
+
+// shortID abrevia IDs de imagem no formato sha256:<hex>
+func shortID(id string) string {
+	id = strings.TrimPrefix(id, "sha256:")
+	if len(id) > 12 {
+		return id[:12]
+	}
+	return id
+}
@@ -13,10 +13,11 @@ import (
 )
 
 var (
-	initTitle    string
-	initAuthor   string
-	initTemplate string
-	initForce    bool
+	initTitle       string
+	initAuthor      string
+	initTemplate    string
+	initForce       bool
+	initInstallDeps bool
 )
 
 var InitCmd = &cobra.Command{
@@ -40,6 +41,7 @@ func init() {
 	InitCmd.Flags().StringVarP(&initAuthor, "author", "a", "", "Nome do autor")
 	InitCmd.Flags().StringVar(&initTemplate, "template", "default", "Template a usar")
 	InitCmd.Flags().BoolVarP(&initForce, "force", "f", false, "Sobrescreve arquivos existentes")
+	InitCmd.Flags().BoolVar(&initInstallDeps, "install-deps", false, "Instala com tlmgr as dependências ausentes do template")
 }
 
 func initProject() error {
@@ -87,6 +89,12 @@ func initProject() error {
 		return fmt.Errorf("erro ao criar projeto: %w", err)
 	}
 
+	// Verificar dependências do template (não bloqueia a inicialização)
+	if _, err := checkTemplateDependencies(tmpl, initInstallDeps, initInstallDeps); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível verificar dependências: %v", err))
+		colors.PrintInfo("Execute 'ltx template validate --check-deps' com o ambiente Docker ativo")
+	}
+
 	colors.PrintSuccess("Documento LaTeX inicializado com sucesso!")
 	colors.Printf("[INFO] Template usado: %s\n", tmpl.Metadata.Name)
 	colors.Printf("[INFO] Arquivos criados em: %s\n", sourceDir)
@@ -0,0 +1,177 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+// texOptions são as opções da engine em toda compilação
+var texOptions = []string{"-interaction=nonstopmode", "-file-line-error", "-synctex=1"}
+
+var LatexmkrcCmd = &cobra.Command{
+	Use:   "latexmkrc",
+	Short: "Configuração do latexmk gerada pelo ltx",
+	Long: `A cada compilação o ltx gera .ltx/<documento>.latexmkrc a partir da
+configuração (engine, diretório de saída, shell-escape, backend da
+bibliografia, LATEXMK_MAX_REPEAT e LATEXMK_EXTRA) e dos fontes (glossários,
+nomenclaturas e índices), e o passa ao latexmk com -r.
+
+Dentro de 'ltx shell', 'latexmk -r .ltx/main.latexmkrc' repete a compilação
+do build.`,
+}
+
+var latexmkrcShowCmd = &cobra.Command{
+	Use:   "show [arquivo.tex]",
+	Short: "Exibe o latexmkrc gerado para o documento",
+	Long: `Exibe o latexmkrc que o build gera para o documento (por padrão
+src/main.tex), sem gravá-lo. As flags --engine e --shell-escape simulam as
+do build.`,
+	Example: `  ltx latexmkrc show
+  ltx latexmkrc show --engine lualatex`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target := filepath.Join(config.GetSourceDir(), "main.tex")
+		if len(args) > 0 {
+			target = args[0]
+		}
+
+		bibliography, err := planBibliography(target)
+		if err != nil {
+			return err
+		}
+		rc, err := buildLatexmkrc(target, bibliography)
+		if err != nil {
+			return err
+		}
+		fmt.Print(rc.Render())
+		return nil
+	},
+}
+
+func init() {
+	LatexmkrcCmd.AddCommand(latexmkrcShowCmd)
+
+	latexmkrcShowCmd.Flags().StringVar(&buildEngine, "engine", "", "Engine LaTeX (pdflatex, xelatex, lualatex; padrão: LATEX_ENGINE)")
+	latexmkrcShowCmd.Flags().StringVar(&buildShellEscape, "shell-escape", "", "Política de shell-escape (off, restricted, on)")
+}
+
+// targetName é o nome do documento principal, sem diretório nem extensão (main)
+func targetName(mainTexPath string) string {
+	return strings.TrimSuffix(filepath.Base(mainTexPath), filepath.Ext(mainTexPath))
+}
+
+// latexmkrcPath é o latexmkrc gerado para um documento (.ltx/main.latexmkrc)
+func latexmkrcPath(mainTexPath string) string {
+	return filepath.Join(".ltx", targetName(mainTexPath)+".latexmkrc")
+}
+
+// auxDir é o diretório dos arquivos auxiliares de um documento (AUX_DIR)
+func auxDir(mainTexPath string) string {
+	return filepath.Clean(config.GetAuxDir(targetName(mainTexPath)))
+}
+
+// mainAuxDir é o diretório dos auxiliares do documento principal (src/main.tex)
+func mainAuxDir() string {
+	return auxDir(projectMainFile())
+}
+
+// projectMainFile retorna o documento principal do projeto, em SOURCE_DIR
+func projectMainFile() string {
+	return filepath.Join(config.GetSourceDir(), "main.tex")
+}
+
+// buildLatexmkrc monta o latexmkrc do documento a partir da configuração
+// (e das flags do build) e dos fontes
+func buildLatexmkrc(mainTexPath string, bibliography bibliographyPlan) (texlive.Latexmkrc, error) {
+	engine := effectiveEngine()
+	if _, err := latexmkEngineFlag(engine); err != nil {
+		return texlive.Latexmkrc{}, err
+	}
+	shellEscape, err := resolveShellEscape(mainTexPath)
+	if err != nil {
+		return texlive.Latexmkrc{}, err
+	}
+	maxRepeat, err := config.GetLatexmkMaxRepeat()
+	if err != nil {
+		return texlive.Latexmkrc{}, err
+	}
+
+	escapeArgs, escapeEnv := shellEscapeOptions(shellEscape, config.GetShellEscapeCommands())
+	env := make(map[string]string)
+	for _, variable := range escapeEnv {
+		name, value, _ := strings.Cut(variable, "=")
+		env[name] = value
+	}
+
+	// Arquivos de src/ e subdiretórios são encontrados sem caminho
+	sourcePath := "./" + filepath.ToSlash(filepath.Dir(mainTexPath)) + "//"
+
+	rc := texlive.Latexmkrc{
+		Target:      filepath.ToSlash(mainTexPath),
+		Engine:      strings.ToLower(engine),
+		OutDir:      filepath.ToSlash(config.GetOutputDir()),
+		AuxDir:      filepath.ToSlash(auxDir(mainTexPath)),
+		TexOptions:  append(append([]string(nil), texOptions...), escapeArgs...),
+		Env:         env,
+		SearchPaths: map[string]string{"TEXINPUTS": sourcePath, "BIBINPUTS": sourcePath},
+		BibtexUse:   bibliography.bibtexUse(),
+		BibBackend:  bibliography.Backend,
+		MaxRepeat:   maxRepeat,
+		Extra:       config.GetLatexmkExtra(),
+	}
+	if project, err := latex.Load(mainTexPath); err == nil {
+		rc.Indexing = project.Indexing()
+	}
+	return rc, nil
+}
+
+// writeLatexmkrc grava o latexmkrc do documento e retorna o caminho
+func writeLatexmkrc(mainTexPath string, rc texlive.Latexmkrc) (string, error) {
+	path := latexmkrcPath(mainTexPath)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(path, []byte(rc.Render()), 0644); err != nil {
+		return "", fmt.Errorf("erro ao gravar %s: %w", path, err)
+	}
+	return path, nil
+}
+
+// tempFileDirs são os diretórios com arquivos intermediários: AUX_DIR e
+// OUTPUT_DIR, que guarda os auxiliares de compilações anteriores à separação
+func tempFileDirs() []string {
+	dirs := []string{mainAuxDir()}
+	if output := filepath.Clean(config.GetOutputDir()); output != dirs[0] {
+		dirs = append(dirs, output)
+	}
+	return dirs
+}
+
+// tempFilePatterns são os arquivos intermediários da compilação em dir,
+// incluindo os glossários declarados no documento
+func tempFilePatterns(dir string) []string {
+	extensions := []string{
+		"aux", "log", "bbl", "blg", "bcf", "run.xml", "fls", "fdb_latexmk",
+		"synctex.gz", "out", "toc", "lot", "lof", "nav", "snm", "vrb",
+	}
+
+	var indexing latex.Indexing
+	if project, err := latex.Load(filepath.Join(config.GetSourceDir(), "main.tex")); err == nil {
+		indexing = project.Indexing()
+	}
+	extensions = append(extensions, indexing.Extensions()...)
+
+	patterns := make([]string, len(extensions))
+	for i, ext := range extensions {
+		patterns[i] = filepath.Join(dir, "*."+ext)
+	}
+	return patterns
+}
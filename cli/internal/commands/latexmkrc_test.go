@@ -0,0 +1,54 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestBuildLatexmkrc(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+	viper.Set("latex_engine", "lualatex")
+	viper.Set("shell_escape", "restricted")
+	viper.Set("latexmk_max_repeat", "6")
+	viper.Set("latexmk_extra", "$preview_continuous_mode = 1;")
+	viper.Set("output_dir", "pdf")
+	buildEngine, buildShellEscape = "", ""
+
+	dir := t.TempDir()
+	mainTex := filepath.Join(dir, "src", "tese.tex")
+	if err := os.MkdirAll(filepath.Dir(mainTex), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(mainTex, []byte("\\documentclass{article}\n\\begin{document}\n\\end{document}\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	rc, err := buildLatexmkrc(mainTex, bibliographyPlan{Backend: "biber"})
+	if err != nil {
+		t.Fatalf("buildLatexmkrc() error = %v", err)
+	}
+
+	out := rc.Render()
+	for _, want := range []string{
+		"$out_dir = 'pdf';",
+		"$pdf_mode = 4;",
+		"-shell-restricted %O %S",
+		"$max_repeat = 6;",
+		"$bibtex_use = 2;",
+		"$biber = 'biber %O %S';",
+		"$preview_continuous_mode = 1;",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("latexmkrc não contém %q:\n%s", want, out)
+		}
+	}
+
+	if path := latexmkrcPath(mainTex); path != filepath.Join(".ltx", "tese.latexmkrc") {
+		t.Errorf("latexmkrcPath() = %q, expected .ltx/tese.latexmkrc", path)
+	}
+}
@@ -0,0 +1,249 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lint"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+var (
+	lintFormat    string
+	lintDisable   []string
+	lintNoChktex  bool
+	lintListRules bool
+)
+
+var LintCmd = &cobra.Command{
+	Use:   "lint [arquivo]",
+	Short: "Verifica problemas comuns nos fontes LaTeX",
+	Long: `Verifica o documento (por padrão src/main.tex) seguindo \input e \include,
+com regras nativas do ltx e com o chktex no ambiente do projeto.
+
+Regras nativas (veja --list-rules): \ref para rótulos inexistentes, rótulos
+duplicados ou nunca usados, \cite de chaves ausentes do .bib, chaves e
+ambientes sem par, $$ ... $$ e espaço sem ~ antes de \ref e \cite.
+
+Regras podem ser desativadas com LINT_DISABLE na configuração, com --disable
+ou nos próprios fontes:
+
+  % ltx-lint-disable-line missing-tie
+  % ltx-lint-disable-next-line undefined-ref
+  % ltx-lint-disable unused-label   ...   % ltx-lint-enable unused-label
+
+Sem lista de regras, a diretiva vale para todas. Avisos do chktex são
+identificados como chktex/N (ex.: chktex/1).
+
+Formatos (--format): text (padrão), json e sarif (GitHub code scanning).
+O comando falha quando há resultados de nível error.`,
+	Example: `  ltx lint
+  ltx lint --disable unused-label,chktex/1
+  ltx lint --format sarif > ltx-lint.sarif`,
+	Args:         cobra.MaximumNArgs(1),
+	SilenceUsage: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if lintListRules {
+			printLintRules(os.Stdout)
+			return nil
+		}
+
+		target := filepath.Join(config.GetSourceDir(), "main.tex")
+		if len(args) > 0 {
+			target = args[0]
+		}
+		return runLint(target, lintFormat)
+	},
+}
+
+func init() {
+	LintCmd.Flags().StringVar(&lintFormat, "format", "text", "Formato da saída: text, json ou sarif")
+	LintCmd.Flags().StringSliceVar(&lintDisable, "disable", nil, "Regras a desativar, além das de LINT_DISABLE")
+	LintCmd.Flags().BoolVar(&lintNoChktex, "no-chktex", false, "Executar apenas as regras nativas")
+	LintCmd.Flags().BoolVar(&lintListRules, "list-rules", false, "Listar as regras nativas e sair")
+}
+
+// lintReport é o resultado de uma verificação (saída de --format json)
+type lintReport struct {
+	Target   string         `json:"target"`
+	Chktex   bool           `json:"chktex"`
+	Findings []lint.Finding `json:"findings"`
+	Warnings []string       `json:"warnings,omitempty"`
+}
+
+func runLint(target, format string) error {
+	switch format {
+	case "text", "json", "sarif":
+	default:
+		return fmt.Errorf("formato inválido: %q (use text, json ou sarif)", format)
+	}
+
+	disabled, err := lintDisabledRules(append(config.GetLintDisabled(), lintDisable...))
+	if err != nil {
+		return err
+	}
+
+	project, err := latex.Load(target)
+	if err != nil {
+		return fmt.Errorf("arquivo %s não encontrado", target)
+	}
+
+	report := lintReport{Target: target, Findings: lint.Run(project, disabled)}
+	for _, missing := range project.Missing {
+		report.Warnings = append(report.Warnings, fmt.Sprintf("Arquivo incluído não encontrado: %s", missing))
+	}
+
+	if config.GetLintChktex() && !lintNoChktex {
+		warnings, err := runChktex(target)
+		if err != nil {
+			report.Warnings = append(report.Warnings, fmt.Sprintf("chktex indisponível (%v); usando apenas as regras nativas", err))
+		} else {
+			report.Chktex = true
+			findings := append(report.Findings, chktexFindings(warnings)...)
+			report.Findings = lint.Filter(project, findings, disabled)
+		}
+	}
+	if report.Findings == nil {
+		report.Findings = []lint.Finding{}
+	}
+
+	switch format {
+	case "json":
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case "sarif":
+		// Avisos vão para stderr para não corromper o SARIF
+		for _, warning := range report.Warnings {
+			fmt.Fprintln(os.Stderr, colors.Colorize("[WARN] "+warning))
+		}
+		if err := lint.WriteSARIF(os.Stdout, report.Findings, Version); err != nil {
+			return err
+		}
+	default:
+		printLintReport(os.Stdout, report)
+	}
+
+	if errorCount := countLevel(report.Findings, lint.LevelError); errorCount > 0 {
+		return fmt.Errorf("lint encontrou %d erro(s)", errorCount)
+	}
+	return nil
+}
+
+// lintDisabledRules valida as regras desativadas: nativas ou chktex/N
+func lintDisabledRules(rules []string) (map[string]bool, error) {
+	disabled := make(map[string]bool, len(rules))
+	for _, rule := range rules {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+
+		if number, ok := strings.CutPrefix(rule, "chktex/"); ok {
+			if _, err := strconv.Atoi(number); err != nil {
+				return nil, fmt.Errorf("regra inválida: %q (use chktex/N, com o número do aviso)", rule)
+			}
+		} else if _, ok := lint.FindRule(rule); !ok {
+			return nil, fmt.Errorf("regra desconhecida: %q (veja 'ltx lint --list-rules')", rule)
+		}
+		disabled[rule] = true
+	}
+	return disabled, nil
+}
+
+// runChktex executa o chktex no ambiente do projeto (ou no TeX Live local)
+func runChktex(target string) ([]texlive.ChktexWarning, error) {
+	runner, closeRunner, err := newTexRunner(false)
+	if err != nil {
+		return nil, err
+	}
+	defer closeRunner()
+
+	return texlive.Chktex(context.Background(), runner, filepath.ToSlash(target))
+}
+
+// chktexFindings converte os avisos do chktex em resultados do lint
+func chktexFindings(warnings []texlive.ChktexWarning) []lint.Finding {
+	findings := make([]lint.Finding, 0, len(warnings))
+	for _, w := range warnings {
+		level := lint.LevelWarning
+		switch w.Kind {
+		case "Error":
+			level = lint.LevelError
+		case "Message":
+			level = lint.LevelNote
+		}
+
+		findings = append(findings, lint.Finding{
+			Rule:    fmt.Sprintf("chktex/%d", w.Number),
+			Level:   level,
+			Message: w.Message,
+			File:    w.File,
+			Line:    w.Line,
+			Column:  w.Column,
+		})
+	}
+	return findings
+}
+
+func countLevel(findings []lint.Finding, level string) int {
+	count := 0
+	for _, f := range findings {
+		if f.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+func printLintReport(w io.Writer, report lintReport) {
+	for _, warning := range report.Warnings {
+		fmt.Fprintln(w, colors.Colorize("[WARN] "+warning))
+	}
+
+	for _, f := range report.Findings {
+		position := fmt.Sprintf("%s:%d", f.File, f.Line)
+		if f.Column > 0 {
+			position += fmt.Sprintf(":%d", f.Column)
+		}
+		fmt.Fprintf(w, "%s %s: %s [%s]\n", lintSymbol(f.Level), position, f.Message, f.Rule)
+	}
+
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(w, "✓ Nenhum problema encontrado")
+		return
+	}
+	fmt.Fprintf(w, "\n%d erro(s), %d aviso(s), %d nota(s)\n",
+		countLevel(report.Findings, lint.LevelError),
+		countLevel(report.Findings, lint.LevelWarning),
+		countLevel(report.Findings, lint.LevelNote))
+}
+
+func lintSymbol(level string) string {
+	switch level {
+	case lint.LevelError:
+		return "✗"
+	case lint.LevelWarning:
+		return "⚠"
+	default:
+		return "ℹ"
+	}
+}
+
+func printLintRules(w io.Writer) {
+	for _, rule := range lint.Rules {
+		fmt.Fprintf(w, "%-20s %-8s %s\n", rule.ID, rule.Level, rule.Description)
+	}
+}
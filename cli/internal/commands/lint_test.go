@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lint"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+func TestLintDisabledRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []string
+		expected map[string]bool
+		wantErr  bool
+	}{
+		{name: "vazio", rules: nil, expected: map[string]bool{}},
+		{name: "nativas e chktex", rules: []string{"unused-label", " chktex/1", ""}, expected: map[string]bool{"unused-label": true, "chktex/1": true}},
+		{name: "regra desconhecida", rules: []string{"nao-existe"}, wantErr: true},
+		{name: "chktex sem número", rules: []string{"chktex/x"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := lintDisabledRules(tt.rules)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("lintDisabledRules() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("lintDisabledRules() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestChktexFindings(t *testing.T) {
+	findings := chktexFindings([]texlive.ChktexWarning{
+		{File: "src/main.tex", Line: 3, Column: 7, Kind: "Warning", Number: 1, Message: "Command terminated with space."},
+		{File: "src/main.tex", Line: 5, Column: 1, Kind: "Error", Number: 17, Message: "Number of `(' doesn't match"},
+		{File: "src/main.tex", Line: 9, Column: 2, Kind: "Message", Number: 30, Message: "Multiple spaces"},
+	})
+
+	expected := []string{lint.LevelWarning, lint.LevelError, lint.LevelNote}
+	for i, f := range findings {
+		if f.Level != expected[i] {
+			t.Errorf("findings[%d].Level = %q, expected %q", i, f.Level, expected[i])
+		}
+	}
+	if findings[0].Rule != "chktex/1" || findings[0].Column != 7 {
+		t.Errorf("findings[0] = %+v, expected chktex/1 na coluna 7", findings[0])
+	}
+}
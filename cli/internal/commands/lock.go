@@ -0,0 +1,236 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/image"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/texlive"
+)
+
+var LockCmd = &cobra.Command{
+	Use:   "lock",
+	Short: "Fixa a imagem LaTeX por digest",
+	Long: `Gerencia o arquivo ltx.lock, que fixa a imagem LaTeX por digest para
+compilações reproduzíveis. Com o lock presente, 'ltx build' usa sempre o
+digest registrado, mesmo que a tag configurada (ex: latest) mude no registro.
+
+Versione o ltx.lock junto com o projeto.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return showLock()
+	},
+}
+
+var lockUpdateCmd = &cobra.Command{
+	Use:   "update",
+	Short: "Atualiza o ltx.lock",
+	Long: `Baixa a versão atual de latex_image, resolve seu digest e grava no
+ltx.lock o digest, o ano do TeX Live e os pacotes extras instalados.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return updateLock()
+	},
+}
+
+func init() {
+	LockCmd.AddCommand(lockUpdateCmd)
+}
+
+// loadProjectLock lê o ltx.lock do projeto, avisando (sem falhar) se for inválido
+func loadProjectLock() *lock.Lock {
+	l, err := lock.Load(lock.File)
+	if err != nil {
+		colors.PrintWarn(fmt.Sprintf("Ignorando %s: %v", lock.File, err))
+		return nil
+	}
+	return l
+}
+
+// effectiveSpec retorna a especificação da imagem com a base fixada pelo lock, se houver
+func effectiveSpec() *image.Spec {
+	spec := image.SpecFromConfig()
+	if l := loadProjectLock(); l != nil {
+		spec.Base = l.Digest
+	}
+	return spec
+}
+
+// lockedRef retorna a referência local da imagem travada. Uma imagem importada
+// com 'ltx image import' pode não preservar o digest de registro; nesse caso
+// usa-se o ID registrado na importação.
+func lockedRef(l *lock.Lock) string {
+	origin, err := image.LoadOrigin(image.OriginFile)
+	if err != nil || origin == nil || origin.Manifest.Digest != l.Digest || origin.Manifest.BaseID == "" {
+		return l.Digest
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return l.Digest
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	if exists, err := client.ImageExists(ctx, l.Digest); err == nil && exists {
+		return l.Digest
+	}
+	if exists, err := client.ImageExists(ctx, origin.Manifest.BaseID); err == nil && exists {
+		return origin.Manifest.BaseID
+	}
+	return l.Digest
+}
+
+// lockPackages reúne os pacotes extras atuais no formato do lock
+func lockPackages() lock.Packages {
+	recorded, err := texlive.LoadRecord(texlive.RecordFile)
+	if err != nil {
+		colors.PrintWarn(fmt.Sprintf("Erro ao ler %s: %v", texlive.RecordFile, err))
+	}
+
+	return lock.Packages{
+		Tex:      sortedCopy(config.GetTexPackages()),
+		Apt:      sortedCopy(config.GetAptPackages()),
+		Pip:      sortedCopy(config.GetPipPackages()),
+		Recorded: sortedCopy(recorded),
+	}
+}
+
+func sortedCopy(items []string) []string {
+	if len(items) == 0 {
+		return nil
+	}
+	sorted := append([]string(nil), items...)
+	sort.Strings(sorted)
+	return sorted
+}
+
+// lockBaseRef retorna a imagem que o lock fixa: a base das compilações sem o
+// lock (latex_image ou a imagem padrão). A imagem padrão é construída
+// localmente e não tem digest de registro para ser fixado.
+func lockBaseRef() (string, error) {
+	ref := image.SpecFromConfig().Base
+	if ref == defaultEnvImage {
+		return "", fmt.Errorf("a imagem padrão %s é construída localmente e não tem digest de registro; configure LATEX_IMAGE com uma imagem de registro para usar o %s", ref, lock.File)
+	}
+	return ref, nil
+}
+
+// lockDrift descreve as diferenças entre o lock e a configuração atual
+func lockDrift(l *lock.Lock) []string {
+	var drift []string
+
+	if base := image.SpecFromConfig().Base; l.Image != base {
+		drift = append(drift, fmt.Sprintf("lock gerado para %s, mas a imagem configurada é %s", l.Image, base))
+	}
+	if !reflect.DeepEqual(l.Packages, lockPackages()) {
+		drift = append(drift, "pacotes extras mudaram desde o último 'ltx lock update'")
+	}
+
+	return drift
+}
+
+func updateLock() error {
+	ref, err := lockBaseRef()
+	if err != nil {
+		return err
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	ctx := context.Background()
+	colors.Printf(">> Resolvendo %s...\n", ref)
+
+	if err := client.Pull(ctx, ref, os.Stdout); err != nil {
+		if exists, _ := client.ImageExists(ctx, ref); exists {
+			return fmt.Errorf("imagem %s existe só localmente, sem digest de registro; o %s só fixa imagens de registro", ref, lock.File)
+		}
+		return fmt.Errorf("erro ao baixar %s: %w", ref, err)
+	}
+
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return err
+	}
+
+	digest, err := lock.DigestFor(ref, info.Digests)
+	if err != nil {
+		return fmt.Errorf("%w; o %s só fixa imagens de registro", err, lock.File)
+	}
+
+	year, err := image.TexLiveYear(ctx, client, ref)
+	if err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível detectar a versão do TeX Live: %v", err))
+	}
+
+	previous := loadProjectLock()
+
+	l := &lock.Lock{
+		Image:     ref,
+		Digest:    digest,
+		TexLive:   year,
+		Packages:  lockPackages(),
+		UpdatedAt: time.Now().UTC().Truncate(time.Second),
+	}
+
+	if err := l.Save(lock.File); err != nil {
+		return fmt.Errorf("erro ao gravar %s: %w", lock.File, err)
+	}
+
+	if previous != nil && previous.Digest != l.Digest {
+		colors.Printf("[CHANGE] %s\n         → %s\n", previous.Digest, l.Digest)
+	}
+
+	colors.PrintSuccess(fmt.Sprintf("%s atualizado", lock.File))
+	colors.Printf("   Digest: %s\n", l.Digest)
+	if l.TexLive != "" {
+		colors.Printf("   TeX Live: %s\n", l.TexLive)
+	}
+
+	return nil
+}
+
+func showLock() error {
+	l, err := lock.Load(lock.File)
+	if err != nil {
+		return err
+	}
+	if l == nil {
+		colors.PrintInfo(fmt.Sprintf("Nenhum %s encontrado. Execute 'ltx lock update' para fixar a imagem", lock.File))
+		return nil
+	}
+
+	fmt.Printf("Imagem: %s\n", l.Image)
+	fmt.Printf("Digest: %s\n", l.Digest)
+	if l.TexLive != "" {
+		fmt.Printf("TeX Live: %s\n", l.TexLive)
+	}
+	printLockList("Pacotes TeX (imagem)", l.Packages.Tex)
+	printLockList("Pacotes apt", l.Packages.Apt)
+	printLockList("Pacotes pip", l.Packages.Pip)
+	printLockList("Pacotes registrados", l.Packages.Recorded)
+	fmt.Printf("Atualizado em: %s\n", l.UpdatedAt.Local().Format("2006-01-02 15:04:05"))
+
+	for _, d := range lockDrift(l) {
+		colors.PrintWarn(d)
+	}
+
+	return nil
+}
+
+func printLockList(label string, items []string) {
+	if len(items) > 0 {
+		fmt.Printf("%s: %s\n", label, strings.Join(items, " "))
+	}
+}
@@ -0,0 +1,141 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+)
+
+// generatedDirs são os diretórios escritos pelas compilações no container:
+// OUTPUT_DIR, tmp/ e o AUX_DIR, quando fica em outro lugar (.ltx/build/main)
+func generatedDirs() []string {
+	dirs := []string{filepath.Clean(config.GetOutputDir())}
+	for _, dir := range []string{"tmp", mainAuxDir()} {
+		if !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// hostUser retorna o usuário do host no formato UID:GID usado pelo Docker, ou
+// "" quando não há UID (Windows), caso em que vale o usuário da imagem
+func hostUser() string {
+	uid, gid := os.Getuid(), os.Getgid()
+	if uid < 0 || gid < 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d:%d", uid, gid)
+}
+
+// foreignOwned lista os arquivos dos diretórios informados que pertencem a
+// outro usuário (por exemplo, gerados pelo latexuser da imagem, UID 1001)
+func foreignOwned(dirs ...string) ([]string, error) {
+	uid := os.Getuid()
+	if uid < 0 {
+		return nil, nil
+	}
+
+	var foreign []string
+	for _, dir := range dirs {
+		err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if owner, ok := fileOwner(info); ok && owner != uid {
+				foreign = append(foreign, path)
+			}
+			return nil
+		})
+		if err != nil {
+			return foreign, err
+		}
+	}
+
+	return foreign, nil
+}
+
+// checkOwnership avisa sobre arquivos de outro usuário em dist/ e tmp/ e
+// oferece corrigir a propriedade
+func checkOwnership() {
+	foreign, err := foreignOwned(generatedDirs()...)
+	if err != nil || len(foreign) == 0 {
+		return
+	}
+
+	colors.PrintWarn(fmt.Sprintf("%d arquivo(s) em dist/ ou tmp/ pertencem a outro usuário (ex.: %s)", len(foreign), foreign[0]))
+	if !askUserConfirmation("Corrigir a propriedade para o seu usuário?") {
+		colors.PrintInfo("Sem a correção, 'ltx clean' e 'ltx reset' podem falhar com permissão negada")
+		return
+	}
+
+	if err := fixOwnership(generatedDirs()...); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível corrigir a propriedade: %v", err))
+		return
+	}
+
+	colors.PrintSuccess("Propriedade dos arquivos corrigida")
+}
+
+// fixOwnership transfere os diretórios para o usuário do host usando um
+// container descartável como root, já que o usuário não pode fazer chown de
+// arquivos de outro UID
+func fixOwnership(dirs ...string) error {
+	user := hostUser()
+	if user == "" {
+		return nil
+	}
+
+	var targets []string
+	for _, dir := range dirs {
+		if _, err := os.Stat(dir); err == nil {
+			targets = append(targets, filepath.ToSlash(filepath.Join(docker.WorkspaceDir, dir)))
+		}
+	}
+	if len(targets) == 0 {
+		return nil
+	}
+
+	image, err := resolveBuildImage()
+	if err != nil {
+		return err
+	}
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return err
+	}
+	defer closeClient()
+
+	exitCode, err := client.Run(context.Background(), docker.RunOptions{
+		Image:      image,
+		Cmd:        append([]string{"chown", "-R", user}, targets...),
+		User:       "0:0",
+		WorkingDir: docker.WorkspaceDir,
+		Binds:      []string{config.ProjectRoot() + ":" + docker.WorkspaceDir},
+		Stderr:     os.Stderr,
+	})
+	if err != nil {
+		return err
+	}
+	if exitCode != 0 {
+		return fmt.Errorf("chown terminou com código %d", exitCode)
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"testing"
+)
+
+func TestHostUser(t *testing.T) {
+	user := hostUser()
+
+	if runtime.GOOS == "windows" {
+		if user != "" {
+			t.Errorf("hostUser() = %q, expected vazio no Windows", user)
+		}
+		return
+	}
+
+	if !regexp.MustCompile(`^\d+:\d+$`).MatchString(user) {
+		t.Errorf("hostUser() = %q, expected formato UID:GID", user)
+	}
+}
+
+func TestForeignOwned(t *testing.T) {
+	tempDir := t.TempDir()
+
+	dist := filepath.Join(tempDir, "dist")
+	if err := os.MkdirAll(dist, 0755); err != nil {
+		t.Fatalf("Erro ao criar diretório: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dist, "main.pdf"), []byte("%PDF"), 0644); err != nil {
+		t.Fatalf("Erro ao criar arquivo: %v", err)
+	}
+
+	// Arquivos do próprio usuário e diretórios inexistentes não são reportados
+	foreign, err := foreignOwned(dist, filepath.Join(tempDir, "tmp"))
+	if err != nil {
+		t.Fatalf("foreignOwned() error = %v", err)
+	}
+	if len(foreign) != 0 {
+		t.Errorf("foreignOwned() = %v, expected nenhum arquivo", foreign)
+	}
+}
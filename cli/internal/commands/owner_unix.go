@@ -0,0 +1,17 @@
+//go:build !windows
+
+package commands
+
+import (
+	"os"
+	"syscall"
+)
+
+// fileOwner retorna o UID dono do arquivo
+func fileOwner(info os.FileInfo) (int, bool) {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok {
+		return 0, false
+	}
+	return int(stat.Uid), true
+}
@@ -0,0 +1,11 @@
+//go:build windows
+
+package commands
+
+import "os"
+
+// fileOwner não se aplica no Windows: o Docker Desktop não preserva UIDs nos
+// volumes montados
+func fileOwner(info os.FileInfo) (int, bool) {
+	return 0, false
+}
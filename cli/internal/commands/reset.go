@@ -1,12 +1,13 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
-	"os/exec"
 
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
 )
 
 var (
@@ -62,6 +63,9 @@ func resetEnvironment() error {
 
 	colors.Println(">> Iniciando reset do ambiente...")
 
+	// Arquivos gerados por outro usuário não podem ser removidos sem corrigir o dono
+	checkOwnership()
+
 	// 1. Parar e remover containers Docker
 	if err := stopDockerContainers(); err != nil {
 		colors.PrintError(fmt.Sprintf("Erro ao parar containers: %v", err))
@@ -87,15 +91,28 @@ func resetEnvironment() error {
 }
 
 func stopDockerContainers() error {
-	colors.Println("   🐳 Parando containers Docker...")
+	colors.Println("   🐳 Removendo container do projeto...")
+
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return fmt.Errorf("falha ao conectar ao Docker: %w", err)
+	}
+	defer closeClient()
 
-	// Parar containers via docker-compose
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "down", "--remove-orphans")
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("falha ao parar containers: %w", err)
+	ctx := context.Background()
+	name := config.GetContainerName()
+
+	exists, err := client.ContainerExists(ctx, name)
+	if err != nil {
+		return fmt.Errorf("falha ao verificar container: %w", err)
+	}
+	if exists {
+		if err := client.RemoveContainer(ctx, name); err != nil {
+			return fmt.Errorf("falha ao remover container %s: %w", name, err)
+		}
 	}
 
-	colors.Printf("[STOPPED] Containers Docker\n")
+	colors.Printf("[STOPPED] Container %s\n", name)
 	return nil
 }
 
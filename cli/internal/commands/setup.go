@@ -1,6 +1,7 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
@@ -8,6 +9,8 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/image"
 )
 
 var SetupCmd = &cobra.Command{
@@ -18,6 +21,12 @@ para desenvolvimento LaTeX.`,
 	RunE: runSetup,
 }
 
+var setupImageArchive string
+
+func init() {
+	SetupCmd.Flags().StringVar(&setupImageArchive, "image-archive", "", "Importa a imagem de um arquivo de 'ltx image export' em vez de baixá-la")
+}
+
 func runSetup(cmd *cobra.Command, args []string) error {
 	colors.Println(">> Configurando ambiente LaTeX Docker...")
 
@@ -36,8 +45,20 @@ func runSetup(cmd *cobra.Command, args []string) error {
 	}
 	fmt.Println("[OK] Docker verificado")
 
-	// 4. Pular verificação de imagem por enquanto
-	fmt.Println("[OK] Imagem LaTeX será verificada durante o build")
+	// 4. Verificar imagem LaTeX (de um arquivo, em máquinas sem rede)
+	if setupImageArchive != "" {
+		if err := importImage(setupImageArchive); err != nil {
+			return err
+		}
+	} else if ref := currentImageRef(); ref != "" && image.SpecFromConfig().Empty() {
+		if err := checkAndPullImage(ref); err != nil {
+			return err
+		}
+	} else if ref != "" {
+		fmt.Println("[OK] Imagem derivada será construída no primeiro build (ou com 'ltx image build')")
+	} else {
+		fmt.Println("[OK] Imagem padrão será construída no primeiro build")
+	}
 
 	// 5. Criar diretórios necessários
 	if err := createDirectories(); err != nil {
@@ -108,20 +129,18 @@ func setupVSCode() error {
 }
 
 func checkAndPullImage(imageName string) error {
-	// Verificar se a imagem já existe
-	cmd := exec.Command("docker", "images", "-q", imageName)
-	output, err := cmd.Output()
-
-	if err == nil && len(output) > 0 {
-		fmt.Printf("[OK] Imagem %s já existe localmente\n", imageName)
-		return nil
+	client, err := docker.NewClient()
+	if err != nil {
+		return fmt.Errorf("docker não está disponível: %w", err)
 	}
+	defer func() {
+		if err := client.Close(); err != nil {
+			colors.Printf("[WARN] Erro ao fechar cliente Docker: %v\n", err)
+		}
+	}()
 
-	// Imagem não existe, fazer pull
-	fmt.Printf(">> Baixando imagem %s...\n", imageName)
-	cmd = exec.Command("docker", "pull", imageName)
-
-	if err := cmd.Run(); err != nil {
+	// PullImage não baixa novamente imagens já presentes e exibe o progresso do download
+	if err := client.PullImage(context.Background(), imageName); err != nil {
 		return fmt.Errorf("falha ao baixar imagem %s: %w", imageName, err)
 	}
 
@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+)
+
+// defaultShellEscapeCommands é a lista de comandos liberados por padrão pelo
+// TeX Live no modo restrito (shell_escape_commands do texmf.cnf)
+var defaultShellEscapeCommands = []string{
+	"bibtex", "bibtex8", "extractbb", "gregorio", "kpsewhich",
+	"l3sys-query", "makeindex", "repstopdf", "r-mpost", "texosquery-jre8",
+	// minted 3: o latexminted foi feito para o modo restrito (só grava nos
+	// diretórios do documento). O pygmentize do minted 2 não entra na lista
+	// padrão porque aceita -o com qualquer caminho; libere-o em
+	// SHELL_ESCAPE_COMMANDS se necessário.
+	"latexminted",
+}
+
+// resolveShellEscape retorna a política de shell-escape da compilação de um
+// documento: a da flag --shell-escape, se informada, a do documento em
+// SHELL_ESCAPE_TARGETS ou a de SHELL_ESCAPE
+func resolveShellEscape(mainTexPath string) (string, error) {
+	if buildShellEscape != "" {
+		return config.ParseShellEscape(buildShellEscape)
+	}
+	return config.GetShellEscapeFor(targetName(mainTexPath))
+}
+
+// shellEscapeOptions traduz a política em opções do latexmk e variáveis de
+// ambiente. No modo restrito a lista de comandos é passada ao kpathsea pela
+// variável shell_escape_commands, que sobrepõe a do texmf.cnf.
+func shellEscapeOptions(mode string, allowed []string) (args []string, env []string) {
+	switch mode {
+	case config.ShellEscapeOff:
+		return []string{"-no-shell-escape"}, nil
+	case config.ShellEscapeOn:
+		return []string{"-shell-escape"}, nil
+	}
+
+	commands := append([]string{}, defaultShellEscapeCommands...)
+	seen := make(map[string]bool)
+	for _, cmd := range commands {
+		seen[cmd] = true
+	}
+	for _, cmd := range allowed {
+		cmd = strings.TrimSpace(cmd)
+		if cmd != "" && !seen[cmd] {
+			seen[cmd] = true
+			commands = append(commands, cmd)
+		}
+	}
+
+	return []string{"-shell-restricted"}, []string{"shell_escape_commands=" + strings.Join(commands, ",")}
+}
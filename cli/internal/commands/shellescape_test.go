@@ -0,0 +1,58 @@
+package commands
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+)
+
+func TestShellEscapeOptions(t *testing.T) {
+	defaults := strings.Join(defaultShellEscapeCommands, ",")
+
+	tests := []struct {
+		name         string
+		mode         string
+		allowed      []string
+		expectedArgs []string
+		expectedEnv  []string
+	}{
+		{
+			name:         "desativado",
+			mode:         config.ShellEscapeOff,
+			expectedArgs: []string{"-no-shell-escape"},
+		},
+		{
+			name:         "liberado",
+			mode:         config.ShellEscapeOn,
+			allowed:      []string{"pygmentize"},
+			expectedArgs: []string{"-shell-escape"},
+		},
+		{
+			name:         "restrito com lista padrão",
+			mode:         config.ShellEscapeRestricted,
+			expectedArgs: []string{"-shell-restricted"},
+			expectedEnv:  []string{"shell_escape_commands=" + defaults},
+		},
+		{
+			name:         "restrito com comandos extras sem repetições",
+			mode:         config.ShellEscapeRestricted,
+			allowed:      []string{"pygmentize", "makeindex", " gnuplot "},
+			expectedArgs: []string{"-shell-restricted"},
+			expectedEnv:  []string{"shell_escape_commands=" + defaults + ",pygmentize,gnuplot"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			args, env := shellEscapeOptions(tt.mode, tt.allowed)
+			if !reflect.DeepEqual(args, tt.expectedArgs) {
+				t.Errorf("shellEscapeOptions() args = %v, expected %v", args, tt.expectedArgs)
+			}
+			if !reflect.DeepEqual(env, tt.expectedEnv) {
+				t.Errorf("shellEscapeOptions() env = %v, expected %v", env, tt.expectedEnv)
+			}
+		})
+	}
+}
@@ -1,14 +1,35 @@
 package commands
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"sort"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/image"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+)
+
+// Versão da CLI, definida por cmd.SetVersion a partir das ldflags
+var (
+	Version   = "dev"
+	BuildTime string
+)
+
+var (
+	statusJSON   bool
+	statusFormat string
 )
 
 var StatusCmd = &cobra.Command{
@@ -19,204 +40,577 @@ var StatusCmd = &cobra.Command{
 - Status da CLI e configurações
 - Status do Docker e containers
 - Informações do projeto LaTeX
-- Status da última compilação`,
+- Status da última compilação
+
+Com --json a saída é um objeto JSON; com --format, um template Go aplicado
+ao mesmo modelo, por exemplo:
+
+  ltx status --format '{{.Container.Running}}'
+  ltx status --format '{{.Project.Title}} ({{.Config.Engine}})'`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return showStatus()
+		return runStatus(statusJSON, statusFormat)
 	},
 }
 
-func showStatus() error {
-	fmt.Println("=== Status do LaTeX Docker Environment ===")
-	fmt.Println()
+func init() {
+	StatusCmd.Flags().BoolVar(&statusJSON, "json", false, "Saída em JSON")
+	StatusCmd.Flags().StringVar(&statusFormat, "format", "", "Formata a saída com um template Go")
+}
 
-	// Status da CLI
-	showCLIStatus()
-	fmt.Println()
+// statusReport reúne o estado da CLI, configuração, Docker, container, imagem
+// e projeto. É a base da saída em texto, JSON e --format.
+type statusReport struct {
+	CLI       cliStatus       `json:"cli"`
+	Config    configStatus    `json:"config"`
+	Docker    dockerStatus    `json:"docker"`
+	Container containerStatus `json:"container"`
+	Image     imageStatus     `json:"image"`
+	Project   projectStatus   `json:"project"`
+}
 
-	// Status do Docker
-	if err := showDockerStatus(); err != nil {
-		colors.Printf("[ERROR] Erro ao verificar Docker: %v\n", err)
-	}
-	fmt.Println()
+type cliStatus struct {
+	Version    string `json:"version"`
+	BuildTime  string `json:"build_time,omitempty"`
+	WorkDir    string `json:"work_dir"`
+	ConfigFile string `json:"config_file,omitempty"` // vazio se não encontrado
+}
+
+type configStatus struct {
+	Engine        string   `json:"engine"`
+	SourceDir     string   `json:"source_dir"`
+	OutputDir     string   `json:"output_dir"`
+	AuxDir        string   `json:"aux_dir"`
+	ContainerMode string   `json:"container_mode"`
+	LatexImage    string   `json:"latex_image"`
+	StartTimeout  string   `json:"start_timeout"`
+	BuildTimeout  string   `json:"build_timeout"`
+	ShellEscape   string   `json:"shell_escape"`
+	Bibliography  string   `json:"bibliography_backend"`
+	CPULimit      float64  `json:"cpu_limit,omitempty"`
+	MemoryLimit   int64    `json:"memory_limit,omitempty"`
+	PidsLimit     int64    `json:"pids_limit,omitempty"`
+	Errors        []string `json:"errors,omitempty"` // valores inválidos
+}
 
-	// Status do Projeto
-	showProjectStatus()
+type dockerStatus struct {
+	Available  bool   `json:"available"`
+	Version    string `json:"version,omitempty"`
+	APIVersion string `json:"api_version,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+type containerStatus struct {
+	Name            string `json:"name"`
+	Mode            string `json:"mode"`
+	Exists          bool   `json:"exists"`
+	Running         bool   `json:"running"`
+	Health          string `json:"health,omitempty"`
+	Image           string `json:"image,omitempty"`
+	LastHealthcheck string `json:"last_healthcheck,omitempty"`
+}
+
+type imageStatus struct {
+	Ref      string      `json:"ref"`
+	Origin   string      `json:"origin,omitempty"`
+	Lock     *lockStatus `json:"lock,omitempty"`
+	Warnings []string    `json:"warnings,omitempty"`
+}
+
+type lockStatus struct {
+	Digest    string `json:"digest"`
+	TexLive   string `json:"texlive,omitempty"`
+	Available bool   `json:"available"`
+}
+
+type projectStatus struct {
+	Initialized bool           `json:"initialized"`
+	MainFile    string         `json:"main_file"`
+	Title       string         `json:"title,omitempty"`
+	Author      string         `json:"author,omitempty"`
+	LatexFiles  int            `json:"latex_files"`
+	Chapters    int            `json:"chapters"`
+	BibEntries  int            `json:"bib_entries"`
+	Stats       *latex.Stats   `json:"stats,omitempty"`
+	Missing     []string       `json:"missing_includes,omitempty"`
+	BibFiles    []string       `json:"bib_files,omitempty"`
+	BibTypes    map[string]int `json:"bib_types,omitempty"`
+	BibBackend  string         `json:"bib_backend,omitempty"` // detectado nos fontes
+	IndexTools  []string       `json:"index_tools,omitempty"` // glossários, nomenclaturas e índices
+	PDF         *pdfStatus     `json:"pdf,omitempty"`
+}
+
+type pdfStatus struct {
+	Path     string    `json:"path"`
+	Modified time.Time `json:"modified"`
+}
+
+func runStatus(asJSON bool, format string) error {
+	report := gatherStatus()
+
+	switch {
+	case asJSON:
+		data, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	case format != "":
+		tmpl, err := template.New("status").Parse(format)
+		if err != nil {
+			return fmt.Errorf("template de --format inválido: %w", err)
+		}
+		if err := tmpl.Execute(os.Stdout, report); err != nil {
+			return fmt.Errorf("erro ao aplicar --format: %w", err)
+		}
+		fmt.Println()
+	default:
+		printStatus(report)
+	}
 
 	return nil
 }
 
-func showCLIStatus() {
-	fmt.Println("=== LaTeX CLI ===")
-	fmt.Println("Versão: 2.0.0")
+func showStatus() error {
+	return runStatus(false, "")
+}
 
-	workDir, _ := os.Getwd()
-	fmt.Printf("Diretório do projeto: %s\n", workDir)
+// gatherStatus coleta o estado atual; falhas (Docker indisponível, lock
+// inválido) são registradas no relatório, não retornadas
+func gatherStatus() statusReport {
+	var report statusReport
+
+	report.CLI = gatherCLIStatus()
+	report.Config = gatherConfigStatus()
+	report.Container = containerStatus{Name: config.GetContainerName(), Mode: report.Config.ContainerMode}
 
-	configPath := "config/latex-cli.conf"
-	if _, err := os.Stat(configPath); err == nil {
-		fmt.Printf("Arquivo de configuração: %s\n", configPath)
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		report.Docker.Error = err.Error()
 	} else {
-		fmt.Printf("Arquivo de configuração: não encontrado\n")
+		defer closeClient()
+		ctx := context.Background()
+		report.Docker = gatherDockerStatus(ctx, client)
+		gatherContainerStatus(ctx, client, &report.Container)
 	}
 
-	fmt.Println("Configurações:")
-	fmt.Println("  Engine LaTeX: pdflatex")
-	fmt.Println("  Diretório fonte: src")
-	fmt.Println("  Diretório de saída: dist")
-	fmt.Println("  Container: latex-env")
+	report.Image = gatherImageStatus(client, report.Container)
+	report.Project = gatherProjectStatus(report.Config.SourceDir, report.Config.OutputDir)
+
+	return report
 }
 
-func showDockerStatus() error {
-	fmt.Println("=== Status do Docker ===")
+func gatherCLIStatus() cliStatus {
+	workDir, _ := os.Getwd()
 
-	// Verificar se Docker está disponível
-	if err := exec.Command("docker", "version").Run(); err != nil {
-		fmt.Println("✗ Docker não está disponível")
-		return err
+	status := cliStatus{Version: Version, BuildTime: BuildTime, WorkDir: workDir}
+	if file := viper.ConfigFileUsed(); file != "" {
+		if _, err := os.Stat(file); err == nil {
+			status.ConfigFile = file
+		}
 	}
-	fmt.Println("✓ Docker está disponível")
+	return status
+}
 
-	// Obter versão do Docker
-	cmd := exec.Command("docker", "version", "--format", "{{.Server.Version}}")
-	if output, err := cmd.Output(); err == nil {
-		fmt.Printf("Versão: %s", string(output))
+func gatherConfigStatus() configStatus {
+	status := configStatus{
+		Engine:       config.GetLatexEngine(),
+		SourceDir:    config.GetSourceDir(),
+		OutputDir:    config.GetOutputDir(),
+		AuxDir:       mainAuxDir(),
+		LatexImage:   config.GetLatexImage(),
+		StartTimeout: config.GetStartTimeout().String(),
 	}
 
-	// Verificar Docker Compose
-	if err := exec.Command("docker", "compose", "version").Run(); err != nil {
-		fmt.Println("✗ Docker Compose não está disponível")
+	mode, err := config.GetContainerMode()
+	if err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	}
+	status.ContainerMode = mode
+
+	if timeout, err := config.GetBuildTimeout(); err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else if timeout > 0 {
+		status.BuildTimeout = timeout.String()
 	} else {
-		fmt.Println("✓ Docker Compose está disponível")
+		status.BuildTimeout = "0"
 	}
 
-	// Verificar container
-	return checkContainerStatus()
+	if escape, err := config.GetShellEscapeFor(targetName(projectMainFile())); err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else {
+		status.ShellEscape = escape
+	}
+
+	if backend, err := config.GetBibliographyBackend(); err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else {
+		status.Bibliography = backend
+	}
+
+	if limits, err := config.GetResourceLimits(); err != nil {
+		status.Errors = append(status.Errors, err.Error())
+	} else {
+		status.CPULimit = limits.CPUs
+		status.MemoryLimit = limits.Memory
+		status.PidsLimit = limits.Pids
+	}
+
+	return status
 }
 
-func checkContainerStatus() error {
-	// Verificar se container está rodando
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "ps", "-q", "latex-env")
-	output, err := cmd.Output()
+func gatherDockerStatus(ctx context.Context, client *docker.Client) dockerStatus {
+	status := dockerStatus{Available: true}
 
-	if err != nil || len(strings.TrimSpace(string(output))) == 0 {
-		fmt.Println("✗ Container latex-env não está executando")
-		return nil
+	if info, err := client.ServerInfo(ctx); err == nil {
+		status.Version = info.Version
+		status.APIVersion = info.APIVersion
 	}
+	return status
+}
 
-	fmt.Println("✓ Container latex-env está executando")
+func gatherContainerStatus(ctx context.Context, client *docker.Client, status *containerStatus) {
+	state, err := client.ContainerHealth(ctx, status.Name)
+	if err != nil {
+		return
+	}
 
-	// Verificar saúde do container
-	containerID := strings.TrimSpace(string(output))
-	cmd = exec.Command("docker", "inspect", "--format", "{{.State.Health.Status}}", containerID)
-	if healthOutput, err := cmd.Output(); err == nil {
-		health := strings.TrimSpace(string(healthOutput))
-		if health == "healthy" {
-			fmt.Println("✓ Container está saudável")
-		} else {
-			fmt.Printf("⚠ Container health: %s\n", health)
+	status.Exists = true
+	status.Running = state.Running
+	status.Health = state.Health
+	if state.Health != "" && state.Health != "healthy" {
+		status.LastHealthcheck = state.LastOutput
+	}
+	if image, err := client.ContainerImage(ctx, status.Name); err == nil {
+		status.Image = image
+	}
+}
+
+func gatherImageStatus(client *docker.Client, container containerStatus) imageStatus {
+	var status imageStatus
+
+	l, err := lock.Load(lock.File)
+	if err != nil {
+		status.Ref = config.GetLatexImage()
+		status.Warnings = append(status.Warnings, err.Error())
+		return status
+	}
+
+	status.Ref = currentImageRef()
+	if status.Ref == "" {
+		status.Ref = defaultEnvImage
+	}
+
+	ctx := context.Background()
+	if client != nil {
+		status.Origin = imageOrigin(ctx, client, status.Ref)
+	}
+
+	if l == nil {
+		return status
+	}
+
+	status.Lock = &lockStatus{Digest: l.Digest, TexLive: l.TexLive}
+	status.Warnings = append(status.Warnings, lockDrift(l)...)
+
+	if client == nil {
+		return status
+	}
+
+	if exists, err := client.ImageExists(ctx, lockedRef(l)); err == nil {
+		status.Lock.Available = exists
+	}
+
+	if info, err := client.InspectImage(ctx, l.Image); err == nil && !l.Matches(info.Digests) {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("A tag local %s aponta para outro digest; execute 'ltx lock update' para adotá-la", l.Image))
+	}
+
+	if container.Image != "" && container.Image != status.Ref {
+		status.Warnings = append(status.Warnings, fmt.Sprintf("O container usa %s, diferente da imagem travada (será recriado no próximo build)", container.Image))
+	}
+
+	return status
+}
+
+func gatherProjectStatus(sourceDir, outputDir string) projectStatus {
+	status := projectStatus{MainFile: filepath.Join(sourceDir, "main.tex")}
+
+	project, err := latex.Load(status.MainFile)
+	if err != nil {
+		return status
+	}
+
+	stats := project.Stats()
+	status.Initialized = true
+	status.Title = latex.PlainText(project.FirstArg("title"))
+	status.Author = latex.PlainText(project.FirstArg("author"))
+	status.LatexFiles = countLatexFiles(sourceDir)
+	status.Chapters = stats.Sections["chapter"]
+	status.Stats = &stats
+	status.Missing = project.Missing
+	status.BibBackend = project.Bibliography().Backend
+	status.IndexTools = project.Indexing().Tools()
+
+	// Sem \bibliography ou \addbibresource, considerar o arquivo padrão do template
+	status.BibFiles = project.BibFiles()
+	if len(status.BibFiles) == 0 {
+		fallback := filepath.Join(sourceDir, "references.bib")
+		if _, err := os.Stat(fallback); err == nil {
+			status.BibFiles = []string{fallback}
+		}
+	}
+	for _, bib := range status.BibFiles {
+		types, err := latex.BibEntryTypes(bib)
+		if err != nil {
+			continue
+		}
+		if status.BibTypes == nil {
+			status.BibTypes = make(map[string]int)
+		}
+		for entryType, n := range types {
+			status.BibTypes[entryType] += n
+			status.BibEntries += n
 		}
 	}
 
-	return nil
+	pdfPath := filepath.Join(outputDir, targetName(status.MainFile)+".pdf")
+	if stat, err := os.Stat(pdfPath); err == nil {
+		status.PDF = &pdfStatus{Path: pdfPath, Modified: stat.ModTime()}
+	}
+
+	return status
 }
 
-func showProjectStatus() {
-	fmt.Println("=== Status do Projeto ===")
+// printStatus exibe o relatório em texto
+func printStatus(r statusReport) {
+	fmt.Println("=== Status do LaTeX Docker Environment ===")
+	fmt.Println()
 
-	sourceDir := "src"
-	mainTexPath := filepath.Join(sourceDir, "main.tex")
+	fmt.Println("=== LaTeX CLI ===")
+	fmt.Printf("Versão: %s\n", r.CLI.Version)
+	fmt.Printf("Diretório do projeto: %s\n", r.CLI.WorkDir)
+	if r.CLI.ConfigFile != "" {
+		fmt.Printf("Arquivo de configuração: %s\n", r.CLI.ConfigFile)
+	} else {
+		fmt.Printf("Arquivo de configuração: não encontrado\n")
+	}
 
-	// Verificar se projeto está inicializado
-	if _, err := os.Stat(mainTexPath); os.IsNotExist(err) {
-		fmt.Println("✗ Projeto não inicializado")
-		fmt.Println("  Execute 'ltx init' para começar")
+	fmt.Println("Configurações:")
+	fmt.Printf("  Engine LaTeX: %s\n", r.Config.Engine)
+	fmt.Printf("  Diretório fonte: %s\n", r.Config.SourceDir)
+	fmt.Printf("  Diretório de saída: %s\n", r.Config.OutputDir)
+	fmt.Printf("  Diretório de auxiliares: %s\n", r.Config.AuxDir)
+	fmt.Printf("  Modo do container: %s\n", r.Config.ContainerMode)
+	fmt.Printf("  Container: %s\n", r.Container.Name)
+	for _, e := range r.Config.Errors {
+		fmt.Printf("✗ %s\n", e)
+	}
+	fmt.Println()
+
+	fmt.Println("=== Status do Docker ===")
+	if !r.Docker.Available {
+		fmt.Println("✗ Docker não está disponível")
+		colors.Printf("[ERROR] Erro ao verificar Docker: %s\n", r.Docker.Error)
+	} else {
+		fmt.Println("✓ Docker está disponível")
+		if r.Docker.Version != "" {
+			fmt.Printf("Versão: %s (API %s)\n", r.Docker.Version, r.Docker.APIVersion)
+		}
+		printContainerStatus(r.Container)
+	}
+	fmt.Println()
+
+	printImageStatus(r.Image, r.Docker.Available)
+	fmt.Println()
+
+	printProjectStatus(r.Project)
+}
+
+func printContainerStatus(c containerStatus) {
+	if c.Mode == config.ContainerModeEphemeral {
+		fmt.Println("✓ Modo efêmero: um container descartável por compilação")
 		return
 	}
 
-	fmt.Println("✓ Projeto inicializado")
+	if !c.Running {
+		fmt.Printf("✗ Container %s não está executando\n", c.Name)
+		return
+	}
 
-	// Tentar extrair título e autor do main.tex
-	if content, err := os.ReadFile(mainTexPath); err == nil {
-		contentStr := string(content)
+	fmt.Printf("✓ Container %s está executando\n", c.Name)
 
-		if title := extractFromLatex(contentStr, "\\title{"); title != "" {
-			fmt.Printf("  Título: %s\n", title)
-		} else {
-			fmt.Println("  Título: Não encontrado")
+	// Verificar saúde do container
+	switch c.Health {
+	case "healthy":
+		fmt.Println("✓ Container está saudável")
+	case "":
+		fmt.Println("⚠ Container não declara healthcheck")
+	default:
+		fmt.Printf("⚠ Container health: %s\n", c.Health)
+		printLastHealthcheck(c.LastHealthcheck)
+	}
+}
+
+// printLastHealthcheck exibe a saída da última verificação do healthcheck
+func printLastHealthcheck(output string) {
+	if output == "" {
+		return
+	}
+
+	fmt.Println("  Última verificação:")
+	for _, line := range strings.Split(output, "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+}
+
+func printImageStatus(img imageStatus, dockerAvailable bool) {
+	fmt.Println("=== Imagem LaTeX ===")
+	fmt.Printf("Em uso: %s\n", img.Ref)
+	if img.Origin != "" {
+		fmt.Printf("Origem: %s\n", img.Origin)
+	}
+
+	if img.Lock == nil {
+		for _, w := range img.Warnings {
+			fmt.Printf("✗ %s\n", w)
 		}
+		if len(img.Warnings) == 0 {
+			fmt.Printf("⚠ Nenhum %s (imagem não fixada por digest)\n", lock.File)
+			fmt.Println("  Execute 'ltx lock update' para fixar a imagem")
+		}
+		return
+	}
 
-		if author := extractFromLatex(contentStr, "\\author{"); author != "" {
-			fmt.Printf("  Autor: %s\n", author)
+	fmt.Printf("Digest travado: %s\n", img.Lock.Digest)
+	if img.Lock.TexLive != "" {
+		fmt.Printf("TeX Live: %s\n", img.Lock.TexLive)
+	}
+	if dockerAvailable {
+		if img.Lock.Available {
+			fmt.Println("✓ Imagem travada disponível localmente")
 		} else {
-			fmt.Println("  Autor: Não encontrado")
+			fmt.Println("⚠ Imagem travada não está disponível localmente (será baixada no próximo build)")
 		}
 	}
+	for _, w := range img.Warnings {
+		fmt.Printf("⚠ %s\n", w)
+	}
+}
 
-	// Contar arquivos LaTeX
-	latexFiles := countLatexFiles(sourceDir)
-	fmt.Printf("  Arquivos LaTeX: %d\n", latexFiles)
+func printProjectStatus(p projectStatus) {
+	fmt.Println("=== Status do Projeto ===")
 
-	// Verificar PDF
-	pdfPath := "dist/main.pdf"
-	if stat, err := os.Stat(pdfPath); err == nil {
-		fmt.Printf("✓ PDF disponível (compilado em: %s)\n", stat.ModTime().Format("2006-01-02 15:04:05"))
+	// Verificar se projeto está inicializado
+	if !p.Initialized {
+		fmt.Println("✗ Projeto não inicializado")
+		fmt.Println("  Execute 'ltx init' para começar")
+		return
+	}
 
-		// Contar capítulos (arquivos .tex em chapters/)
-		chaptersDir := filepath.Join(sourceDir, "chapters")
-		if chapters := countFiles(chaptersDir, ".tex"); chapters > 0 {
-			fmt.Printf("  Capítulos: %d\n", chapters)
-		}
+	fmt.Println("✓ Projeto inicializado")
+	fmt.Printf("  Título: %s\n", valueOr(p.Title, "Não encontrado"))
+	fmt.Printf("  Autor: %s\n", valueOr(p.Author, "Não encontrado"))
+	fmt.Printf("  Arquivos LaTeX: %d\n", p.LatexFiles)
+	if p.Stats != nil {
+		printProjectStats(p)
+	}
 
-		// Contar referências
-		referencesPath := filepath.Join(sourceDir, "references.bib")
-		if refs := countBibEntries(referencesPath); refs > 0 {
-			fmt.Printf("  Referências bibliográficas: %d\n", refs)
-		}
-	} else {
+	if p.PDF == nil {
 		fmt.Println("✗ PDF não encontrado")
 		fmt.Println("  Execute 'ltx build' para compilar")
+		return
 	}
+
+	fmt.Printf("✓ PDF disponível (compilado em: %s)\n", p.PDF.Modified.Format("2006-01-02 15:04:05"))
 }
 
-func extractFromLatex(content, command string) string {
-	start := strings.Index(content, command)
-	if start == -1 {
-		return ""
-	}
+// sectionNames são os nomes exibidos para cada nível de seção
+var sectionNames = map[string]string{
+	"part":          "partes",
+	"chapter":       "capítulos",
+	"section":       "seções",
+	"subsection":    "subseções",
+	"subsubsection": "subsubseções",
+	"paragraph":     "parágrafos",
+	"subparagraph":  "subparágrafos",
+}
 
-	// Procurar a primeira '{' após o comando
-	start += len(command)
-	for start < len(content) && content[start] != '{' {
-		start++
+// printProjectStats exibe a estrutura do documento obtida pelo scanner
+func printProjectStats(p projectStatus) {
+	s := p.Stats
+
+	fmt.Printf("  Arquivos incluídos a partir de %s: %d\n", filepath.Base(p.MainFile), s.Files)
+
+	var sections []string
+	for _, level := range latex.SectionLevels {
+		if n := s.Sections[level]; n > 0 {
+			sections = append(sections, fmt.Sprintf("%d %s", n, sectionNames[level]))
+		}
+	}
+	if len(sections) > 0 {
+		fmt.Printf("  Seções: %s\n", strings.Join(sections, ", "))
 	}
 
-	if start >= len(content) {
-		return ""
+	fmt.Printf("  Figuras: %d | Tabelas: %d | Equações: %d | Rótulos: %d\n", s.Figures, s.Tables, s.Equations, s.Labels)
+	if s.Citations > 0 {
+		fmt.Printf("  Citações: %d (%d chaves distintas)\n", s.Citations, s.UniqueCitations)
 	}
 
-	// Pular a '{'
-	start++
-	depth := 1
+	if p.BibEntries > 0 {
+		types := make([]string, 0, len(p.BibTypes))
+		for entryType := range p.BibTypes {
+			types = append(types, entryType)
+		}
+		sort.Strings(types)
 
-	for i := start; i < len(content) && depth > 0; i++ {
-		switch content[i] {
-		case '{':
-			depth++
-		case '}':
-			depth--
+		var parts []string
+		for _, entryType := range types {
+			parts = append(parts, fmt.Sprintf("%s: %d", entryType, p.BibTypes[entryType]))
 		}
+		fmt.Printf("  Referências bibliográficas: %d (%s)\n", p.BibEntries, strings.Join(parts, ", "))
+	}
+	if p.BibBackend != "" {
+		fmt.Printf("  Bibliografia: %s\n", p.BibBackend)
+	}
+	if len(p.IndexTools) > 0 {
+		fmt.Printf("  Glossários e índices: %s\n", strings.Join(p.IndexTools, ", "))
+	}
 
-		if depth == 0 {
-			result := content[start:i]
-			// Limpar formatação básica mas preservar chaves internas
-			result = strings.ReplaceAll(result, "\\textbf{", "")
-			result = strings.ReplaceAll(result, "\\emph{", "")
-			// Não remover todas as chaves, apenas as de formatação
-			return strings.TrimSpace(result)
+	for _, missing := range p.Missing {
+		fmt.Printf("⚠ Arquivo incluído não encontrado: %s\n", missing)
+	}
+}
+
+func valueOr(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+// imageOrigin descreve de onde veio uma imagem local: arquivo importado,
+// construção local ou registro
+func imageOrigin(ctx context.Context, client *docker.Client, ref string) string {
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return "não disponível localmente"
+	}
+
+	if o, err := image.LoadOrigin(image.OriginFile); err == nil && o != nil &&
+		(o.Manifest.ID == info.ID || o.Manifest.BaseID == info.ID) {
+		origin := fmt.Sprintf("arquivo %s (importado em %s", o.Archive, o.ImportedAt.Local().Format("2006-01-02 15:04"))
+		if o.Manifest.Host != "" {
+			origin += fmt.Sprintf(", exportado de %s", o.Manifest.Host)
 		}
+		return origin + ")"
 	}
 
-	return ""
+	if base := info.Labels[image.BaseLabel]; base != "" {
+		return fmt.Sprintf("construída localmente (pacotes extras sobre %s)", base)
+	}
+	if len(info.Digests) > 0 {
+		return fmt.Sprintf("registro (%s)", info.Digests[0])
+	}
+	return "construída localmente"
 }
 
 func countLatexFiles(dir string) int {
@@ -236,24 +630,3 @@ func countLatexFiles(dir string) int {
 	}
 	return count
 }
-
-func countFiles(dir, ext string) int {
-	count := 0
-	if entries, err := os.ReadDir(dir); err == nil {
-		for _, entry := range entries {
-			if !entry.IsDir() && filepath.Ext(entry.Name()) == ext {
-				count++
-			}
-		}
-	}
-	return count
-}
-
-func countBibEntries(bibPath string) int {
-	content, err := os.ReadFile(bibPath)
-	if err != nil {
-		return 0
-	}
-
-	return strings.Count(string(content), "@")
-}
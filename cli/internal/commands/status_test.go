@@ -3,7 +3,10 @@ package commands
 import (
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/spf13/viper"
 )
 
 func TestShowStatus(t *testing.T) {
@@ -53,49 +56,6 @@ func TestShowStatus(t *testing.T) {
 	}
 }
 
-func TestExtractFromLatex(t *testing.T) {
-	tests := []struct {
-		name     string
-		content  string
-		command  string
-		expected string
-	}{
-		{
-			name:     "extrair título",
-			content:  "\\title{My Great Title}\\begin{document}",
-			command:  "\\title",
-			expected: "My Great Title",
-		},
-		{
-			name:     "extrair autor",
-			content:  "\\author{John Doe}\\begin{document}",
-			command:  "\\author",
-			expected: "John Doe",
-		},
-		{
-			name:     "sem título",
-			content:  "\\begin{document}Content\\end{document}",
-			command:  "\\title",
-			expected: "",
-		},
-		{
-			name:     "título com chaves extras",
-			content:  "\\title{My {Complex} Title}\\begin{document}",
-			command:  "\\title",
-			expected: "My {Complex} Title",
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			result := extractFromLatex(tt.content, tt.command)
-			if result != tt.expected {
-				t.Errorf("extractFromLatex() = %v, want %v", result, tt.expected)
-			}
-		})
-	}
-}
-
 func TestCountLatexFiles(t *testing.T) {
 	// Criar diretório temporário
 	tempDir := t.TempDir()
@@ -125,98 +85,72 @@ func TestCountLatexFiles(t *testing.T) {
 	}
 }
 
-func TestCountFiles(t *testing.T) {
-	// Criar diretório temporário
+func TestGatherConfigStatus(t *testing.T) {
+	viper.Reset()
+	defer viper.Reset()
+
+	viper.Set("latex_engine", "lualatex")
+	viper.Set("output_dir", "out")
+	viper.Set("container_mode", "ephemeral")
+	viper.Set("build_timeout", "0")
+	viper.Set("memory_limit", "muito")
+
+	status := gatherConfigStatus()
+
+	if status.Engine != "lualatex" {
+		t.Errorf("Engine = %q, expected lualatex", status.Engine)
+	}
+	if status.SourceDir != "src" || status.OutputDir != "out" {
+		t.Errorf("SourceDir/OutputDir = %q/%q, expected src/out", status.SourceDir, status.OutputDir)
+	}
+	if status.ContainerMode != "ephemeral" {
+		t.Errorf("ContainerMode = %q, expected ephemeral", status.ContainerMode)
+	}
+	if status.BuildTimeout != "0" {
+		t.Errorf("BuildTimeout = %q, expected 0", status.BuildTimeout)
+	}
+	if len(status.Errors) != 1 || !strings.Contains(status.Errors[0], "memory_limit") {
+		t.Errorf("Errors = %v, expected erro de memory_limit", status.Errors)
+	}
+}
+
+func TestGatherProjectStatus(t *testing.T) {
 	tempDir := t.TempDir()
 
-	// Criar arquivos de teste
-	files := []string{"file1.txt", "file2.png", "file3.pdf"}
-	for _, file := range files {
-		filePath := filepath.Join(tempDir, file)
-		if err := os.WriteFile(filePath, []byte("content"), 0644); err != nil {
+	files := map[string]string{
+		"src/main.tex":          "\\title{\\textbf{Minha Tese}}\\author{Ana}\\begin{document}\n% \\input{chapters/antigo}\n\\input{chapters/cap1}\n\\bibliography{refs}\n\\end{document}",
+		"src/chapters/cap1.tex": "\\chapter{Um}\\section{A}\\cite{x,y}\\cite{x}\n\\begin{verbatim}\n\\section{Exemplo}\n\\end{verbatim}",
+		"src/refs.bib":          "@article{x,\n  note = {a@b.com}\n}\n@book{y,}",
+		"out/main.pdf":          "%PDF",
+	}
+	for file, content := range files {
+		path := filepath.Join(tempDir, file)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Erro ao criar diretório: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
 			t.Fatalf("Erro ao criar arquivo %s: %v", file, err)
 		}
 	}
 
-	count := countFiles(tempDir, ".txt")
-	expected := 1 // file1.txt
-	if count != expected {
-		t.Errorf("countFiles() = %d, want %d", count, expected)
-	}
+	status := gatherProjectStatus(filepath.Join(tempDir, "src"), filepath.Join(tempDir, "out"))
 
-	// Testar com outra extensão
-	count = countFiles(tempDir, ".png")
-	expected = 1 // file2.png
-	if count != expected {
-		t.Errorf("countFiles() = %d, want %d", count, expected)
+	if !status.Initialized {
+		t.Fatal("Initialized = false, expected true")
 	}
-
-	// Testar contagem total (simulando uma extensão que não existe)
-	count = countFiles(tempDir, ".xyz")
-	expected = 0
-	if count != expected {
-		t.Errorf("countFiles() = %d, want %d", count, expected)
+	if status.Title != "Minha Tese" || status.Author != "Ana" {
+		t.Errorf("Title/Author = %q/%q, expected Minha Tese/Ana", status.Title, status.Author)
 	}
-}
-
-func TestCountBibEntries(t *testing.T) {
-	tests := []struct {
-		name     string
-		content  string
-		expected int
-	}{
-		{
-			name:     "arquivo bib vazio",
-			content:  "",
-			expected: 0,
-		},
-		{
-			name: "uma entrada",
-			content: `@article{key1,
-				title={Test Title},
-				author={Test Author}
-			}`,
-			expected: 1,
-		},
-		{
-			name: "múltiplas entradas",
-			content: `@article{key1,
-				title={Test Title 1}
-			}
-			@book{key2,
-				title={Test Book}
-			}
-			@inproceedings{key3,
-				title={Test Proceedings}
-			}`,
-			expected: 3,
-		},
-		{
-			name: "entradas com comentários",
-			content: `% This is a comment
-			@article{key1,
-				title={Test Title}
-			}
-			% Another comment
-			@book{key2,
-				title={Test Book}
-			}`,
-			expected: 2,
-		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			// Criar arquivo temporário
-			tempFile := filepath.Join(t.TempDir(), "test.bib")
-			if err := os.WriteFile(tempFile, []byte(tt.content), 0644); err != nil {
-				t.Fatalf("Erro ao criar arquivo de teste: %v", err)
-			}
-
-			count := countBibEntries(tempFile)
-			if count != tt.expected {
-				t.Errorf("countBibEntries() = %d, want %d", count, tt.expected)
-			}
-		})
+	if status.LatexFiles != 2 || status.Chapters != 1 {
+		t.Errorf("LatexFiles/Chapters = %d/%d, expected 2/1", status.LatexFiles, status.Chapters)
+	}
+	if status.Stats == nil || status.Stats.Sections["section"] != 1 || status.Stats.Citations != 3 || status.Stats.UniqueCitations != 2 {
+		t.Errorf("Stats = %+v, expected 1 seção e 3 citações (2 distintas)", status.Stats)
+	}
+	if status.BibEntries != 2 || status.BibTypes["article"] != 1 || status.BibTypes["book"] != 1 {
+		t.Errorf("BibEntries/BibTypes = %d/%v, expected 2 (article e book)", status.BibEntries, status.BibTypes)
+	}
+	if status.PDF == nil {
+		t.Error("PDF = nil, expected PDF em out/")
 	}
 }
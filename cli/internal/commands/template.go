@@ -11,6 +11,11 @@ import (
 	"github.com/martinsmiguel/latex-docker-env/cli/pkg/types"
 )
 
+var (
+	validateCheckDeps   bool
+	validateInstallDeps bool
+)
+
 var TemplateCmd = &cobra.Command{
 	Use:   "template",
 	Short: "Gerencia templates do LaTeX",
@@ -29,7 +34,10 @@ var listTemplatesCmd = &cobra.Command{
 var validateTemplateCmd = &cobra.Command{
 	Use:   "validate [template-path]",
 	Short: "Valida um template",
-	Long:  `Valida a estrutura e metadados de um template.`,
+	Long: `Valida a estrutura e metadados de um template.
+
+Com --check-deps, verifica também se os pacotes LaTeX listados em
+'dependencies' estão instalados no container (ou no TeX Live local).`,
 	Args:  cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return validateTemplate(args[0])
@@ -39,6 +47,9 @@ var validateTemplateCmd = &cobra.Command{
 func init() {
 	TemplateCmd.AddCommand(listTemplatesCmd)
 	TemplateCmd.AddCommand(validateTemplateCmd)
+
+	validateTemplateCmd.Flags().BoolVar(&validateCheckDeps, "check-deps", false, "Verifica se as dependências LaTeX estão instaladas")
+	validateTemplateCmd.Flags().BoolVar(&validateInstallDeps, "install-deps", false, "Instala dependências ausentes com tlmgr (implica --check-deps)")
 }
 
 func listTemplates() error {
@@ -166,5 +177,16 @@ func validateTemplate(templatePath string) error {
 		}
 	}
 
+	if validateCheckDeps || validateInstallDeps {
+		missing, err := checkTemplateDependencies(tmpl, validateInstallDeps, true)
+		if err != nil {
+			colors.PrintError(fmt.Sprintf("❌ Erro ao verificar dependências: %v", err))
+			return err
+		}
+		if len(missing) > 0 {
+			return fmt.Errorf("%d dependência(s) ausente(s)", len(missing))
+		}
+	}
+
 	return nil
 }
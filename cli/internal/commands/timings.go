@@ -0,0 +1,77 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+)
+
+// timingsFile guarda os tempos da última compilação em cada container_mode
+var timingsFile = filepath.Join(".ltx", "build-timings.yaml")
+
+// buildTimings registra as fases de uma compilação
+type buildTimings struct {
+	Mode    string        `yaml:"-"`
+	Env     time.Duration `yaml:"env"`     // preparo do ambiente (container ou imagem) e pacotes
+	Compile time.Duration `yaml:"compile"` // latexmk, incluindo recompilações
+	Total   time.Duration `yaml:"total"`
+	At      time.Time     `yaml:"at"`
+}
+
+// reportBuildTimings exibe os tempos da compilação atual e os da última
+// compilação no outro modo, para ajudar a escolher o container_mode
+func reportBuildTimings(current buildTimings) {
+	current.At = time.Now().UTC().Truncate(time.Second)
+
+	colors.Printf("[INFO] Tempos (%s): ambiente %v, compilação %v, total %v\n",
+		current.Mode, current.Env.Round(10*time.Millisecond), current.Compile.Round(10*time.Millisecond), current.Total.Round(10*time.Millisecond))
+
+	history := loadBuildTimings()
+
+	other := config.ContainerModeEphemeral
+	if current.Mode == config.ContainerModeEphemeral {
+		other = config.ContainerModePersistent
+	}
+
+	if last, ok := history[other]; ok {
+		colors.Printf("[INFO] Última compilação em %s (%s): ambiente %v, compilação %v, total %v\n",
+			other, last.At.Local().Format("2006-01-02 15:04"), last.Env.Round(10*time.Millisecond), last.Compile.Round(10*time.Millisecond), last.Total.Round(10*time.Millisecond))
+	} else {
+		colors.Printf("[INFO] Compile com CONTAINER_MODE=%s para comparar os tempos\n", other)
+	}
+
+	history[current.Mode] = current
+	if err := saveBuildTimings(history); err != nil {
+		colors.PrintWarn(fmt.Sprintf("Não foi possível registrar os tempos: %v", err))
+	}
+}
+
+func loadBuildTimings() map[string]buildTimings {
+	history := make(map[string]buildTimings)
+
+	data, err := os.ReadFile(timingsFile)
+	if err != nil {
+		return history
+	}
+	if err := yaml.Unmarshal(data, &history); err != nil {
+		return make(map[string]buildTimings)
+	}
+	return history
+}
+
+func saveBuildTimings(history map[string]buildTimings) error {
+	data, err := yaml.Marshal(history)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(timingsFile), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(timingsFile, data, 0644)
+}
@@ -1,13 +1,16 @@
 package commands
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/template"
 )
 
@@ -59,24 +62,30 @@ func init() {
 func cleanProject() error {
 	colors.Println(">> Limpando arquivos temporários...")
 
-	distDir := "dist"
-	if _, err := os.Stat(distDir); os.IsNotExist(err) {
-		colors.PrintInfo("Diretório dist não existe, nada para limpar")
+	distDir := config.GetOutputDir()
+	var dirs []string
+	for _, dir := range tempFileDirs() {
+		if _, err := os.Stat(dir); err == nil {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		colors.PrintInfo("Diretórios de saída e de auxiliares não existem, nada para limpar")
 		return nil
 	}
 
-	// Padrões de arquivos temporários
-	tempPatterns := []string{
-		"*.aux", "*.log", "*.bbl", "*.blg", "*.fls",
-		"*.fdb_latexmk", "*.synctex.gz", "*.out",
-		"*.toc", "*.lot", "*.lof", "*.nav", "*.snm",
-		"*.vrb", "*.idx", "*.ind", "*.ilg",
+	checkOwnership()
+
+	// Inclui glossários, siglas, nomenclaturas e índices (declarados no documento)
+	var tempPatterns []string
+	for _, dir := range dirs {
+		tempPatterns = append(tempPatterns, tempFilePatterns(dir)...)
 	}
 
 	cleanedCount := 0
 
 	for _, pattern := range tempPatterns {
-		matches, err := filepath.Glob(filepath.Join(distDir, pattern))
+		matches, err := filepath.Glob(pattern)
 		if err != nil {
 			continue
 		}
@@ -117,15 +126,14 @@ func openShell() error {
 	colors.Println(">> Abrindo shell do container...")
 	colors.PrintInfo("Digite 'exit' para sair do container")
 
-	// Verificar se container está rodando
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "ps", "-q", "latex-env")
-	output, err := cmd.Output()
-	if err != nil || len(output) == 0 {
+	// Verificar se o container do projeto está rodando
+	name := config.GetContainerName()
+	if !projectEnvRunning(name) {
 		return fmt.Errorf("container não está rodando. Execute 'ltx build' primeiro")
 	}
 
-	// Abrir shell interativo
-	cmd = exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "exec", "latex-env", "/bin/bash")
+	// Abrir shell interativo (o docker CLI cuida do TTY)
+	cmd := exec.Command("docker", "exec", "-it", name, "/bin/bash")
 	cmd.Stdin = os.Stdin
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
@@ -136,28 +144,79 @@ func openShell() error {
 func showLogs() error {
 	colors.Println(">> Mostrando logs do container...")
 
-	// Mostrar logs do container
-	cmd := exec.Command("docker", "compose", "-f", "config/docker/docker-compose.yml", "logs", "--tail", "50", "latex-env")
+	// Mostrar logs do container do projeto
+	cmd := exec.Command("docker", "logs", "--tail", "50", config.GetContainerName())
 	cmd.Stdout = os.Stdout
 	cmd.Stderr = os.Stderr
 
 	return cmd.Run()
 }
 
+// projectEnvRunning indica se o container informado está em execução
+func projectEnvRunning(name string) bool {
+	client, closeClient, err := newDockerClient()
+	if err != nil {
+		return false
+	}
+	defer closeClient()
+
+	state, err := client.ContainerHealth(context.Background(), name)
+	return err == nil && state.Running
+}
+
 // Função utilitária para criar registry de templates
 func getTemplateRegistry() *template.Registry {
 	registry := template.NewRegistry()
 
-	// Usar sempre caminhos absolutos fixos para debug
-	projectRoot := "/Users/miguelmartins/workspace/labs/latex-docker-env"
-
-	registry.AddTemplatePath(filepath.Join(projectRoot, "cli/templates"))
-	registry.AddTemplatePath(filepath.Join(projectRoot, "templates"))
-	registry.AddTemplatePath(filepath.Join(projectRoot, "user-templates"))
+	for _, path := range templatePaths() {
+		registry.AddTemplatePath(path)
+	}
 
 	return registry
 }
 
+// templatePaths retorna os diretórios de templates candidatos em cada raiz
+func templatePaths() []string {
+	var paths []string
+	for _, root := range installRoots() {
+		paths = append(paths,
+			filepath.Join(root, "cli/templates"),
+			filepath.Join(root, "templates"),
+			filepath.Join(root, "user-templates"),
+		)
+	}
+	return paths
+}
+
+// installRoots retorna, sem repetições, as raízes do latex-docker-env onde
+// procurar templates: a do diretório atual, a do executável (bin/ltx) e, em
+// builds de desenvolvimento, a da árvore de código-fonte
+func installRoots() []string {
+	candidates := []string{findProjectRoot()}
+
+	if exe, err := os.Executable(); err == nil {
+		if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+			exe = resolved
+		}
+		candidates = append(candidates, rootFrom(filepath.Dir(exe)))
+	}
+
+	// Caminho deste arquivo na compilação (inexistente em builds com -trimpath)
+	if _, file, _, ok := runtime.Caller(0); ok {
+		candidates = append(candidates, rootFrom(filepath.Dir(file)))
+	}
+
+	var roots []string
+	seen := make(map[string]bool)
+	for _, root := range candidates {
+		if root != "" && !seen[root] {
+			seen[root] = true
+			roots = append(roots, root)
+		}
+	}
+	return roots
+}
+
 // Encontra o diretório raiz do projeto latex-docker-env
 func findProjectRoot() string {
 	wd, err := os.Getwd()
@@ -165,8 +224,13 @@ func findProjectRoot() string {
 		return ""
 	}
 
+	return rootFrom(wd)
+}
+
+// rootFrom sobe a partir de dir até a raiz do latex-docker-env
+func rootFrom(dir string) string {
 	// Procurar pelo arquivo go.mod ou qualquer indicador do projeto
-	for dir := wd; dir != "/" && dir != ""; dir = filepath.Dir(dir) {
+	for ; dir != "/" && dir != "." && dir != ""; dir = filepath.Dir(dir) {
 		// Verificar se existe o arquivo go.mod no subdiretório cli/
 		cliPath := filepath.Join(dir, "cli", "go.mod")
 		if _, err := os.Stat(cliPath); err == nil {
@@ -178,6 +242,10 @@ func findProjectRoot() string {
 		if _, err := os.Stat(configPath); err == nil {
 			return dir
 		}
+
+		if parent := filepath.Dir(dir); parent == dir {
+			break
+		}
 	}
 
 	return ""
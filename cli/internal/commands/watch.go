@@ -10,6 +10,8 @@ import (
 	"github.com/fsnotify/fsnotify"
 	"github.com/spf13/cobra"
 	"github.com/martinsmiguel/latex-docker-env/cli/internal/colors"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/history"
 )
 
 var (
@@ -33,6 +35,7 @@ Este comando:
 
 func watchProject() error {
 	colors.Println(">> Iniciando modo de observação...")
+	compileSource = history.SourceWatch
 
 	// Verificar se há compilações em andamento (para watch, pode ser que queiramos parar watch anterior)
 	isRunning, err := checkRunningCompilation()
@@ -54,7 +57,7 @@ func watchProject() error {
 	colors.PrintInfo("Pressione Ctrl+C para parar")
 
 	// Verificar se projeto existe
-	sourceDir := "src"
+	sourceDir := config.GetSourceDir()
 	if _, err := os.Stat(sourceDir); os.IsNotExist(err) {
 		return fmt.Errorf("diretório %s não encontrado. Execute 'ltx init' primeiro", sourceDir)
 	}
@@ -1,14 +1,32 @@
 package config
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
 	"github.com/spf13/viper"
 	"github.com/martinsmiguel/latex-docker-env/cli/pkg/types"
 )
 
 const (
-	DefaultLatexImage = "blang/latex:ubuntu"
-	DefaultOutputDir  = "dist"
-	DefaultSourceDir  = "src"
+	DefaultLatexImage  = "blang/latex:ubuntu"
+	DefaultLatexEngine = "pdflatex"
+	DefaultOutputDir   = "dist"
+	DefaultSourceDir   = "src"
+	DefaultAuxDir      = "tmp"
+
+	DefaultStartTimeout = 60 * time.Second
+	DefaultBuildTimeout = 10 * time.Minute
+)
+
+// Modos de execução das compilações (container_mode)
+const (
+	ContainerModePersistent = "persistent" // container do projeto sempre ativo, comandos via exec
+	ContainerModeEphemeral  = "ephemeral"  // um container descartável por comando
 )
 
 func GetLatexImage() string {
@@ -19,22 +37,379 @@ func GetLatexImage() string {
 	return image
 }
 
+// GetLatexEngine retorna a engine LaTeX configurada
+func GetLatexEngine() string {
+	if engine := strings.TrimSpace(viper.GetString("latex_engine")); engine != "" {
+		return engine
+	}
+	return DefaultLatexEngine
+}
+
+// GetSourceDir retorna o diretório dos fontes LaTeX
+func GetSourceDir() string {
+	if dir := viper.GetString("source_dir"); dir != "" {
+		return dir
+	}
+	return DefaultSourceDir
+}
+
+// GetOutputDir retorna o diretório de saída das compilações
+func GetOutputDir() string {
+	if dir := viper.GetString("output_dir"); dir != "" {
+		return dir
+	}
+	return DefaultOutputDir
+}
+
+// GetAuxDir retorna o diretório dos arquivos auxiliares das compilações (.aux,
+// .log, .fls...). "{target}" é substituído pelo nome do documento principal
+// (.ltx/build/{target})
+func GetAuxDir(target string) string {
+	dir := viper.GetString("aux_dir")
+	if dir == "" {
+		dir = DefaultAuxDir
+	}
+	return strings.ReplaceAll(dir, "{target}", target)
+}
+
+// GetSynctexInOutput indica se o .synctex.gz acompanha o PDF no diretório de
+// saída (padrão) ou fica com os auxiliares
+func GetSynctexInOutput() bool {
+	return viper.GetBool("synctex_in_output")
+}
+
+// LatexImageConfigured indica se latex_image foi definida explicitamente (arquivo de
+// configuração ou variável de ambiente), e não apenas pelo valor padrão
+func LatexImageConfigured() bool {
+	return viper.InConfig("latex_image") || os.Getenv("LATEX_IMAGE") != ""
+}
+
+// GetContainerName retorna o container do projeto: o definido em container_name
+// ou, por padrão, um nome exclusivo derivado da raiz do projeto
+func GetContainerName() string {
+	if name := viper.GetString("container_name"); name != "" {
+		return name
+	}
+	return ProjectContainerName(ProjectRoot())
+}
+
+// GetContainerMode retorna o modo de execução das compilações
+func GetContainerMode() (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(viper.GetString("container_mode")))
+	switch mode {
+	case "", ContainerModePersistent:
+		return ContainerModePersistent, nil
+	case ContainerModeEphemeral:
+		return ContainerModeEphemeral, nil
+	default:
+		return "", fmt.Errorf("container_mode inválido: %q (use %s ou %s)", mode, ContainerModePersistent, ContainerModeEphemeral)
+	}
+}
+
+// Políticas de shell-escape das compilações (shell_escape)
+const (
+	ShellEscapeOff        = "off"        // nenhum comando externo
+	ShellEscapeRestricted = "restricted" // apenas os comandos da lista permitida
+	ShellEscapeOn         = "on"         // qualquer comando, com a rede desativada
+)
+
+// GetShellEscape retorna a política de shell-escape
+func GetShellEscape() (string, error) {
+	return ParseShellEscape(viper.GetString("shell_escape"))
+}
+
+// GetShellEscapeFor retorna a política de shell-escape de um documento: a de
+// shell_escape_targets para o nome do documento (slides=on), ou a global
+func GetShellEscapeFor(target string) (string, error) {
+	targets, err := GetShellEscapeTargets()
+	if err != nil {
+		return "", err
+	}
+	if mode, ok := targets[target]; ok {
+		return mode, nil
+	}
+	return GetShellEscape()
+}
+
+// GetShellEscapeTargets lê as políticas por documento de shell_escape_targets,
+// no formato "nome=política" (ex.: "slides=on apendice=off"), com o nome do
+// documento principal sem extensão, como o {target} de aux_dir
+func GetShellEscapeTargets() (map[string]string, error) {
+	targets := make(map[string]string)
+	for _, entry := range viper.GetStringSlice("shell_escape_targets") {
+		name, value, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("shell_escape_targets inválido: %q (use documento=política, ex.: slides=on)", entry)
+		}
+		mode, err := ParseShellEscape(value)
+		if err != nil {
+			return nil, fmt.Errorf("shell_escape_targets (%s): %w", name, err)
+		}
+		targets[name] = mode
+	}
+	return targets, nil
+}
+
+// ParseShellEscape valida uma política de shell-escape; vazio equivale a restricted
+func ParseShellEscape(value string) (string, error) {
+	mode := strings.ToLower(strings.TrimSpace(value))
+	switch mode {
+	case "", ShellEscapeRestricted:
+		return ShellEscapeRestricted, nil
+	case ShellEscapeOff, ShellEscapeOn:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("shell_escape inválido: %q (use %s, %s ou %s)", value, ShellEscapeOff, ShellEscapeRestricted, ShellEscapeOn)
+	}
+}
+
+// Backends da bibliografia (bibliography_backend)
+const (
+	BibBackendAuto   = "auto"   // detectado pelos fontes (biblatex/\addbibresource ou \bibliography)
+	BibBackendBibtex = "bibtex" // BibTeX
+	BibBackendBiber  = "biber"  // biber, com biblatex
+	BibBackendNone   = "none"   // não processar a bibliografia
+)
+
+// GetBibliographyBackend retorna o backend da bibliografia configurado
+func GetBibliographyBackend() (string, error) {
+	return ParseBibliographyBackend(viper.GetString("bibliography_backend"))
+}
+
+// ParseBibliographyBackend valida um backend de bibliografia; vazio equivale a auto
+func ParseBibliographyBackend(value string) (string, error) {
+	backend := strings.ToLower(strings.TrimSpace(value))
+	switch backend {
+	case "", BibBackendAuto:
+		return BibBackendAuto, nil
+	case BibBackendBibtex, BibBackendBiber, BibBackendNone:
+		return backend, nil
+	default:
+		return "", fmt.Errorf("bibliography_backend inválido: %q (use %s, %s, %s ou %s)", value, BibBackendAuto, BibBackendBibtex, BibBackendBiber, BibBackendNone)
+	}
+}
+
+// GetShellEscapeCommands retorna os comandos liberados, além dos padrões do
+// TeX Live, no modo restricted
+func GetShellEscapeCommands() []string {
+	return viper.GetStringSlice("shell_escape_commands")
+}
+
+// GetStartTimeout retorna quanto tempo aguardar o container ficar saudável
+func GetStartTimeout() time.Duration {
+	timeout := viper.GetDuration("start_timeout")
+	if timeout <= 0 {
+		return DefaultStartTimeout
+	}
+	return timeout
+}
+
+// GetBuildTimeout retorna o tempo máximo de cada compilação (0 = sem limite)
+func GetBuildTimeout() (time.Duration, error) {
+	value := strings.TrimSpace(viper.GetString("build_timeout"))
+	if value == "" || value == "0" {
+		return 0, nil
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		return 0, fmt.Errorf("build_timeout inválido: %q (use por exemplo 10m ou 0 para desativar)", value)
+	}
+	return timeout, nil
+}
+
+// ResourceLimits são os limites de recursos dos containers de compilação.
+// Valores zero significam sem limite.
+type ResourceLimits struct {
+	CPUs   float64 // núcleos, ex.: 1.5
+	Memory int64   // bytes
+	Pids   int64   // processos
+}
+
+// GetResourceLimits lê cpu_limit, memory_limit e pids_limit
+func GetResourceLimits() (ResourceLimits, error) {
+	var limits ResourceLimits
+
+	if value := strings.TrimSpace(viper.GetString("cpu_limit")); value != "" {
+		cpus, err := strconv.ParseFloat(value, 64)
+		if err != nil || cpus < 0 {
+			return limits, fmt.Errorf("cpu_limit inválido: %q (use o número de núcleos, ex.: 2 ou 0.5)", value)
+		}
+		limits.CPUs = cpus
+	}
+
+	if value := strings.TrimSpace(viper.GetString("memory_limit")); value != "" && value != "0" {
+		memory, err := units.RAMInBytes(value)
+		if err != nil || memory < 0 {
+			return limits, fmt.Errorf("memory_limit inválido: %q (use por exemplo 2g ou 512m)", value)
+		}
+		limits.Memory = memory
+	}
+
+	if value := strings.TrimSpace(viper.GetString("pids_limit")); value != "" {
+		pids, err := strconv.ParseInt(value, 10, 64)
+		if err != nil || pids < 0 {
+			return limits, fmt.Errorf("pids_limit inválido: %q (use um número inteiro de processos)", value)
+		}
+		limits.Pids = pids
+	}
+
+	return limits, nil
+}
+
+// Categorias de palavras que podem ser somadas para o limite (word_limit_counts)
+const (
+	WordsText      = "text"
+	WordsHeaders   = "headers"
+	WordsCaptions  = "captions"
+	WordsFootnotes = "footnotes"
+)
+
+// DefaultWordLimitCounts segue a soma padrão do texcount: texto, títulos e legendas
+var DefaultWordLimitCounts = []string{WordsText, WordsHeaders, WordsCaptions}
+
+// GetWordLimit retorna o limite de palavras do documento (0 = sem limite)
+func GetWordLimit() (int, error) {
+	value := strings.TrimSpace(viper.GetString("word_limit"))
+	if value == "" {
+		return 0, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 0 {
+		return 0, fmt.Errorf("word_limit inválido: %q (use um número inteiro de palavras ou 0 para desativar)", value)
+	}
+	return limit, nil
+}
+
+// GetWordLimitCounts retorna as categorias de palavras somadas para o limite
+func GetWordLimitCounts() ([]string, error) {
+	var counts []string
+	for _, value := range viper.GetStringSlice("word_limit_counts") {
+		for _, count := range strings.Split(value, ",") {
+			count = strings.ToLower(strings.TrimSpace(count))
+			switch count {
+			case "":
+				continue
+			case WordsText, WordsHeaders, WordsCaptions, WordsFootnotes:
+				counts = append(counts, count)
+			default:
+				return nil, fmt.Errorf("word_limit_counts inválido: %q (use %s, %s, %s ou %s)", count, WordsText, WordsHeaders, WordsCaptions, WordsFootnotes)
+			}
+		}
+	}
+
+	if len(counts) == 0 {
+		return DefaultWordLimitCounts, nil
+	}
+	return counts, nil
+}
+
+// GetLintDisabled retorna as regras desativadas em 'ltx lint' (separadas por
+// espaço ou vírgula), incluindo as do chktex no formato chktex/N
+func GetLintDisabled() []string {
+	var rules []string
+	for _, value := range viper.GetStringSlice("lint_disable") {
+		for _, rule := range strings.Split(value, ",") {
+			if rule = strings.TrimSpace(rule); rule != "" {
+				rules = append(rules, rule)
+			}
+		}
+	}
+	return rules
+}
+
+// GetLintChktex indica se 'ltx lint' também executa o chktex
+func GetLintChktex() bool {
+	return viper.GetBool("lint_chktex")
+}
+
+// GetLatexmkMaxRepeat retorna o número máximo de passadas do latexmk
+// ($max_repeat; 0 mantém o padrão do latexmk)
+func GetLatexmkMaxRepeat() (int, error) {
+	value := strings.TrimSpace(viper.GetString("latexmk_max_repeat"))
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("latexmk_max_repeat inválido: %q (use um número inteiro positivo)", value)
+	}
+	return n, nil
+}
+
+// GetLatexmkExtra retorna o código Perl acrescentado ao latexmkrc gerado
+func GetLatexmkExtra() string {
+	return viper.GetString("latexmk_extra")
+}
+
+// GetBibKeyPattern retorna o padrão das chaves geradas por 'ltx bib import'
+// (vazio usa o padrão {author}{year}{firstword})
+func GetBibKeyPattern() string {
+	return viper.GetString("bib_key_pattern")
+}
+
+// GetTexPackages retorna os pacotes do TeX Live a incorporar na imagem derivada
+func GetTexPackages() []string {
+	return viper.GetStringSlice("tex_packages")
+}
+
+// GetAptPackages retorna os pacotes do sistema (apt) a incorporar na imagem derivada
+func GetAptPackages() []string {
+	return viper.GetStringSlice("apt_packages")
+}
+
+// GetPipPackages retorna os pacotes Python (pip) a incorporar na imagem derivada
+func GetPipPackages() []string {
+	return viper.GetStringSlice("pip_packages")
+}
+
 func GetConfig() *types.Config {
 	return &types.Config{
-		LatexEngine:   viper.GetString("latex_engine"),
-		OutputDir:     viper.GetString("output_dir"),
-		SourceDir:     viper.GetString("source_dir"),
-		ContainerName: viper.GetString("container_name"),
-		ImageName:     viper.GetString("image_name"),
-		WatchDebounce: viper.GetString("watch_debounce"),
+		LatexEngine:         viper.GetString("latex_engine"),
+		OutputDir:           viper.GetString("output_dir"),
+		AuxDir:              viper.GetString("aux_dir"),
+		SynctexInOutput:     GetSynctexInOutput(),
+		SourceDir:           viper.GetString("source_dir"),
+		ContainerName:       GetContainerName(),
+		ImageName:           viper.GetString("image_name"),
+		WatchDebounce:       viper.GetString("watch_debounce"),
+		StartTimeout:        viper.GetString("start_timeout"),
+		ContainerMode:       viper.GetString("container_mode"),
+		BuildTimeout:        viper.GetString("build_timeout"),
+		ShellEscape:         viper.GetString("shell_escape"),
+		ShellEscapeCommands: GetShellEscapeCommands(),
+		ShellEscapeTargets:  viper.GetStringSlice("shell_escape_targets"),
+		BibliographyBackend: viper.GetString("bibliography_backend"),
+		CPULimit:            viper.GetString("cpu_limit"),
+		MemoryLimit:         viper.GetString("memory_limit"),
+		PidsLimit:           viper.GetString("pids_limit"),
+		WordLimit:           viper.GetString("word_limit"),
+		WordLimitCounts:     viper.GetStringSlice("word_limit_counts"),
+		LintDisable:         GetLintDisabled(),
+		LintChktex:          GetLintChktex(),
+		BibKeyPattern:       GetBibKeyPattern(),
+		LatexmkMaxRepeat:    viper.GetString("latexmk_max_repeat"),
+		LatexmkExtra:        GetLatexmkExtra(),
+		TexPackages:         GetTexPackages(),
+		AptPackages:         GetAptPackages(),
+		PipPackages:         GetPipPackages(),
 	}
 }
 
 func SetDefaults() {
-	viper.SetDefault("latex_engine", "xelatex")
+	viper.SetDefault("latex_engine", DefaultLatexEngine)
 	viper.SetDefault("output_dir", DefaultOutputDir)
+	viper.SetDefault("aux_dir", DefaultAuxDir)
+	viper.SetDefault("synctex_in_output", true)
 	viper.SetDefault("source_dir", DefaultSourceDir)
-	viper.SetDefault("container_name", "latex-env")
 	viper.SetDefault("latex_image", DefaultLatexImage)
 	viper.SetDefault("watch_debounce", "500ms")
+	viper.SetDefault("start_timeout", DefaultStartTimeout.String())
+	viper.SetDefault("container_mode", ContainerModePersistent)
+	viper.SetDefault("build_timeout", DefaultBuildTimeout.String())
+	viper.SetDefault("shell_escape", ShellEscapeRestricted)
+	viper.SetDefault("bibliography_backend", BibBackendAuto)
+	viper.SetDefault("lint_chktex", true)
 }
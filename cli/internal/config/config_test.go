@@ -1,7 +1,9 @@
 package config
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -50,12 +52,15 @@ func TestSetDefaults(t *testing.T) {
 		key      string
 		expected interface{}
 	}{
-		{"latex_engine", "xelatex"},
+		{"latex_engine", DefaultLatexEngine},
 		{"output_dir", DefaultOutputDir},
 		{"source_dir", DefaultSourceDir},
-		{"container_name", "latex-env"},
 		{"latex_image", DefaultLatexImage},
 		{"watch_debounce", "500ms"},
+		{"start_timeout", "1m0s"},
+		{"container_mode", ContainerModePersistent},
+		{"build_timeout", "10m0s"},
+		{"shell_escape", ShellEscapeRestricted},
 	}
 
 	for _, tt := range tests {
@@ -67,3 +72,263 @@ func TestSetDefaults(t *testing.T) {
 		})
 	}
 }
+
+func TestGetContainerMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		setValue string
+		expected string
+		wantErr  bool
+	}{
+		{name: "valor padrão", setValue: "", expected: ContainerModePersistent},
+		{name: "efêmero", setValue: "Ephemeral", expected: ContainerModeEphemeral},
+		{name: "valor inválido", setValue: "temporario", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			if tt.setValue != "" {
+				viper.Set("container_mode", tt.setValue)
+			}
+
+			result, err := GetContainerMode()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetContainerMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("GetContainerMode() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetBuildTimeout(t *testing.T) {
+	tests := []struct {
+		name     string
+		setValue string
+		expected time.Duration
+		wantErr  bool
+	}{
+		{name: "sem limite", setValue: "0", expected: 0},
+		{name: "minutos", setValue: "5m", expected: 5 * time.Minute},
+		{name: "valor inválido", setValue: "cinco minutos", wantErr: true},
+		{name: "negativo", setValue: "-1m", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("build_timeout", tt.setValue)
+
+			result, err := GetBuildTimeout()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetBuildTimeout() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("GetBuildTimeout() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetAuxDir(t *testing.T) {
+	tests := []struct {
+		name     string
+		setValue string
+		expected string
+	}{
+		{name: "padrão", setValue: "", expected: "tmp"},
+		{name: "por documento", setValue: ".ltx/build/{target}", expected: ".ltx/build/tese"},
+		{name: "fixo", setValue: "build", expected: "build"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("aux_dir", tt.setValue)
+
+			if result := GetAuxDir("tese"); result != tt.expected {
+				t.Errorf("GetAuxDir() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetLatexmkMaxRepeat(t *testing.T) {
+	tests := []struct {
+		name     string
+		setValue string
+		expected int
+		wantErr  bool
+	}{
+		{name: "padrão do latexmk", setValue: "", expected: 0},
+		{name: "valor definido", setValue: "8", expected: 8},
+		{name: "negativo", setValue: "-1", wantErr: true},
+		{name: "não numérico", setValue: "cinco", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("latexmk_max_repeat", tt.setValue)
+
+			result, err := GetLatexmkMaxRepeat()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetLatexmkMaxRepeat() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("GetLatexmkMaxRepeat() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetResourceLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		values   map[string]string
+		expected ResourceLimits
+		wantErr  bool
+	}{
+		{name: "sem limites", values: nil, expected: ResourceLimits{}},
+		{
+			name:     "todos os limites",
+			values:   map[string]string{"cpu_limit": "1.5", "memory_limit": "2g", "pids_limit": "256"},
+			expected: ResourceLimits{CPUs: 1.5, Memory: 2 << 30, Pids: 256},
+		},
+		{name: "memória inválida", values: map[string]string{"memory_limit": "muito"}, wantErr: true},
+		{name: "cpu negativa", values: map[string]string{"cpu_limit": "-1"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			for k, v := range tt.values {
+				viper.Set(k, v)
+			}
+
+			result, err := GetResourceLimits()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetResourceLimits() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("GetResourceLimits() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseShellEscape(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{value: "", expected: ShellEscapeRestricted},
+		{value: "OFF", expected: ShellEscapeOff},
+		{value: " on ", expected: ShellEscapeOn},
+		{value: "restricted", expected: ShellEscapeRestricted},
+		{value: "sim", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseShellEscape(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseShellEscape(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseShellEscape(%q) = %v, expected %v", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetShellEscapeFor(t *testing.T) {
+	tests := []struct {
+		name     string
+		global   string
+		targets  string
+		target   string
+		expected string
+		wantErr  bool
+	}{
+		{name: "sem políticas por documento", global: "off", target: "main", expected: ShellEscapeOff},
+		{name: "documento listado", global: "off", targets: "slides=on apendice=restricted", target: "slides", expected: ShellEscapeOn},
+		{name: "documento não listado", targets: "slides=on", target: "main", expected: ShellEscapeRestricted},
+		{name: "política inválida", targets: "slides=sim", target: "main", wantErr: true},
+		{name: "entrada sem documento", targets: "=on", target: "main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("shell_escape", tt.global)
+			viper.Set("shell_escape_targets", tt.targets)
+
+			result, err := GetShellEscapeFor(tt.target)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetShellEscapeFor(%q) error = %v, wantErr %v", tt.target, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("GetShellEscapeFor(%q) = %v, expected %v", tt.target, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseBibliographyBackend(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+		wantErr  bool
+	}{
+		{value: "", expected: BibBackendAuto},
+		{value: "Biber", expected: BibBackendBiber},
+		{value: " bibtex ", expected: BibBackendBibtex},
+		{value: "none", expected: BibBackendNone},
+		{value: "biblatex", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			result, err := ParseBibliographyBackend(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseBibliographyBackend(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if result != tt.expected {
+				t.Errorf("ParseBibliographyBackend(%q) = %v, expected %v", tt.value, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetWordLimitCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected []string
+		wantErr  bool
+	}{
+		{name: "padrão", value: "", expected: DefaultWordLimitCounts},
+		{name: "separado por espaços", value: "text footnotes", expected: []string{"text", "footnotes"}},
+		{name: "separado por vírgulas", value: "Text,headers", expected: []string{"text", "headers"}},
+		{name: "categoria inválida", value: "text tables", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("word_limit_counts", tt.value)
+
+			result, err := GetWordLimitCounts()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetWordLimitCounts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if strings.Join(result, " ") != strings.Join(tt.expected, " ") {
+				t.Errorf("GetWordLimitCounts() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// CacheVolume é o volume compartilhado com a árvore de usuário do TeX Live
+const CacheVolume = "ltx-texlive-cache"
+
+var unsafeNameChars = regexp.MustCompile(`[^a-z0-9_.-]+`)
+
+// ProjectRoot retorna a raiz do projeto (o diretório de trabalho da CLI), em
+// forma absoluta e sem links simbólicos para que o hash seja estável
+func ProjectRoot() string {
+	wd, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	if resolved, err := filepath.EvalSymlinks(wd); err == nil {
+		wd = resolved
+	}
+	return wd
+}
+
+// ProjectHash identifica o projeto pelo caminho da raiz
+func ProjectHash(root string) string {
+	sum := sha256.Sum256([]byte(root))
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// ProjectContainerName deriva um nome de container exclusivo do projeto, legível
+// pelo nome do diretório (ex: ltx-minha-tese-1a2b3c4d5e6f)
+func ProjectContainerName(root string) string {
+	base := strings.ToLower(filepath.Base(root))
+	base = strings.Trim(unsafeNameChars.ReplaceAllString(base, "-"), "-.")
+	if len(base) > 32 {
+		base = strings.TrimRight(base[:32], "-.")
+	}
+	if base == "" {
+		return "ltx-" + ProjectHash(root)
+	}
+	return "ltx-" + base + "-" + ProjectHash(root)
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestProjectContainerName(t *testing.T) {
+	tests := []struct {
+		name   string
+		root   string
+		prefix string
+	}{
+		{
+			name:   "nome simples",
+			root:   "/home/ana/tese",
+			prefix: "ltx-tese-",
+		},
+		{
+			name:   "caracteres inválidos",
+			root:   "/home/ana/Minha Tese (final)",
+			prefix: "ltx-minha-tese-final-",
+		},
+		{
+			name:   "raiz do sistema",
+			root:   "/",
+			prefix: "ltx-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ProjectContainerName(tt.root)
+			if !strings.HasPrefix(got, tt.prefix) {
+				t.Errorf("ProjectContainerName(%q) = %q, expected prefixo %q", tt.root, got, tt.prefix)
+			}
+			if !strings.HasSuffix(got, ProjectHash(tt.root)) {
+				t.Errorf("ProjectContainerName(%q) = %q, deveria terminar com o hash do projeto", tt.root, got)
+			}
+		})
+	}
+}
+
+func TestProjectContainerNameUnique(t *testing.T) {
+	a := ProjectContainerName("/home/ana/tese")
+	b := ProjectContainerName("/home/bruno/tese")
+
+	if a == b {
+		t.Errorf("projetos diferentes com o mesmo nome de diretório geraram o mesmo container: %q", a)
+	}
+}
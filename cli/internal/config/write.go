@@ -0,0 +1,62 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// DefaultConfigFile é o arquivo de configuração do projeto, no formato KEY="valor"
+var DefaultConfigFile = filepath.Join("config", "latex-cli.conf")
+
+// FilePath retorna o arquivo de configuração em uso (ou o padrão)
+func FilePath() string {
+	if used := viper.ConfigFileUsed(); used != "" {
+		return used
+	}
+	return DefaultConfigFile
+}
+
+// SetValue grava uma chave no arquivo de configuração do projeto e na configuração
+// em memória. Em arquivos .conf as demais linhas e comentários são preservados.
+func SetValue(key, value string) error {
+	path := FilePath()
+	viper.Set(key, value)
+
+	if filepath.Ext(path) != ".conf" {
+		return viper.WriteConfigAs(path)
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	updated := setConfLine(string(content), key, value)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, []byte(updated), 0644)
+}
+
+// setConfLine substitui (ou acrescenta) a linha KEY="valor" em um arquivo .conf
+func setConfLine(content, key, value string) string {
+	envKey := strings.ToUpper(key)
+	line := fmt.Sprintf("%s=%q", envKey, value)
+	pattern := regexp.MustCompile(`(?m)^[ \t]*` + regexp.QuoteMeta(envKey) + `[ \t]*=.*$`)
+
+	if pattern.MatchString(content) {
+		return pattern.ReplaceAllLiteralString(content, line)
+	}
+
+	if content != "" && !strings.HasSuffix(content, "\n") {
+		content += "\n"
+	}
+
+	return content + line + "\n"
+}
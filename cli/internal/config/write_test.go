@@ -0,0 +1,43 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestSetConfLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "substitui valor existente",
+			content:  "# Imagem\nLATEX_IMAGE=\"blang/latex:ubuntu\"\nOUTPUT_DIR=\"dist\"\n",
+			expected: "# Imagem\nLATEX_IMAGE=\"texlive/texlive:2024\"\nOUTPUT_DIR=\"dist\"\n",
+		},
+		{
+			name:     "acrescenta chave ausente",
+			content:  "OUTPUT_DIR=\"dist\"",
+			expected: "OUTPUT_DIR=\"dist\"\nLATEX_IMAGE=\"texlive/texlive:2024\"\n",
+		},
+		{
+			name:     "preserva linha comentada",
+			content:  "# LATEX_IMAGE=\"antiga\"\n",
+			expected: "# LATEX_IMAGE=\"antiga\"\nLATEX_IMAGE=\"texlive/texlive:2024\"\n",
+		},
+		{
+			name:     "arquivo vazio",
+			content:  "",
+			expected: "LATEX_IMAGE=\"texlive/texlive:2024\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := setConfLine(tt.content, "latex_image", "texlive/texlive:2024")
+			if got != tt.expected {
+				t.Errorf("setConfLine() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
 
 	"github.com/docker/docker/api/types/container"
 	"github.com/docker/docker/api/types/image"
@@ -46,6 +47,16 @@ func (c *Client) PullImage(ctx context.Context, imageName string) error {
 
 	fmt.Printf(">> Baixando imagem %s...\n", imageName)
 
+	if err := c.Pull(ctx, imageName, os.Stdout); err != nil {
+		return err
+	}
+
+	fmt.Printf("[OK] Imagem %s baixada com sucesso\n", imageName)
+	return nil
+}
+
+// Pull baixa (ou atualiza) a imagem, exibindo o progresso de cada camada em out
+func (c *Client) Pull(ctx context.Context, imageName string, out io.Writer) error {
 	reader, err := c.cli.ImagePull(ctx, imageName, image.PullOptions{})
 	if err != nil {
 		return err
@@ -56,12 +67,11 @@ func (c *Client) PullImage(ctx context.Context, imageName string) error {
 		}
 	}()
 
-	// Descartar o output para evitar travamento
-	if _, err := io.Copy(io.Discard, reader); err != nil {
-		return fmt.Errorf("erro ao processar pull output: %w", err)
+	// O stream precisa ser consumido até o fim para o pull concluir
+	if err := displayJSONMessages(reader, out); err != nil {
+		return fmt.Errorf("erro ao baixar imagem %s: %w", imageName, err)
 	}
 
-	fmt.Printf("[OK] Imagem %s baixada com sucesso\n", imageName)
 	return nil
 }
 
@@ -0,0 +1,186 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// RunOptions descreve um container de execução única
+type RunOptions struct {
+	Image      string
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	User       string
+	Binds      []string
+	Labels     map[string]string
+	Limits     Limits
+	NoNetwork  bool // executa sem rede (network_mode none)
+	Stdout     io.Writer
+	Stderr     io.Writer
+}
+
+// ErrOOMKilled indica que o container foi encerrado por esgotar o limite de memória
+var ErrOOMKilled = errors.New("container encerrado por falta de memória")
+
+// Limits restringe os recursos de um container (zero = sem limite)
+type Limits struct {
+	NanoCPUs  int64 // CPUs x 1e9
+	Memory    int64 // bytes
+	PidsLimit int64
+}
+
+func (l Limits) resources() container.Resources {
+	res := container.Resources{
+		NanoCPUs: l.NanoCPUs,
+		Memory:   l.Memory,
+	}
+	if l.PidsLimit > 0 {
+		pids := l.PidsLimit
+		res.PidsLimit = &pids
+	}
+	return res
+}
+
+// String descreve os limites de forma estável, para detectar mudanças
+func (l Limits) String() string {
+	return fmt.Sprintf("cpu=%d,mem=%d,pids=%d", l.NanoCPUs, l.Memory, l.PidsLimit)
+}
+
+// ContainerImage retorna a referência da imagem usada para criar o container
+func (c *Client) ContainerImage(ctx context.Context, containerName string) (string, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return "", err
+	}
+
+	if inspect.Config == nil {
+		return "", nil
+	}
+
+	return inspect.Config.Image, nil
+}
+
+func networkMode(disabled bool) container.NetworkMode {
+	if disabled {
+		return network.NetworkNone
+	}
+	return ""
+}
+
+// OOMKilled informa se o Docker registrou que o container esgotou o limite de
+// memória. O estado vale até o container ser reiniciado.
+func (c *Client) OOMKilled(ctx context.Context, containerName string) (bool, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return false, err
+	}
+
+	return inspect.State != nil && inspect.State.OOMKilled, nil
+}
+
+// ContainerLabels retorna os labels de um container
+func (c *Client) ContainerLabels(ctx context.Context, containerName string) (map[string]string, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	if inspect.Config == nil {
+		return nil, nil
+	}
+
+	return inspect.Config.Labels, nil
+}
+
+// Run cria um container, executa o comando até o fim, transmite sua saída e
+// remove o container. Retorna o código de saída do comando, com ErrOOMKilled se
+// o container esgotou o limite de memória.
+func (c *Client) Run(ctx context.Context, opts RunOptions) (int, error) {
+	created, err := c.cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:      opts.Image,
+			Cmd:        opts.Cmd,
+			Env:        opts.Env,
+			WorkingDir: opts.WorkingDir,
+			User:       opts.User,
+			Labels:     opts.Labels,
+		},
+		&container.HostConfig{
+			Binds:       opts.Binds,
+			Resources:   opts.Limits.resources(),
+			NetworkMode: networkMode(opts.NoNetwork),
+		},
+		nil, nil, "")
+	if err != nil {
+		return -1, fmt.Errorf("erro ao criar container: %w", err)
+	}
+
+	defer func() {
+		// Usar um contexto próprio para remover o container mesmo após cancelamento
+		if err := c.cli.ContainerRemove(context.Background(), created.ID, container.RemoveOptions{Force: true}); err != nil {
+			fmt.Printf("Erro ao remover container %s: %v\n", created.ID[:12], err)
+		}
+	}()
+
+	attach, err := c.cli.ContainerAttach(ctx, created.ID, container.AttachOptions{
+		Stream: true,
+		Stdout: true,
+		Stderr: true,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("erro ao anexar ao container: %w", err)
+	}
+	defer attach.Close()
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	copyDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(stdout, stderr, attach.Reader)
+		copyDone <- err
+	}()
+
+	waitCh, errCh := c.cli.ContainerWait(ctx, created.ID, container.WaitConditionNextExit)
+
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return -1, fmt.Errorf("erro ao iniciar container: %w", err)
+	}
+
+	var exitCode int
+	select {
+	case result := <-waitCh:
+		if result.Error != nil {
+			return -1, fmt.Errorf("erro ao aguardar container: %s", result.Error.Message)
+		}
+		exitCode = int(result.StatusCode)
+	case err := <-errCh:
+		return -1, fmt.Errorf("erro ao aguardar container: %w", err)
+	}
+
+	// Garantir que toda a saída foi transmitida antes de retornar
+	if err := <-copyDone; err != nil && err != io.EOF {
+		return exitCode, fmt.Errorf("erro ao ler saída do container: %w", err)
+	}
+
+	if exitCode != 0 {
+		if oom, err := c.OOMKilled(ctx, created.ID); err == nil && oom {
+			return exitCode, ErrOOMKilled
+		}
+	}
+
+	return exitCode, nil
+}
@@ -0,0 +1,154 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/client"
+)
+
+// Labels aplicados aos containers de ambiente criados pela CLI
+const (
+	LabelManaged     = "io.ltx.managed"
+	LabelProject     = "io.ltx.project"
+	LabelProjectHash = "io.ltx.project-hash"
+	LabelEphemeral   = "io.ltx.ephemeral"
+	LabelLimits      = "io.ltx.limits"
+)
+
+const (
+	// WorkspaceDir é onde a raiz do projeto é montada no container
+	WorkspaceDir = "/workspace"
+	// CacheDir guarda a árvore de usuário do TeX Live (volume persistente)
+	CacheDir = "/home/latexuser/.texlive"
+)
+
+// EnvOptions descreve o container de ambiente de um projeto
+type EnvOptions struct {
+	Name        string
+	Image       string
+	ProjectDir  string // montado em WorkspaceDir
+	CacheVolume string // montado em CacheDir
+	Env         []string
+	Labels      map[string]string
+	Limits      Limits
+}
+
+// EnvContainer resume um container de ambiente existente
+type EnvContainer struct {
+	ID      string
+	Name    string
+	Image   string
+	State   string
+	Status  string
+	Project string
+	Labels  map[string]string
+	Created time.Time
+}
+
+// CreateEnv cria e inicia o container de ambiente do projeto, que permanece em
+// execução para receber os comandos de compilação
+func (c *Client) CreateEnv(ctx context.Context, opts EnvOptions) error {
+	labels := map[string]string{LabelManaged: "true", LabelLimits: opts.Limits.String()}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+
+	created, err := c.cli.ContainerCreate(ctx,
+		&container.Config{
+			Image:      opts.Image,
+			Cmd:        []string{"tail", "-f", "/dev/null"},
+			Env:        opts.Env,
+			WorkingDir: WorkspaceDir,
+			Labels:     labels,
+			Healthcheck: &container.HealthConfig{
+				Test:          []string{"CMD", "pdflatex", "--version"},
+				Interval:      30 * time.Second,
+				Timeout:       5 * time.Second,
+				Retries:       3,
+				StartPeriod:   60 * time.Second,
+				StartInterval: time.Second,
+			},
+		},
+		&container.HostConfig{
+			Mounts: []mount.Mount{
+				{Type: mount.TypeBind, Source: opts.ProjectDir, Target: WorkspaceDir},
+				{Type: mount.TypeVolume, Source: opts.CacheVolume, Target: CacheDir},
+			},
+			Resources: opts.Limits.resources(),
+		},
+		nil, nil, opts.Name)
+	if err != nil {
+		return fmt.Errorf("erro ao criar container %s: %w", opts.Name, err)
+	}
+
+	if err := c.cli.ContainerStart(ctx, created.ID, container.StartOptions{}); err != nil {
+		return fmt.Errorf("erro ao iniciar container %s: %w", opts.Name, err)
+	}
+
+	return nil
+}
+
+// StartContainer inicia um container existente que esteja parado
+func (c *Client) StartContainer(ctx context.Context, name string) error {
+	return c.cli.ContainerStart(ctx, name, container.StartOptions{})
+}
+
+// StopContainer para um container
+func (c *Client) StopContainer(ctx context.Context, name string) error {
+	return c.cli.ContainerStop(ctx, name, container.StopOptions{})
+}
+
+// RemoveContainer remove um container, parando-o se necessário
+func (c *Client) RemoveContainer(ctx context.Context, name string) error {
+	return c.cli.ContainerRemove(ctx, name, container.RemoveOptions{Force: true})
+}
+
+// ContainerExists indica se existe um container (em qualquer estado) com o nome informado
+func (c *Client) ContainerExists(ctx context.Context, name string) (bool, error) {
+	_, err := c.cli.ContainerInspect(ctx, name)
+	if err == nil {
+		return true, nil
+	}
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// ListEnvs lista os containers de ambiente criados pela CLI em todo o host
+func (c *Client) ListEnvs(ctx context.Context) ([]EnvContainer, error) {
+	containers, err := c.cli.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", LabelManaged+"=true")),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	envs := make([]EnvContainer, 0, len(containers))
+	for _, ctr := range containers {
+		name := ctr.ID[:12]
+		if len(ctr.Names) > 0 {
+			name = strings.TrimPrefix(ctr.Names[0], "/")
+		}
+
+		envs = append(envs, EnvContainer{
+			ID:      ctr.ID,
+			Name:    name,
+			Image:   ctr.Image,
+			State:   string(ctr.State),
+			Status:  ctr.Status,
+			Project: ctr.Labels[LabelProject],
+			Labels:  ctr.Labels,
+			Created: time.Unix(ctr.Created, 0),
+		})
+	}
+
+	return envs, nil
+}
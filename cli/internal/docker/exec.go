@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// ExecOptions descreve um comando a ser executado dentro de um container
+type ExecOptions struct {
+	Cmd        []string
+	Env        []string
+	WorkingDir string
+	User       string
+	Stdout     io.Writer
+	Stderr     io.Writer
+}
+
+// Exec executa um comando em um container em execução e retorna o código de saída.
+// Um código diferente de zero não é tratado como erro; apenas falhas de
+// comunicação com o Docker retornam erro.
+func (c *Client) Exec(ctx context.Context, containerName string, opts ExecOptions) (int, error) {
+	created, err := c.cli.ContainerExecCreate(ctx, containerName, container.ExecOptions{
+		User:         opts.User,
+		AttachStdout: true,
+		AttachStderr: true,
+		Env:          opts.Env,
+		WorkingDir:   opts.WorkingDir,
+		Cmd:          opts.Cmd,
+	})
+	if err != nil {
+		return -1, fmt.Errorf("erro ao criar exec em %s: %w", containerName, err)
+	}
+
+	attach, err := c.cli.ContainerExecAttach(ctx, created.ID, container.ExecAttachOptions{})
+	if err != nil {
+		return -1, fmt.Errorf("erro ao anexar ao exec: %w", err)
+	}
+	defer attach.Close()
+
+	stdout := opts.Stdout
+	if stdout == nil {
+		stdout = io.Discard
+	}
+	stderr := opts.Stderr
+	if stderr == nil {
+		stderr = io.Discard
+	}
+
+	// Sem TTY o Docker multiplexa stdout/stderr no mesmo stream
+	copyDone := make(chan error, 1)
+	go func() {
+		_, err := stdcopy.StdCopy(stdout, stderr, attach.Reader)
+		copyDone <- err
+	}()
+
+	select {
+	case err := <-copyDone:
+		if err != nil {
+			return -1, fmt.Errorf("erro ao ler saída do exec: %w", err)
+		}
+	case <-ctx.Done():
+		// O processo continua no container; quem chama deve encerrá-lo
+		return -1, ctx.Err()
+	}
+
+	inspect, err := c.cli.ContainerExecInspect(ctx, created.ID)
+	if err != nil {
+		return -1, fmt.Errorf("erro ao inspecionar exec: %w", err)
+	}
+
+	return inspect.ExitCode, nil
+}
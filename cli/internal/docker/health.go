@@ -0,0 +1,96 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// HealthState resume o estado de execução e de saúde de um container
+type HealthState struct {
+	Status        string // estado do container (running, exited, ...)
+	Running       bool
+	ExitCode      int
+	Health        string // starting, healthy, unhealthy ou "" sem healthcheck
+	FailingStreak int
+	LastOutput    string // saída da última verificação do healthcheck
+}
+
+// Ready indica se o container pode receber comandos
+func (s *HealthState) Ready() bool {
+	return s.Running && (s.Health == "" || s.Health == container.Healthy)
+}
+
+// ContainerHealth consulta o estado atual de um container
+func (c *Client) ContainerHealth(ctx context.Context, containerName string) (*HealthState, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, err
+	}
+	return healthFromState(inspect.State), nil
+}
+
+// WaitHealthy consulta o container a cada interval até que ele esteja saudável
+// (ou apenas em execução, se não declarar healthcheck). onPoll, se informado, é
+// chamado a cada consulta. O prazo é dado pelo contexto; em caso de falha o
+// último estado é retornado junto com o erro.
+func (c *Client) WaitHealthy(ctx context.Context, containerName string, interval time.Duration, onPoll func(*HealthState)) (*HealthState, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *HealthState
+	for {
+		state, err := c.ContainerHealth(ctx, containerName)
+		if err != nil {
+			if ctx.Err() != nil {
+				return last, fmt.Errorf("tempo esgotado aguardando o container %s", containerName)
+			}
+			return last, err
+		}
+		last = state
+
+		if onPoll != nil {
+			onPoll(state)
+		}
+
+		switch {
+		case state.Ready():
+			return state, nil
+		case !state.Running:
+			return state, fmt.Errorf("container %s não está em execução (estado %s, código %d)", containerName, state.Status, state.ExitCode)
+		case state.Health == container.Unhealthy:
+			return state, fmt.Errorf("container %s não está saudável (%d verificações falharam)", containerName, state.FailingStreak)
+		}
+
+		select {
+		case <-ctx.Done():
+			return last, fmt.Errorf("tempo esgotado aguardando o container %s (health: %s)", containerName, last.Health)
+		case <-ticker.C:
+		}
+	}
+}
+
+func healthFromState(state *container.State) *HealthState {
+	if state == nil {
+		return &HealthState{}
+	}
+
+	hs := &HealthState{
+		Status:   string(state.Status),
+		Running:  state.Running,
+		ExitCode: state.ExitCode,
+	}
+
+	if state.Health != nil && state.Health.Status != container.NoHealthcheck {
+		hs.Health = string(state.Health.Status)
+		hs.FailingStreak = state.Health.FailingStreak
+		if n := len(state.Health.Log); n > 0 && state.Health.Log[n-1] != nil {
+			hs.LastOutput = strings.TrimSpace(state.Health.Log[n-1].Output)
+		}
+	}
+
+	return hs
+}
@@ -0,0 +1,80 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+func TestHealthFromState(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      *container.State
+		ready      bool
+		health     string
+		lastOutput string
+	}{
+		{
+			name:  "sem healthcheck em execução",
+			state: &container.State{Status: "running", Running: true},
+			ready: true,
+		},
+		{
+			name: "iniciando",
+			state: &container.State{Status: "running", Running: true, Health: &container.Health{
+				Status: container.Starting,
+			}},
+			ready:  false,
+			health: "starting",
+		},
+		{
+			name: "saudável",
+			state: &container.State{Status: "running", Running: true, Health: &container.Health{
+				Status: container.Healthy,
+				Log:    []*container.HealthcheckResult{{ExitCode: 0, Output: "pdfTeX 3.14\n"}},
+			}},
+			ready:      true,
+			health:     "healthy",
+			lastOutput: "pdfTeX 3.14",
+		},
+		{
+			name: "não saudável com saída da última verificação",
+			state: &container.State{Status: "running", Running: true, Health: &container.Health{
+				Status:        container.Unhealthy,
+				FailingStreak: 3,
+				Log: []*container.HealthcheckResult{
+					{ExitCode: 0, Output: "ok"},
+					{ExitCode: 1, Output: "pdflatex: not found\n"},
+				},
+			}},
+			ready:      false,
+			health:     "unhealthy",
+			lastOutput: "pdflatex: not found",
+		},
+		{
+			name:  "parado",
+			state: &container.State{Status: "exited", ExitCode: 1},
+			ready: false,
+		},
+		{
+			name:  "sem estado",
+			state: nil,
+			ready: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := healthFromState(tt.state)
+			if got.Ready() != tt.ready {
+				t.Errorf("Ready() = %v, expected %v", got.Ready(), tt.ready)
+			}
+			if got.Health != tt.health {
+				t.Errorf("Health = %q, expected %q", got.Health, tt.health)
+			}
+			if got.LastOutput != tt.lastOutput {
+				t.Errorf("LastOutput = %q, expected %q", got.LastOutput, tt.lastOutput)
+			}
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package docker
+
+import (
+	"archive/tar"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/docker/docker/api/types/build"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/client"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"github.com/moby/term"
+)
+
+// ImageInfo resume os dados de uma imagem local usados pela CLI
+type ImageInfo struct {
+	ID      string
+	Tags    []string
+	Digests []string
+	Size    int64
+	Created time.Time
+	User    string
+	Env     []string
+	Labels  map[string]string
+}
+
+// InspectImage retorna informações de uma imagem presente localmente
+func (c *Client) InspectImage(ctx context.Context, ref string) (*ImageInfo, error) {
+	inspect, err := c.cli.ImageInspect(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &ImageInfo{
+		ID:      inspect.ID,
+		Tags:    inspect.RepoTags,
+		Digests: inspect.RepoDigests,
+		Size:    inspect.Size,
+	}
+
+	if created, err := time.Parse(time.RFC3339Nano, inspect.Created); err == nil {
+		info.Created = created
+	}
+
+	if inspect.Config != nil {
+		info.User = inspect.Config.User
+		info.Env = inspect.Config.Env
+		info.Labels = inspect.Config.Labels
+	}
+
+	return info, nil
+}
+
+// ImageExists indica se a imagem está disponível localmente
+func (c *Client) ImageExists(ctx context.Context, ref string) (bool, error) {
+	_, err := c.cli.ImageInspect(ctx, ref)
+	if err == nil {
+		return true, nil
+	}
+	if client.IsErrNotFound(err) {
+		return false, nil
+	}
+	return false, err
+}
+
+// BuildImage constrói uma imagem a partir de um Dockerfile gerado em memória,
+// exibindo o progresso da construção em out
+func (c *Client) BuildImage(ctx context.Context, dockerfile []byte, tag string, labels map[string]string, out io.Writer) error {
+	buildContext, err := dockerfileContext(dockerfile)
+	if err != nil {
+		return fmt.Errorf("erro ao preparar contexto de build: %w", err)
+	}
+
+	resp, err := c.cli.ImageBuild(ctx, buildContext, build.ImageBuildOptions{
+		Tags:       []string{tag},
+		Labels:     labels,
+		Dockerfile: "Dockerfile",
+		Remove:     true,
+	})
+	if err != nil {
+		return fmt.Errorf("erro ao construir imagem %s: %w", tag, err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			fmt.Printf("Erro ao fechar resposta do build: %v\n", err)
+		}
+	}()
+
+	return displayJSONMessages(resp.Body, out)
+}
+
+// dockerfileContext empacota o Dockerfile em um tar, formato exigido pela API de build
+func dockerfileContext(dockerfile []byte) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	header := &tar.Header{
+		Name:    "Dockerfile",
+		Mode:    0644,
+		Size:    int64(len(dockerfile)),
+		ModTime: time.Now(),
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write(dockerfile); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+
+	return &buf, nil
+}
+
+// displayJSONMessages decodifica o stream de mensagens JSON da API do Docker
+// (build, pull, load) e o exibe em out, retornando o primeiro erro reportado
+func displayJSONMessages(in io.Reader, out io.Writer) error {
+	fd, isTerminal := uintptr(0), false
+	if f, ok := out.(*os.File); ok {
+		fd, isTerminal = term.GetFdInfo(f)
+	}
+
+	return jsonmessage.DisplayJSONMessagesStream(in, out, fd, isTerminal, nil)
+}
+
+// ImageSummary resume uma imagem da listagem local
+type ImageSummary struct {
+	ID      string
+	Tags    []string
+	Size    int64
+	Created time.Time
+}
+
+// ListImages lista as imagens locais que possuem tag
+func (c *Client) ListImages(ctx context.Context) ([]ImageSummary, error) {
+	images, err := c.cli.ImageList(ctx, image.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	var summaries []ImageSummary
+	for _, img := range images {
+		if len(img.RepoTags) == 0 {
+			continue
+		}
+		summaries = append(summaries, ImageSummary{
+			ID:      img.ID,
+			Tags:    img.RepoTags,
+			Size:    img.Size,
+			Created: time.Unix(img.Created, 0),
+		})
+	}
+
+	return summaries, nil
+}
+
+// RemoveImage remove uma imagem local pela referência
+func (c *Client) RemoveImage(ctx context.Context, ref string) error {
+	_, err := c.cli.ImageRemove(ctx, ref, image.RemoveOptions{PruneChildren: true})
+	return err
+}
+
+// PruneDanglingImages remove imagens sem tag e retorna o espaço liberado em bytes
+func (c *Client) PruneDanglingImages(ctx context.Context) (uint64, error) {
+	report, err := c.cli.ImagesPrune(ctx, filters.NewArgs(filters.Arg("dangling", "true")))
+	if err != nil {
+		return 0, err
+	}
+	return report.SpaceReclaimed, nil
+}
+
+// SaveImages exporta as imagens informadas (tags ou IDs) no formato de 'docker save'.
+// O chamador deve fechar o leitor retornado.
+func (c *Client) SaveImages(ctx context.Context, refs []string) (io.ReadCloser, error) {
+	return c.cli.ImageSave(ctx, refs)
+}
+
+// LoadImages importa imagens no formato de 'docker save', exibindo o progresso em out
+func (c *Client) LoadImages(ctx context.Context, in io.Reader, out io.Writer) error {
+	resp, err := c.cli.ImageLoad(ctx, in, client.ImageLoadWithQuiet(false))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return displayJSONMessages(resp.Body, out)
+}
@@ -0,0 +1,70 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/versions"
+)
+
+// MinAPIVersion é a menor versão da API do Docker suportada: o healthcheck dos
+// containers de ambiente usa start_interval, introduzido na API 1.44 (Docker 25)
+const MinAPIVersion = "1.44"
+
+// ServerInfo resume a versão do daemon Docker
+type ServerInfo struct {
+	Version          string
+	APIVersion       string
+	MinAPIVersion    string
+	ClientAPIVersion string // versão negociada pelo cliente
+	OS               string
+	Arch             string
+}
+
+// ServerInfo consulta a versão do daemon
+func (c *Client) ServerInfo(ctx context.Context) (*ServerInfo, error) {
+	v, err := c.cli.ServerVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ServerInfo{
+		Version:          v.Version,
+		APIVersion:       v.APIVersion,
+		MinAPIVersion:    v.MinAPIVersion,
+		ClientAPIVersion: c.cli.ClientVersion(),
+		OS:               v.Os,
+		Arch:             v.Arch,
+	}, nil
+}
+
+// APISupported indica se a versão da API atende a MinAPIVersion
+func APISupported(apiVersion string) bool {
+	return versions.GreaterThanOrEqualTo(apiVersion, MinAPIVersion)
+}
+
+// MountInfo descreve uma montagem de um container
+type MountInfo struct {
+	Type        string
+	Source      string
+	Name        string // nome do volume, quando Type é volume
+	Destination string
+}
+
+// ContainerMounts retorna as montagens de um container
+func (c *Client) ContainerMounts(ctx context.Context, containerName string) ([]MountInfo, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	mounts := make([]MountInfo, 0, len(inspect.Mounts))
+	for _, m := range inspect.Mounts {
+		mounts = append(mounts, MountInfo{
+			Type:        string(m.Type),
+			Source:      m.Source,
+			Name:        m.Name,
+			Destination: m.Destination,
+		})
+	}
+	return mounts, nil
+}
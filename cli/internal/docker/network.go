@@ -0,0 +1,67 @@
+package docker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/docker/docker/api/types/network"
+)
+
+// DefaultNetwork é a rede à qual os containers de ambiente são conectados
+const DefaultNetwork = "bridge"
+
+// IsolateNetwork desconecta o container de todas as redes. A função retornada
+// reconecta-o às mesmas redes e deve ser chamada ao final.
+func (c *Client) IsolateNetwork(ctx context.Context, containerName string) (func() error, error) {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return nil, err
+	}
+
+	var networks []string
+	if inspect.NetworkSettings != nil {
+		for name := range inspect.NetworkSettings.Networks {
+			networks = append(networks, name)
+		}
+	}
+
+	var disconnected []string
+	restore := func() error {
+		// Contexto próprio: a reconexão deve ocorrer mesmo após cancelamento
+		var firstErr error
+		for _, name := range disconnected {
+			if err := c.cli.NetworkConnect(context.Background(), name, containerName, &network.EndpointSettings{}); err != nil && firstErr == nil {
+				firstErr = fmt.Errorf("erro ao reconectar %s à rede %s: %w", containerName, name, err)
+			}
+		}
+		return firstErr
+	}
+
+	for _, name := range networks {
+		if err := c.cli.NetworkDisconnect(ctx, name, containerName, true); err != nil {
+			_ = restore()
+			return nil, fmt.Errorf("erro ao desconectar %s da rede %s: %w", containerName, name, err)
+		}
+		disconnected = append(disconnected, name)
+	}
+
+	return restore, nil
+}
+
+// EnsureNetwork reconecta à rede padrão um container que tenha ficado sem rede
+// (por exemplo, se o ltx foi interrompido durante uma compilação isolada)
+func (c *Client) EnsureNetwork(ctx context.Context, containerName string) error {
+	inspect, err := c.cli.ContainerInspect(ctx, containerName)
+	if err != nil {
+		return err
+	}
+
+	if inspect.NetworkSettings != nil && len(inspect.NetworkSettings.Networks) > 0 {
+		return nil
+	}
+	if inspect.HostConfig != nil && inspect.HostConfig.NetworkMode.IsNone() {
+		return nil
+	}
+
+	return c.cli.NetworkConnect(ctx, DefaultNetwork, containerName, &network.EndpointSettings{})
+}
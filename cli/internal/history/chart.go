@@ -0,0 +1,73 @@
+package history
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	units "github.com/docker/go-units"
+)
+
+// Metric é uma grandeza do histórico que pode ser exibida em gráfico
+type Metric struct {
+	Name   string
+	Label  string
+	Value  func(Record) float64
+	Format func(float64) string
+}
+
+// Metrics são as grandezas aceitas por 'ltx history --chart'
+var Metrics = []Metric{
+	{Name: "duration", Label: "Duração", Value: durationMS, Format: formatDuration},
+	{Name: "passes", Label: "Passadas", Value: func(r Record) float64 { return float64(r.Passes) }, Format: formatCount},
+	{Name: "warnings", Label: "Avisos", Value: func(r Record) float64 { return float64(r.Warnings) }, Format: formatCount},
+	{Name: "errors", Label: "Erros", Value: func(r Record) float64 { return float64(r.Errors) }, Format: formatCount},
+	{Name: "pages", Label: "Páginas", Value: func(r Record) float64 { return float64(r.Pages) }, Format: formatCount},
+	{Name: "size", Label: "Tamanho do PDF", Value: func(r Record) float64 { return float64(r.PDFSize) }, Format: units.HumanSize},
+}
+
+func durationMS(r Record) float64 { return float64(r.DurationMS) }
+
+func formatDuration(ms float64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(100 * time.Millisecond).String()
+}
+
+func formatCount(v float64) string { return fmt.Sprintf("%.0f", v) }
+
+// FindMetric retorna a grandeza pelo nome
+func FindMetric(name string) (Metric, error) {
+	var names []string
+	for _, m := range Metrics {
+		if m.Name == name {
+			return m, nil
+		}
+		names = append(names, m.Name)
+	}
+	return Metric{}, fmt.Errorf("gráfico inválido: %q (use %s)", name, strings.Join(names, ", "))
+}
+
+// barEighths são os blocos parciais usados no fim das barras
+var barEighths = []string{"", "▏", "▎", "▍", "▌", "▋", "▊", "▉"}
+
+// Bars desenha uma barra horizontal por valor, proporcional ao maior, com
+// até width caracteres. Valores positivos têm ao menos um traço visível.
+func Bars(values []float64, width int) []string {
+	max := 0.0
+	for _, v := range values {
+		max = math.Max(max, v)
+	}
+
+	bars := make([]string, len(values))
+	for i, v := range values {
+		if max <= 0 || v <= 0 {
+			continue
+		}
+		eighths := int(math.Round(v / max * float64(width*8)))
+		if eighths == 0 {
+			eighths = 1
+		}
+		bars[i] = strings.Repeat("█", eighths/8) + barEighths[eighths%8]
+	}
+	return bars
+}
@@ -0,0 +1,166 @@
+// Package history guarda o histórico das compilações do projeto (.ltx/history)
+// para acompanhar a evolução do tempo de build, dos avisos e do PDF.
+package history
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Dir é o diretório do histórico, dentro do estado do ltx no projeto
+var Dir = filepath.Join(".ltx", "history")
+
+// fileName é o arquivo de registros: um JSON por linha, só acrescentado
+const fileName = "builds.jsonl"
+
+// Origem da compilação
+const (
+	SourceBuild = "build"
+	SourceWatch = "watch"
+)
+
+// Resultado da compilação
+const (
+	StatusSuccess = "success"
+	StatusFailure = "failure"
+	StatusTimeout = "timeout" // interrompida por BUILD_TIMEOUT
+	StatusOOM     = "oom"     // encerrada ao esgotar MEMORY_LIMIT
+)
+
+// Record é uma compilação registrada
+type Record struct {
+	At         time.Time `json:"at"`
+	Source     string    `json:"source"` // build ou watch
+	Target     string    `json:"target"` // documento principal (src/main.tex)
+	Engine     string    `json:"engine"`
+	DurationMS int64     `json:"duration_ms"` // execução do latexmk
+	Passes     int       `json:"passes"`      // execuções da engine pelo latexmk
+	Status     string    `json:"status"`
+	ExitCode   int       `json:"exit_code"`
+	Warnings   int       `json:"warnings"`
+	Errors     int       `json:"errors"`
+	Pages      int       `json:"pages"`
+	PDFSize    int64     `json:"pdf_size"` // bytes
+}
+
+// Duration é a duração da compilação
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.DurationMS) * time.Millisecond
+}
+
+// Append acrescenta um registro ao histórico em dir
+func Append(dir string, r Record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, fileName), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Load lê os registros de dir, do mais antigo ao mais recente. Linhas
+// inválidas (por exemplo, de uma gravação interrompida) são ignoradas.
+func Load(dir string) ([]Record, error) {
+	f, err := os.Open(filepath.Join(dir, fileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var r Record
+		if err := json.Unmarshal(scanner.Bytes(), &r); err != nil {
+			continue
+		}
+		records = append(records, r)
+	}
+	return records, scanner.Err()
+}
+
+// Filter seleciona registros; campos vazios não filtram
+type Filter struct {
+	Target string // caminho ou nome do documento (main)
+	Engine string
+	Status string
+	Source string
+	Since  time.Time
+	Last   int // apenas os N mais recentes (0 = todos)
+}
+
+// Apply retorna os registros que atendem ao filtro, na ordem original
+func (f Filter) Apply(records []Record) []Record {
+	var selected []Record
+	for _, r := range records {
+		if f.Target != "" && r.Target != f.Target && targetName(r.Target) != f.Target {
+			continue
+		}
+		if f.Engine != "" && !strings.EqualFold(r.Engine, f.Engine) {
+			continue
+		}
+		if f.Status != "" && r.Status != f.Status {
+			continue
+		}
+		if f.Source != "" && r.Source != f.Source {
+			continue
+		}
+		if !f.Since.IsZero() && r.At.Before(f.Since) {
+			continue
+		}
+		selected = append(selected, r)
+	}
+
+	if f.Last > 0 && len(selected) > f.Last {
+		selected = selected[len(selected)-f.Last:]
+	}
+	return selected
+}
+
+func targetName(target string) string {
+	base := filepath.Base(filepath.FromSlash(target))
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// ParseSince interpreta o início de um período: uma data (2026-10-01), uma
+// duração (12h, 30m) ou um número de dias (7d), contados a partir de now
+func ParseSince(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if date, err := time.ParseInLocation("2006-01-02", value, now.Location()); err == nil {
+		return date, nil
+	}
+	if days, ok := strings.CutSuffix(value, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return now.AddDate(0, 0, -n), nil
+		}
+	}
+	if d, err := time.ParseDuration(value); err == nil && d >= 0 {
+		return now.Add(-d), nil
+	}
+
+	return time.Time{}, fmt.Errorf("período inválido: %q (use por exemplo 7d, 12h ou 2026-10-01)", value)
+}
@@ -0,0 +1,116 @@
+package history
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppendLoad(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "history")
+
+	records := []Record{
+		{At: time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC), Source: SourceBuild, Target: "src/main.tex", Engine: "pdflatex", DurationMS: 12500, Passes: 3, Status: StatusSuccess, Warnings: 2, Pages: 40, PDFSize: 123456},
+		{At: time.Date(2026, 10, 2, 12, 0, 0, 0, time.UTC), Source: SourceWatch, Target: "src/main.tex", Engine: "pdflatex", DurationMS: 3000, Passes: 1, Status: StatusFailure, ExitCode: 12, Errors: 1},
+	}
+	for _, r := range records {
+		if err := Append(dir, r); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Uma gravação interrompida não invalida o histórico
+	f, err := os.OpenFile(filepath.Join(dir, fileName), os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString(`{"at":"2026-10-03T`)
+	f.Close()
+
+	loaded, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded, records) {
+		t.Errorf("Load() = %+v, expected %+v", loaded, records)
+	}
+
+	if loaded, err := Load(t.TempDir()); err != nil || loaded != nil {
+		t.Errorf("Load() sem histórico = %v, %v; expected nil, nil", loaded, err)
+	}
+}
+
+func TestFilterApply(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	records := []Record{
+		{At: day(1), Target: "src/main.tex", Engine: "pdflatex", Status: StatusSuccess, Source: SourceBuild},
+		{At: day(2), Target: "src/main.tex", Engine: "lualatex", Status: StatusFailure, Source: SourceWatch},
+		{At: day(3), Target: "src/slides.tex", Engine: "pdflatex", Status: StatusSuccess, Source: SourceWatch},
+		{At: day(4), Target: "src/main.tex", Engine: "pdflatex", Status: StatusTimeout, Source: SourceBuild},
+	}
+
+	tests := []struct {
+		name     string
+		filter   Filter
+		expected []int // dias dos registros selecionados
+	}{
+		{name: "sem filtro", filter: Filter{}, expected: []int{1, 2, 3, 4}},
+		{name: "documento pelo nome", filter: Filter{Target: "main"}, expected: []int{1, 2, 4}},
+		{name: "documento pelo caminho", filter: Filter{Target: "src/slides.tex"}, expected: []int{3}},
+		{name: "engine", filter: Filter{Engine: "LuaLaTeX"}, expected: []int{2}},
+		{name: "status e origem", filter: Filter{Status: StatusSuccess, Source: SourceWatch}, expected: []int{3}},
+		{name: "desde", filter: Filter{Since: day(3)}, expected: []int{3, 4}},
+		{name: "últimos", filter: Filter{Engine: "pdflatex", Last: 2}, expected: []int{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var days []int
+			for _, r := range tt.filter.Apply(records) {
+				days = append(days, r.At.Day())
+			}
+			if !reflect.DeepEqual(days, tt.expected) {
+				t.Errorf("Apply() = %v, expected %v", days, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{value: "", expected: time.Time{}},
+		{value: "7d", expected: time.Date(2026, 10, 12, 15, 0, 0, 0, time.UTC)},
+		{value: "12h", expected: time.Date(2026, 10, 19, 3, 0, 0, 0, time.UTC)},
+		{value: "2026-10-01", expected: time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)},
+		{value: "-3d", wantErr: true},
+		{value: "ontem", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSince(tt.value, now)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSince(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("ParseSince(%q) = %v, expected %v", tt.value, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBars(t *testing.T) {
+	bars := Bars([]float64{10, 5, 0, 0.01}, 4)
+	expected := []string{"████", "██", "", "▏"}
+	if !reflect.DeepEqual(bars, expected) {
+		t.Errorf("Bars() = %q, expected %q", bars, expected)
+	}
+}
@@ -0,0 +1,324 @@
+package image
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+)
+
+// ManifestName é a primeira entrada do arquivo exportado; o restante é a saída
+// de 'docker save'
+const ManifestName = "ltx-image.yaml"
+
+// OriginFile registra, no projeto, de onde veio a imagem importada
+var OriginFile = filepath.Join(".ltx", "image-origin.yaml")
+
+// Manifest descreve a imagem contida em um arquivo exportado
+type Manifest struct {
+	Image      string     `yaml:"image"`             // referência usada nas compilações
+	ID         string     `yaml:"id"`                // ID local da imagem (sha256:...)
+	Base       string     `yaml:"base"`              // imagem base (igual a Image sem pacotes extras)
+	BaseID     string     `yaml:"base_id"`           // ID local da imagem base
+	Digest     string     `yaml:"digest,omitempty"`  // digest de registro da base (repo@sha256:...)
+	TexLive    string     `yaml:"texlive,omitempty"` // ano do TeX Live
+	Lock       *lock.Lock `yaml:"lock,omitempty"`    // ltx.lock do projeto exportado
+	Host       string     `yaml:"host,omitempty"`
+	ExportedAt time.Time  `yaml:"exported_at"`
+}
+
+// Origin registra uma importação feita a partir de um arquivo
+type Origin struct {
+	Archive    string    `yaml:"archive"`
+	ImportedAt time.Time `yaml:"imported_at"`
+	Manifest   Manifest  `yaml:"manifest"`
+}
+
+var (
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	gzipMagic = []byte{0x1f, 0x8b}
+)
+
+// Export grava em path as imagens refs e o manifesto. A compressão segue a
+// extensão: .zst (requer o binário zstd), .gz/.tgz ou sem compressão.
+func Export(ctx context.Context, client *docker.Client, refs []string, m *Manifest, path string) (err error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if cerr := f.Close(); err == nil {
+			err = cerr
+		}
+		if err != nil {
+			os.Remove(path)
+		}
+	}()
+
+	w, err := compressWriter(path, f)
+	if err != nil {
+		return err
+	}
+
+	saved, err := client.SaveImages(ctx, refs)
+	if err != nil {
+		w.Close()
+		return fmt.Errorf("erro ao exportar imagem: %w", err)
+	}
+	defer saved.Close()
+
+	if err := writeArchive(w, m, saved); err != nil {
+		w.Close()
+		return err
+	}
+
+	return w.Close()
+}
+
+// Import carrega no Docker as imagens de um arquivo exportado e retorna seu manifesto
+func Import(ctx context.Context, client *docker.Client, path string, out io.Writer) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := decompressReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	m, tr, err := readArchive(r)
+	if err != nil {
+		return nil, fmt.Errorf("%s não é um arquivo exportado por 'ltx image export': %w", path, err)
+	}
+
+	// Reempacota as entradas restantes (o 'docker save' original) para o Docker
+	pr, pw := io.Pipe()
+	go func() {
+		tw := tar.NewWriter(pw)
+		err := copyTar(tw, tr)
+		if err == nil {
+			err = tw.Close()
+		}
+		pw.CloseWithError(err)
+	}()
+
+	if err := client.LoadImages(ctx, pr, out); err != nil {
+		pr.CloseWithError(err)
+		return nil, fmt.Errorf("erro ao importar imagem: %w", err)
+	}
+
+	return m, nil
+}
+
+// ReadManifest lê apenas o manifesto de um arquivo exportado
+func ReadManifest(path string) (*Manifest, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r, err := decompressReader(f)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	m, _, err := readArchive(r)
+	return m, err
+}
+
+// writeArchive grava o manifesto seguido das entradas do 'docker save'
+func writeArchive(w io.Writer, m *Manifest, saved io.Reader) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(w)
+	hdr := &tar.Header{
+		Name:    ManifestName,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: m.ExportedAt,
+	}
+	if err := tw.WriteHeader(hdr); err != nil {
+		return err
+	}
+	if _, err := tw.Write(data); err != nil {
+		return err
+	}
+
+	if err := copyTar(tw, tar.NewReader(saved)); err != nil {
+		return fmt.Errorf("erro ao gravar imagem: %w", err)
+	}
+
+	return tw.Close()
+}
+
+// readArchive lê o manifesto e retorna o leitor posicionado nas entradas da imagem
+func readArchive(r io.Reader) (*Manifest, *tar.Reader, error) {
+	tr := tar.NewReader(r)
+
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	if hdr.Name != ManifestName {
+		return nil, nil, fmt.Errorf("manifesto %s ausente", ManifestName)
+	}
+
+	data, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var m Manifest
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, nil, fmt.Errorf("manifesto inválido: %w", err)
+	}
+
+	return &m, tr, nil
+}
+
+func copyTar(tw *tar.Writer, tr *tar.Reader) error {
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		if _, err := io.Copy(tw, tr); err != nil {
+			return err
+		}
+	}
+}
+
+// compressWriter escolhe a compressão pela extensão do arquivo de destino
+func compressWriter(path string, w io.Writer) (io.WriteCloser, error) {
+	name := strings.ToLower(path)
+
+	switch {
+	case strings.HasSuffix(name, ".zst") || strings.HasSuffix(name, ".tzst"):
+		cmd := exec.Command("zstd", "-q", "-c", "-T0")
+		cmd.Stdout = w
+		cmd.Stderr = os.Stderr
+		stdin, err := cmd.StdinPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("zstd não encontrado (instale-o ou use a extensão .tar.gz): %w", err)
+		}
+		return &cmdWriter{WriteCloser: stdin, cmd: cmd}, nil
+	case strings.HasSuffix(name, ".gz") || strings.HasSuffix(name, ".tgz"):
+		return gzip.NewWriter(w), nil
+	default:
+		return nopWriteCloser{w}, nil
+	}
+}
+
+// decompressReader detecta a compressão pelo conteúdo, não pela extensão
+func decompressReader(r io.Reader) (io.ReadCloser, error) {
+	br := bufio.NewReader(r)
+	head, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(head, zstdMagic):
+		cmd := exec.Command("zstd", "-q", "-d", "-c")
+		cmd.Stdin = br
+		cmd.Stderr = os.Stderr
+		stdout, err := cmd.StdoutPipe()
+		if err != nil {
+			return nil, err
+		}
+		if err := cmd.Start(); err != nil {
+			return nil, fmt.Errorf("zstd não encontrado (necessário para arquivos .zst): %w", err)
+		}
+		return &cmdReader{ReadCloser: stdout, cmd: cmd}, nil
+	case bytes.HasPrefix(head, gzipMagic):
+		return gzip.NewReader(br)
+	default:
+		return io.NopCloser(br), nil
+	}
+}
+
+type nopWriteCloser struct{ io.Writer }
+
+func (nopWriteCloser) Close() error { return nil }
+
+// cmdWriter encerra a entrada do processo e aguarda seu término no Close
+type cmdWriter struct {
+	io.WriteCloser
+	cmd *exec.Cmd
+}
+
+func (w *cmdWriter) Close() error {
+	if err := w.WriteCloser.Close(); err != nil {
+		return err
+	}
+	return w.cmd.Wait()
+}
+
+// cmdReader interrompe o processo no Close, mesmo que a saída não tenha sido lida toda
+type cmdReader struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (r *cmdReader) Close() error {
+	r.cmd.Process.Kill()
+	r.cmd.Wait()
+	return nil
+}
+
+// LoadOrigin lê a origem registrada da imagem. Retorna nil, sem erro, se não houver.
+func LoadOrigin(path string) (*Origin, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var o Origin
+	if err := yaml.Unmarshal(data, &o); err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	return &o, nil
+}
+
+// Save grava a origem da imagem, criando o diretório se necessário
+func (o *Origin) Save(path string) error {
+	data, err := yaml.Marshal(o)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
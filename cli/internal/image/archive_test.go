@@ -0,0 +1,101 @@
+package image
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+	"os/exec"
+	"testing"
+	"time"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/lock"
+)
+
+// fakeSave simula a saída de 'docker save' com uma única entrada
+func fakeSave(t *testing.T) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	content := []byte(`[{"Config":"config.json","RepoTags":["texlive/texlive:latest"]}]`)
+	if err := tw.WriteHeader(&tar.Header{Name: "manifest.json", Mode: 0644, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	tw.Write(content)
+	tw.Close()
+
+	return buf.Bytes()
+}
+
+func TestArchiveRoundTrip(t *testing.T) {
+	manifest := &Manifest{
+		Image:      "texlive/texlive:latest",
+		ID:         "sha256:abc",
+		TexLive:    "2024",
+		Lock:       &lock.Lock{Image: "texlive/texlive:latest", Digest: "texlive/texlive@sha256:abc"},
+		ExportedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name string
+		path string
+		tool string
+	}{
+		{name: "sem compressão", path: "imagem.tar"},
+		{name: "gzip", path: "imagem.tar.gz"},
+		{name: "zstd", path: "imagem.tar.zst", tool: "zstd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.tool != "" {
+				if _, err := exec.LookPath(tt.tool); err != nil {
+					t.Skipf("%s não disponível", tt.tool)
+				}
+			}
+
+			var archive bytes.Buffer
+			w, err := compressWriter(tt.path, &archive)
+			if err != nil {
+				t.Fatalf("compressWriter() error = %v", err)
+			}
+			if err := writeArchive(w, manifest, bytes.NewReader(fakeSave(t))); err != nil {
+				t.Fatalf("writeArchive() error = %v", err)
+			}
+			if err := w.Close(); err != nil {
+				t.Fatalf("Close() error = %v", err)
+			}
+
+			r, err := decompressReader(&archive)
+			if err != nil {
+				t.Fatalf("decompressReader() error = %v", err)
+			}
+			defer r.Close()
+
+			got, tr, err := readArchive(r)
+			if err != nil {
+				t.Fatalf("readArchive() error = %v", err)
+			}
+			if got.ID != manifest.ID || got.Lock == nil || got.Lock.Digest != manifest.Lock.Digest {
+				t.Errorf("readArchive() manifesto = %+v", got)
+			}
+
+			hdr, err := tr.Next()
+			if err != nil {
+				t.Fatalf("entrada da imagem ausente: %v", err)
+			}
+			if hdr.Name != "manifest.json" {
+				t.Errorf("entrada = %q, expected manifest.json", hdr.Name)
+			}
+			if _, err := tr.Next(); err != io.EOF {
+				t.Errorf("esperado fim do arquivo, obtido %v", err)
+			}
+		})
+	}
+}
+
+func TestReadArchiveWithoutManifest(t *testing.T) {
+	if _, _, err := readArchive(bytes.NewReader(fakeSave(t))); err == nil {
+		t.Error("readArchive() deveria rejeitar um 'docker save' sem manifesto do ltx")
+	}
+}
@@ -0,0 +1,59 @@
+package image
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/config"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+)
+
+// SpecFromConfig monta a especificação a partir da configuração efetiva do
+// projeto. Sem latex_image configurada, a base é a imagem padrão do ambiente.
+func SpecFromConfig() *Spec {
+	base := DefaultEnvImage
+	if config.LatexImageConfigured() {
+		base = config.GetLatexImage()
+	}
+
+	return &Spec{
+		Base:        base,
+		TexPackages: config.GetTexPackages(),
+		AptPackages: config.GetAptPackages(),
+		PipPackages: config.GetPipPackages(),
+	}
+}
+
+// Ensure garante que a imagem derivada da especificação exista localmente,
+// construindo-a apenas se a tag (hash do conteúdo) ainda não existir ou se force
+// for verdadeiro. Retorna a tag e se houve construção.
+func Ensure(ctx context.Context, client *docker.Client, spec *Spec, force bool, out io.Writer) (string, bool, error) {
+	tag := spec.Tag()
+
+	if !force {
+		exists, err := client.ImageExists(ctx, tag)
+		if err != nil {
+			return "", false, err
+		}
+		if exists {
+			return tag, false, nil
+		}
+	}
+
+	// A imagem base precisa estar disponível para descobrir seu usuário padrão
+	if err := client.PullImage(ctx, spec.Base); err != nil {
+		return "", false, fmt.Errorf("erro ao obter imagem base %s: %w", spec.Base, err)
+	}
+
+	base, err := client.InspectImage(ctx, spec.Base)
+	if err != nil {
+		return "", false, fmt.Errorf("erro ao inspecionar imagem base %s: %w", spec.Base, err)
+	}
+
+	if err := client.BuildImage(ctx, spec.Dockerfile(base.User), tag, spec.Labels(), out); err != nil {
+		return "", false, err
+	}
+
+	return tag, true, nil
+}
@@ -0,0 +1,37 @@
+package image
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestSpecFromConfigBase(t *testing.T) {
+	tests := []struct {
+		name       string
+		latexImage string
+		expected   string
+	}{
+		{name: "sem latex_image configurada", expected: DefaultEnvImage},
+		{name: "latex_image configurada", latexImage: "texlive/texlive:latest", expected: "texlive/texlive:latest"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			viper.Reset()
+			viper.Set("tex_packages", "enumitem")
+			t.Setenv("LATEX_IMAGE", tt.latexImage)
+			if tt.latexImage != "" {
+				viper.Set("latex_image", tt.latexImage)
+			}
+
+			spec := SpecFromConfig()
+			if spec.Base != tt.expected {
+				t.Errorf("SpecFromConfig().Base = %q, expected %q", spec.Base, tt.expected)
+			}
+			if spec.Empty() {
+				t.Error("SpecFromConfig() deveria incluir os pacotes de tex_packages")
+			}
+		})
+	}
+}
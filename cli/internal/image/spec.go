@@ -0,0 +1,128 @@
+package image
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+const (
+	// DerivedRepository é o repositório local das imagens derivadas geradas pelo ltx
+	DerivedRepository = "ltx-local/latex"
+
+	// SpecLabel guarda o hash da especificação na imagem derivada
+	SpecLabel = "io.ltx.spec-hash"
+
+	// BaseLabel guarda a imagem base usada na derivação
+	BaseLabel = "io.ltx.base-image"
+
+	// DefaultEnvImage é a tag da imagem padrão, construída a partir do Dockerfile
+	// do devcontainer quando nenhuma imagem foi configurada
+	DefaultEnvImage = "latex-docker-env:latest"
+)
+
+// Spec descreve os extras que devem ser incorporados sobre a imagem base
+type Spec struct {
+	Base        string
+	TexPackages []string
+	AptPackages []string
+	PipPackages []string
+}
+
+// Empty indica que não há extras configurados e a imagem base pode ser usada diretamente
+func (s *Spec) Empty() bool {
+	return len(s.TexPackages) == 0 && len(s.AptPackages) == 0 && len(s.PipPackages) == 0
+}
+
+// Hash identifica o conteúdo da especificação, independente da ordem dos pacotes
+func (s *Spec) Hash() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "base=%s\n", s.Base)
+	fmt.Fprintf(&b, "tex=%s\n", strings.Join(normalize(s.TexPackages), " "))
+	fmt.Fprintf(&b, "apt=%s\n", strings.Join(normalize(s.AptPackages), " "))
+	fmt.Fprintf(&b, "pip=%s\n", strings.Join(normalize(s.PipPackages), " "))
+
+	sum := sha256.Sum256([]byte(b.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// Tag retorna a tag da imagem derivada, baseada no hash da especificação
+func (s *Spec) Tag() string {
+	return DerivedRepository + ":" + s.Hash()[:12]
+}
+
+// Labels retorna os labels gravados na imagem derivada
+func (s *Spec) Labels() map[string]string {
+	return map[string]string{
+		SpecLabel: s.Hash(),
+		BaseLabel: s.Base,
+	}
+}
+
+var dockerfileTemplate = template.Must(template.New("Dockerfile").Parse(`# Gerado por 'ltx image build' - não edite manualmente.
+# Especificação: {{.Hash}}
+FROM {{.Base}}
+
+USER root
+{{- if .Apt}}
+
+RUN apt-get update && \
+    apt-get install -y --no-install-recommends {{.Apt}} && \
+    apt-get clean -y && \
+    rm -rf /var/lib/apt/lists/*
+{{- end}}
+{{- if .Tex}}
+
+RUN tlmgr install {{.Tex}} || \
+    (tlmgr update --self && tlmgr install {{.Tex}})
+{{- end}}
+{{- if .Pip}}
+
+RUN pip3 install --no-cache-dir {{.Pip}} || \
+    pip3 install --no-cache-dir --break-system-packages {{.Pip}}
+{{- end}}
+{{- if .User}}
+
+USER {{.User}}
+{{- end}}
+`))
+
+// Dockerfile gera o Dockerfile da imagem derivada. user é o usuário padrão da
+// imagem base, restaurado ao final para não alterar o comportamento do container.
+func (s *Spec) Dockerfile(user string) []byte {
+	data := struct {
+		Hash, Base, Apt, Tex, Pip, User string
+	}{
+		Hash: s.Hash(),
+		Base: s.Base,
+		Apt:  strings.Join(normalize(s.AptPackages), " "),
+		Tex:  strings.Join(normalize(s.TexPackages), " "),
+		Pip:  strings.Join(normalize(s.PipPackages), " "),
+		User: user,
+	}
+
+	var buf bytes.Buffer
+	// O template é fixo e os dados são strings; a execução não falha
+	_ = dockerfileTemplate.Execute(&buf, data)
+	return buf.Bytes()
+}
+
+// normalize ordena e remove entradas vazias ou duplicadas
+func normalize(items []string) []string {
+	seen := make(map[string]bool)
+	var result []string
+	for _, item := range items {
+		item = strings.TrimSpace(item)
+		if item == "" || seen[item] {
+			continue
+		}
+		seen[item] = true
+		result = append(result, item)
+	}
+	sort.Strings(result)
+	return result
+}
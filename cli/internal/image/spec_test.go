@@ -0,0 +1,72 @@
+package image
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpecEmpty(t *testing.T) {
+	spec := &Spec{Base: "texlive/texlive:latest"}
+	if !spec.Empty() {
+		t.Error("Spec sem pacotes deveria ser vazia")
+	}
+
+	spec.PipPackages = []string{"pygments"}
+	if spec.Empty() {
+		t.Error("Spec com pacotes pip não deveria ser vazia")
+	}
+}
+
+func TestSpecHash(t *testing.T) {
+	a := &Spec{Base: "texlive/texlive:latest", TexPackages: []string{"enumitem", "fancyhdr"}}
+	b := &Spec{Base: "texlive/texlive:latest", TexPackages: []string{"fancyhdr", "enumitem", "enumitem", " "}}
+
+	if a.Hash() != b.Hash() {
+		t.Error("Hash() deveria ignorar ordem, duplicatas e entradas vazias")
+	}
+
+	c := &Spec{Base: "texlive/texlive:2024", TexPackages: []string{"enumitem", "fancyhdr"}}
+	if a.Hash() == c.Hash() {
+		t.Error("Hash() deveria mudar quando a imagem base muda")
+	}
+
+	d := &Spec{Base: "texlive/texlive:latest", AptPackages: []string{"enumitem", "fancyhdr"}}
+	if a.Hash() == d.Hash() {
+		t.Error("Hash() deveria distinguir pacotes TeX de pacotes apt")
+	}
+
+	if !strings.HasPrefix(a.Tag(), DerivedRepository+":") || len(a.Tag()) != len(DerivedRepository)+13 {
+		t.Errorf("Tag() = %s, formato inesperado", a.Tag())
+	}
+}
+
+func TestSpecDockerfile(t *testing.T) {
+	spec := &Spec{
+		Base:        "texlive/texlive:latest",
+		TexPackages: []string{"fancyhdr", "enumitem"},
+		AptPackages: []string{"inkscape"},
+	}
+
+	dockerfile := string(spec.Dockerfile("latexuser"))
+
+	expected := []string{
+		"FROM texlive/texlive:latest",
+		"USER root",
+		"apt-get install -y --no-install-recommends inkscape",
+		"tlmgr install enumitem fancyhdr",
+		"USER latexuser",
+	}
+	for _, want := range expected {
+		if !strings.Contains(dockerfile, want) {
+			t.Errorf("Dockerfile não contém %q:\n%s", want, dockerfile)
+		}
+	}
+
+	if strings.Contains(dockerfile, "pip3") {
+		t.Errorf("Dockerfile não deveria instalar pacotes pip:\n%s", dockerfile)
+	}
+
+	if strings.Contains(string(spec.Dockerfile("")), "\nUSER \n") {
+		t.Error("Dockerfile não deveria restaurar usuário vazio")
+	}
+}
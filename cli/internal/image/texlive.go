@@ -0,0 +1,65 @@
+package image
+
+import (
+	"bytes"
+	"context"
+	"regexp"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+)
+
+var (
+	// PATH=/usr/local/texlive/2024/bin/x86_64-linux:...
+	texlivePathPattern = regexp.MustCompile(`/texlive/(\d{4})/`)
+
+	// TeX 3.141592653 (TeX Live 2023/Debian)
+	texVersionPattern = regexp.MustCompile(`TeX Live (\d{4})`)
+)
+
+// TexLiveYearFromEnv tenta descobrir o ano do TeX Live pelas variáveis de
+// ambiente da imagem, sem precisar executá-la
+func TexLiveYearFromEnv(env []string) string {
+	for _, entry := range env {
+		if !strings.HasPrefix(entry, "PATH=") {
+			continue
+		}
+		if m := texlivePathPattern.FindStringSubmatch(entry); m != nil {
+			return m[1]
+		}
+	}
+	return ""
+}
+
+// parseTexVersion extrai o ano da saída de 'tex --version'
+func parseTexVersion(output string) string {
+	if m := texVersionPattern.FindStringSubmatch(output); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// TexLiveYear descobre o ano do TeX Live de uma imagem local. Se o ambiente da
+// imagem não indicar o ano, executa 'tex --version' em um container temporário.
+func TexLiveYear(ctx context.Context, client *docker.Client, ref string) (string, error) {
+	info, err := client.InspectImage(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if year := TexLiveYearFromEnv(info.Env); year != "" {
+		return year, nil
+	}
+
+	var out bytes.Buffer
+	if _, err := client.Run(ctx, docker.RunOptions{
+		Image:  ref,
+		Cmd:    []string{"tex", "--version"},
+		Stdout: &out,
+		Stderr: &out,
+	}); err != nil {
+		return "", err
+	}
+
+	return parseTexVersion(out.String()), nil
+}
@@ -0,0 +1,56 @@
+package image
+
+import (
+	"testing"
+)
+
+func TestTexLiveYearFromEnv(t *testing.T) {
+	tests := []struct {
+		name     string
+		env      []string
+		expected string
+	}{
+		{
+			name:     "imagem texlive/texlive",
+			env:      []string{"LANG=C.UTF-8", "PATH=/usr/local/texlive/2024/bin/x86_64-linux:/usr/local/bin:/usr/bin"},
+			expected: "2024",
+		},
+		{
+			name:     "TeX Live da distribuição",
+			env:      []string{"PATH=/usr/local/sbin:/usr/local/bin:/usr/sbin:/usr/bin"},
+			expected: "",
+		},
+		{
+			name:     "sem ambiente",
+			env:      nil,
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TexLiveYearFromEnv(tt.env); got != tt.expected {
+				t.Errorf("TexLiveYearFromEnv() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseTexVersion(t *testing.T) {
+	tests := []struct {
+		output   string
+		expected string
+	}{
+		{"TeX 3.141592653 (TeX Live 2023/Debian)\nkpathsea version 6.3.5", "2023"},
+		{"TeX 3.141592653 (TeX Live 2024)\n", "2024"},
+		{"sh: 1: tex: not found", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if got := parseTexVersion(tt.output); got != tt.expected {
+				t.Errorf("parseTexVersion() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package latex
+
+import (
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+)
+
+// BibEntryTypes conta as entradas de um arquivo .bib por tipo
+func BibEntryTypes(path string) (map[string]int, error) {
+	db, err := bibtex.ParseFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	types := make(map[string]int)
+	for _, entry := range db.Entries {
+		types[entry.Type]++
+	}
+	return types, nil
+}
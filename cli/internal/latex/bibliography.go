@@ -0,0 +1,69 @@
+package latex
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Programas que processam a bibliografia
+const (
+	BackendBibtex = "bibtex"
+	BackendBiber  = "biber"
+)
+
+// Bibliography descreve como o documento declara a bibliografia
+type Bibliography struct {
+	Backend  string `json:"backend"`            // bibtex, biber ou "" sem bibliografia
+	Biblatex bool   `json:"biblatex"`           // usa o pacote biblatex
+	Evidence string `json:"evidence,omitempty"` // comando que determinou o backend (arquivo:linha)
+}
+
+// Bibliography detecta o backend da bibliografia: biblatex usa o biber (ou o
+// BibTeX com backend=bibtex) e \addbibresource implica biblatex; sem biblatex,
+// \bibliography usa o BibTeX
+func (p *Project) Bibliography() Bibliography {
+	var bib Bibliography
+	var legacy *Command
+
+	for _, cmd := range p.Commands() {
+		switch cmd.Name {
+		case "usepackage", "RequirePackage":
+			if !containsString(splitList(cmd.Arg(0)), "biblatex") {
+				continue
+			}
+			bib.Biblatex = true
+			bib.Backend = BackendBiber
+			bib.Evidence = evidence(cmd, `\usepackage{biblatex}`)
+			if len(cmd.Optional) > 0 {
+				for _, option := range splitList(cmd.Optional[0]) {
+					name, value, _ := strings.Cut(option, "=")
+					if strings.TrimSpace(name) == "backend" && strings.HasPrefix(strings.TrimSpace(value), "bibtex") {
+						bib.Backend = BackendBibtex // bibtex ou bibtex8
+						bib.Evidence = evidence(cmd, `\usepackage[backend=bibtex]{biblatex}`)
+					}
+				}
+			}
+			return bib
+
+		case "addbibresource":
+			if bib.Backend == "" {
+				bib = Bibliography{Backend: BackendBiber, Biblatex: true, Evidence: evidence(cmd, `\addbibresource`)}
+			}
+
+		case "bibliography":
+			if legacy == nil {
+				c := cmd
+				legacy = &c
+			}
+		}
+	}
+
+	if bib.Backend == "" && legacy != nil {
+		bib = Bibliography{Backend: BackendBibtex, Evidence: evidence(*legacy, `\bibliography`)}
+	}
+	return bib
+}
+
+func evidence(cmd Command, description string) string {
+	return fmt.Sprintf("%s em %s:%d", description, cmd.File, cmd.Line)
+}
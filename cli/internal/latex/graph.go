@@ -0,0 +1,119 @@
+package latex
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Node é um arquivo na árvore de inclusões do projeto
+type Node struct {
+	Path     string  `json:"path"` // arquivo encontrado ou, se ausente, o alvo como escrito
+	Kind     string  `json:"kind"`
+	Command  string  `json:"command,omitempty"`
+	Line     int     `json:"line,omitempty"`
+	Missing  bool    `json:"missing,omitempty"`
+	Repeated bool    `json:"repeated,omitempty"` // já aparece antes na árvore
+	Children []*Node `json:"children,omitempty"`
+}
+
+// Tree monta a árvore de inclusões a partir do arquivo principal. Arquivos
+// incluídos mais de uma vez (ou em ciclo) aparecem expandidos apenas na
+// primeira ocorrência.
+func (p *Project) Tree() *Node {
+	files := make(map[string]*File, len(p.Files))
+	for _, f := range p.Files {
+		files[filepath.Clean(f.Path)] = f
+	}
+
+	root := &Node{Path: p.Main, Kind: KindTeX}
+	visited := map[string]bool{filepath.Clean(p.Main): true}
+
+	var walk func(node *Node, file *File)
+	walk = func(node *Node, file *File) {
+		for _, ref := range file.Refs {
+			child := &Node{Path: ref.Path, Kind: ref.Kind, Command: ref.Command, Line: ref.Line}
+			node.Children = append(node.Children, child)
+
+			if ref.Path == "" {
+				child.Path = ref.Target
+				child.Missing = true
+				continue
+			}
+			if ref.Kind != KindTeX {
+				continue
+			}
+
+			key := filepath.Clean(ref.Path)
+			if visited[key] {
+				child.Repeated = true
+				continue
+			}
+			visited[key] = true
+			if f := files[key]; f != nil {
+				walk(child, f)
+			}
+		}
+	}
+
+	if main := files[filepath.Clean(p.Main)]; main != nil {
+		walk(root, main)
+	}
+	return root
+}
+
+// MissingReferences retorna as referências a arquivos que não existem
+func (p *Project) MissingReferences() []Reference {
+	var missing []Reference
+	for _, ref := range p.References() {
+		if ref.Path == "" {
+			missing = append(missing, ref)
+		}
+	}
+	return missing
+}
+
+// orphanExts são os tipos de arquivo verificados por Orphans
+var orphanExts = append(append(append([]string{}, texExts...), bibExts...), append(graphicExts, ".svg")...)
+
+// Orphans lista os arquivos .tex, .bib e imagens sob dir que não são
+// referenciados pelo documento. Diretórios ocultos são ignorados.
+func (p *Project) Orphans(dir string) ([]string, error) {
+	referenced := map[string]bool{absPath(p.Main): true}
+	for _, ref := range p.References() {
+		if ref.Path != "" {
+			referenced[absPath(ref.Path)] = true
+		}
+	}
+
+	var orphans []string
+	err := filepath.WalkDir(dir, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if containsString(orphanExts, strings.ToLower(filepath.Ext(path))) && !referenced[absPath(path)] {
+			orphans = append(orphans, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Strings(orphans)
+	return orphans, nil
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return filepath.Clean(path)
+}
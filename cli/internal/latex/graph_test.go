@@ -0,0 +1,64 @@
+package latex
+
+import (
+	"path/filepath"
+	"testing"
+)
+
+func TestProjectGraph(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tex":            "\\graphicspath{{images/}}\n\\begin{document}\n\\input{chapters/cap1}\n\\include{chapters/cap2}\n\\includegraphics[width=3cm]{logo}\n\\bibliography{refs,ausente}\n\\end{document}\n",
+		"chapters/cap1.tex":   "\\includegraphics{figs/grafico}\n\\input{chapters/cap2}\n",
+		"chapters/cap2.tex":   "\\input{chapters/cap1}\n\\includegraphics{inexistente}\n",
+		"chapters/antigo.tex": "\\chapter{Antigo}\n",
+		"images/logo.png":     "png",
+		"figs/grafico.pdf":    "pdf",
+		"images/sobra.jpg":    "jpg",
+		"refs.bib":            "@misc{a,}\n",
+		"estilo.sty":          "% não é verificado\n",
+	})
+
+	p, err := Load(filepath.Join(dir, "main.tex"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	tree := p.Tree()
+	if len(tree.Children) != 5 {
+		t.Fatalf("raiz com %d filhos, expected 5: %+v", len(tree.Children), tree.Children)
+	}
+
+	cap1 := tree.Children[0]
+	if cap1.Kind != KindTeX || cap1.Command != "input" || len(cap1.Children) != 2 {
+		t.Fatalf("cap1 = %+v", cap1)
+	}
+	cap2 := cap1.Children[1]
+	if cap2.Repeated || len(cap2.Children) != 2 || !cap2.Children[0].Repeated {
+		t.Errorf("cap2 = %+v, expected expandido com cap1 repetido", cap2)
+	}
+	if !tree.Children[1].Repeated {
+		t.Errorf("segunda inclusão de cap2 = %+v, expected repeated", tree.Children[1])
+	}
+	if logo := tree.Children[2]; logo.Kind != KindGraphic || filepath.Base(logo.Path) != "logo.png" {
+		t.Errorf("logo = %+v, expected images/logo.png via graphicspath", logo)
+	}
+
+	missing := p.MissingReferences()
+	if len(missing) != 2 || missing[0].Target != "ausente" || missing[1].Target != "inexistente" {
+		t.Errorf("MissingReferences() = %+v, expected ausente e inexistente", missing)
+	}
+
+	orphans, err := p.Orphans(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, o := range orphans {
+		rel, _ := filepath.Rel(dir, o)
+		names = append(names, filepath.ToSlash(rel))
+	}
+	if len(names) != 2 || names[0] != "chapters/antigo.tex" || names[1] != "images/sobra.jpg" {
+		t.Errorf("Orphans() = %v, expected [chapters/antigo.tex images/sobra.jpg]", names)
+	}
+}
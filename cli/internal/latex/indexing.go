@@ -0,0 +1,204 @@
+package latex
+
+import (
+	"strings"
+)
+
+// GlossaryType é um glossário declarado com \newglossary ou por uma opção
+// do pacote glossaries (acronym, symbols, numbers)
+type GlossaryType struct {
+	Name string `json:"name"`
+	Log  string `json:"log"` // extensão do log (glg)
+	In   string `json:"in"`  // extensão gerada pelo makeglossaries e lida pelo LaTeX (gls)
+	Out  string `json:"out"` // extensão escrita pelo LaTeX (glo)
+}
+
+// Indexing descreve os glossários, siglas, nomenclaturas e índices do
+// documento que precisam de um programa externo entre as compilações
+type Indexing struct {
+	Glossaries bool           `json:"glossaries"`         // glossaries com \makeglossaries
+	Bib2gls    bool           `json:"bib2gls"`            // glossaries-extra com a opção record
+	Types      []GlossaryType `json:"types,omitempty"`    // glossários processados pelo makeglossaries
+	Nomencl    bool           `json:"nomencl"`            // nomencl com \makenomenclature
+	Index      bool           `json:"index"`              // \makeindex
+	Xindy      bool           `json:"xindy"`              // índice processado pelo xindy (imakeidx)
+	Indexes    []string       `json:"indexes,omitempty"`  // índices nomeados do imakeidx (\makeindex[name=...])
+	Evidence   []string       `json:"evidence,omitempty"` // comandos que determinaram o processamento
+}
+
+// Any indica se algum processamento é necessário
+func (ix Indexing) Any() bool {
+	return ix.Glossaries || ix.Bib2gls || ix.Nomencl || ix.Index
+}
+
+// Tools lista os programas executados, na ordem em que aparecem no build
+func (ix Indexing) Tools() []string {
+	var tools []string
+	if ix.Glossaries {
+		tools = append(tools, "makeglossaries")
+	}
+	if ix.Bib2gls {
+		tools = append(tools, "bib2gls")
+	}
+	if ix.Nomencl || (ix.Index && !ix.Xindy) {
+		tools = append(tools, "makeindex")
+	}
+	if ix.Index && ix.Xindy {
+		tools = append(tools, "texindy")
+	}
+	return tools
+}
+
+// IndexingExtensions são as extensões dos arquivos intermediários de
+// glossários, nomenclaturas e índices
+var IndexingExtensions = []string{
+	"glo", "gls", "glg", "acn", "acr", "alg", "ist", "xdy", "glsdefs", "glstex",
+	"slo", "sls", "slg", "nlo", "nls", "nlg", "glo-abr", "gls-abr", "glg-abr",
+	"idx", "ind", "ilg",
+}
+
+// Extensions retorna as extensões geradas, incluindo as dos glossários
+// declarados no documento
+func (ix Indexing) Extensions() []string {
+	extensions := append([]string(nil), IndexingExtensions...)
+	for _, t := range ix.Types {
+		for _, ext := range []string{t.Log, t.In, t.Out} {
+			if !containsString(extensions, ext) {
+				extensions = append(extensions, ext)
+			}
+		}
+	}
+	return extensions
+}
+
+// glossaryOptions são as opções do glossaries que criam glossários
+var glossaryOptions = map[string]GlossaryType{
+	"acronym":       {Name: "acronym", Log: "alg", In: "acr", Out: "acn"},
+	"acronyms":      {Name: "acronym", Log: "alg", In: "acr", Out: "acn"},
+	"symbols":       {Name: "symbols", Log: "slg", In: "sls", Out: "slo"},
+	"numbers":       {Name: "numbers", Log: "nlg", In: "nls", Out: "nlo"},
+	"abbreviations": {Name: "abbreviations", Log: "glg-abr", In: "gls-abr", Out: "glo-abr"},
+}
+
+// Indexing detecta glossários (glossaries, glossaries-extra e bib2gls),
+// nomenclaturas (nomencl) e índices (makeidx, imakeidx)
+func (p *Project) Indexing() Indexing {
+	var ix Indexing
+	var makeglossaries, makenomenclature, makeindex, record *Command
+	glossariesPackage, nomenclPackage := false, false
+	types := []GlossaryType{{Name: "main", Log: "glg", In: "gls", Out: "glo"}}
+
+	addType := func(t GlossaryType) {
+		for _, existing := range types {
+			if existing.Out == t.Out {
+				return
+			}
+		}
+		types = append(types, t)
+	}
+
+	for _, cmd := range p.Commands() {
+		switch cmd.Name {
+		case "usepackage", "RequirePackage":
+			var options []string
+			if len(cmd.Optional) > 0 {
+				options = splitList(cmd.Optional[0])
+			}
+
+			for _, pkg := range splitList(cmd.Arg(0)) {
+				switch pkg {
+				case "glossaries", "glossaries-extra":
+					glossariesPackage = true
+					for _, option := range options {
+						name, _, _ := strings.Cut(option, "=")
+						name = strings.TrimSpace(name)
+						if t, ok := glossaryOptions[name]; ok {
+							addType(t)
+						}
+						if name == "record" && pkg == "glossaries-extra" && record == nil {
+							c := cmd
+							record = &c
+						}
+					}
+				case "nomencl":
+					nomenclPackage = true
+				case "imakeidx":
+					if containsString(options, "xindy") || containsString(options, "texindy") {
+						ix.Xindy = true
+					}
+				}
+			}
+
+		case "setupglossaries":
+			for _, option := range splitList(cmd.Arg(0)) {
+				if t, ok := glossaryOptions[strings.TrimSpace(option)]; ok {
+					addType(t)
+				}
+			}
+
+		case "newglossary":
+			if cmd.Star {
+				// \newglossary*{nome}{título}: extensões nome-glg, nome-gls e nome-glo
+				name := strings.TrimSpace(cmd.Arg(0))
+				addType(GlossaryType{Name: name, Log: name + "-glg", In: name + "-gls", Out: name + "-glo"})
+			} else if len(cmd.Args) >= 3 {
+				t := GlossaryType{Name: strings.TrimSpace(cmd.Arg(0)), Log: "glg", In: strings.TrimSpace(cmd.Arg(1)), Out: strings.TrimSpace(cmd.Arg(2))}
+				if len(cmd.Optional) > 0 {
+					t.Log = strings.TrimSpace(cmd.Optional[0])
+				}
+				addType(t)
+			}
+
+		case "makeglossaries":
+			if makeglossaries == nil {
+				c := cmd
+				makeglossaries = &c
+			}
+
+		case "makenomenclature":
+			if makenomenclature == nil {
+				c := cmd
+				makenomenclature = &c
+			}
+
+		case "makeindex":
+			if makeindex == nil {
+				c := cmd
+				makeindex = &c
+			}
+			if len(cmd.Optional) == 0 {
+				continue
+			}
+			for _, option := range splitList(cmd.Optional[0]) {
+				key, value, _ := strings.Cut(option, "=")
+				key, value = strings.TrimSpace(key), strings.Trim(strings.TrimSpace(value), "{}")
+				switch {
+				case key == "name" && value != "" && !containsString(ix.Indexes, value):
+					ix.Indexes = append(ix.Indexes, value)
+				case key == "program" && strings.Contains(value, "xindy"):
+					ix.Xindy = true
+				}
+			}
+		}
+	}
+
+	// Sem o pacote, os comandos são de outra origem (ou definidos pelo autor)
+	if makeglossaries != nil && glossariesPackage {
+		ix.Glossaries = true
+		ix.Types = types
+		ix.Evidence = append(ix.Evidence, evidence(*makeglossaries, `\makeglossaries`))
+	}
+	if record != nil {
+		ix.Bib2gls = true
+		ix.Evidence = append(ix.Evidence, evidence(*record, `\usepackage[record]{glossaries-extra}`))
+	}
+	if makenomenclature != nil && nomenclPackage {
+		ix.Nomencl = true
+		ix.Evidence = append(ix.Evidence, evidence(*makenomenclature, `\makenomenclature`))
+	}
+	if makeindex != nil {
+		ix.Index = true
+		ix.Evidence = append(ix.Evidence, evidence(*makeindex, `\makeindex`))
+	}
+	return ix
+}
@@ -0,0 +1,457 @@
+package latex
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// File é um arquivo .tex lido pelo Project
+type File struct {
+	Path     string
+	Source   string      // conteúdo original
+	Text     string      // conteúdo após Strip
+	Commands []Command   // comandos do arquivo, na ordem
+	Refs     []Reference // arquivos referenciados, na ordem
+}
+
+// Tipos de arquivo referenciados pelos fontes
+const (
+	KindTeX     = "tex"
+	KindGraphic = "graphic"
+	KindBib     = "bib"
+)
+
+// Reference é uma referência a outro arquivo feita no fonte
+type Reference struct {
+	Kind    string `json:"kind"`
+	Command string `json:"command"`        // input, include, includegraphics...
+	Target  string `json:"target"`         // como escrito no fonte
+	Path    string `json:"path,omitempty"` // arquivo encontrado; vazio se não existir
+	From    string `json:"from"`
+	Line    int    `json:"line"`
+}
+
+// Project é um documento formado pelo arquivo principal e tudo o que ele
+// inclui, direta ou indiretamente
+type Project struct {
+	Main    string
+	Files   []*File  // na ordem em que são incluídos (principal primeiro)
+	Missing []string // inclusões de .tex que não foram encontradas
+
+	dirs         []string
+	graphicsDirs []string // de \graphicspath
+	tree         []string // arquivos sob o diretório do principal, para a busca recursiva
+}
+
+// includeCommands são os comandos que incluem outro arquivo .tex
+var includeCommands = map[string]bool{
+	"input":   true,
+	"include": true,
+	"subfile": true,
+}
+
+// Extensões procuradas quando a referência não tem uma
+var (
+	texExts     = []string{".tex"}
+	graphicExts = []string{".pdf", ".png", ".jpg", ".jpeg", ".eps"}
+	bibExts     = []string{".bib"}
+)
+
+// Load lê o arquivo principal e segue \input, \include, \subfile e \import
+// recursivamente. Os caminhos são resolvidos em relação ao diretório do
+// arquivo principal, ao diretório atual e, por fim, em qualquer subdiretório
+// do principal (como o TeX faz com TEXINPUTS=./src//). Inclusões ausentes ou
+// circulares não interrompem a leitura.
+func Load(main string) (*Project, error) {
+	if _, err := os.Stat(main); err != nil {
+		return nil, err
+	}
+
+	p := &Project{
+		Main: main,
+		dirs: []string{filepath.Dir(main), "."},
+	}
+
+	seen := make(map[string]bool)
+	if err := p.load(main, seen); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *Project) load(path string, seen map[string]bool) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		abs = path
+	}
+	if seen[abs] {
+		return nil
+	}
+	seen[abs] = true
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	text := Strip(string(data))
+	file := &File{Path: path, Source: string(data), Text: text, Commands: Commands(text)}
+	for i := range file.Commands {
+		file.Commands[i].File = path
+	}
+	p.Files = append(p.Files, file)
+
+	for _, cmd := range file.Commands {
+		ref := Reference{Command: cmd.Name, From: path, Line: cmd.Line}
+		var targets []string
+		var exts, extraDirs []string
+
+		switch {
+		case includeCommands[cmd.Name]:
+			ref.Kind, exts = KindTeX, texExts
+			targets = []string{cmd.Arg(0)}
+		case cmd.Name == "import" || cmd.Name == "subimport" || cmd.Name == "inputfrom" || cmd.Name == "includefrom":
+			ref.Kind, exts = KindTeX, texExts
+			targets = []string{filepath.Join(cmd.Arg(0), cmd.Arg(1))}
+		case cmd.Name == "includegraphics":
+			ref.Kind, exts, extraDirs = KindGraphic, graphicExts, p.graphicsDirs
+			targets = []string{cmd.Arg(0)}
+		case cmd.Name == "bibliography" || cmd.Name == "addbibresource":
+			ref.Kind, exts = KindBib, bibExts
+			targets = splitList(cmd.Arg(0))
+		case cmd.Name == "graphicspath":
+			for _, dir := range strings.Split(cmd.Arg(0), "}") {
+				if dir = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(dir), "{")); dir != "" {
+					p.graphicsDirs = append(p.graphicsDirs, dir)
+				}
+			}
+			continue
+		default:
+			continue
+		}
+
+		for _, target := range targets {
+			ref.Target = strings.TrimSpace(target)
+			if ref.Target == "" {
+				continue
+			}
+
+			resolved, ok := p.resolve(ref.Target, exts, filepath.Dir(path), extraDirs...)
+			ref.Path = resolved
+			file.Refs = append(file.Refs, ref)
+
+			if ref.Kind != KindTeX {
+				continue
+			}
+			if !ok {
+				p.Missing = append(p.Missing, ref.Target)
+				continue
+			}
+			if err := p.load(resolved, seen); err != nil {
+				p.Missing = append(p.Missing, ref.Target)
+			}
+		}
+	}
+
+	return nil
+}
+
+// resolve procura um arquivo referenciado no fonte, acrescentando as
+// extensões padrão quando o nome não tiver uma delas
+func (p *Project) resolve(name string, exts []string, from string, extraDirs ...string) (string, bool) {
+	candidates := []string{name}
+	if !containsString(exts, strings.ToLower(filepath.Ext(name))) {
+		candidates = nil
+		for _, ext := range exts {
+			candidates = append(candidates, name+ext)
+		}
+		candidates = append(candidates, name)
+	}
+
+	dirs := append(append([]string{from}, extraDirs...), p.dirs...)
+	for _, dir := range dirs {
+		for _, candidate := range candidates {
+			path := candidate
+			if !filepath.IsAbs(candidate) {
+				path = filepath.Join(dir, candidate)
+			}
+			if info, err := os.Stat(path); err == nil && !info.IsDir() {
+				return path, true
+			}
+		}
+	}
+
+	// Busca recursiva sob o diretório do principal, pelo final do caminho
+	for _, candidate := range candidates {
+		suffix := string(filepath.Separator) + filepath.Clean(candidate)
+		for _, path := range p.sourceTree() {
+			if strings.HasSuffix(path, suffix) {
+				return path, true
+			}
+		}
+	}
+	return "", false
+}
+
+// sourceTree lista (uma única vez) os arquivos sob o diretório do principal
+func (p *Project) sourceTree() []string {
+	if p.tree != nil {
+		return p.tree
+	}
+
+	p.tree = []string{}
+	_ = filepath.WalkDir(filepath.Dir(p.Main), func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != filepath.Dir(p.Main) && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+		if !d.IsDir() {
+			p.tree = append(p.tree, path)
+		}
+		return nil
+	})
+	return p.tree
+}
+
+// References retorna as referências de todos os arquivos, na ordem de inclusão
+func (p *Project) References() []Reference {
+	var refs []Reference
+	for _, f := range p.Files {
+		refs = append(refs, f.Refs...)
+	}
+	return refs
+}
+
+// Commands retorna os comandos de todos os arquivos, na ordem de inclusão
+func (p *Project) Commands() []Command {
+	var all []Command
+	for _, f := range p.Files {
+		all = append(all, f.Commands...)
+	}
+	return all
+}
+
+// FirstArg retorna o primeiro argumento da primeira ocorrência de um comando
+// (ex.: FirstArg("title")), ou "" se ele não for usado
+func (p *Project) FirstArg(name string) string {
+	for _, cmd := range p.Commands() {
+		if cmd.Name == name && len(cmd.Args) > 0 {
+			return strings.TrimSpace(cmd.Args[0])
+		}
+	}
+	return ""
+}
+
+// BibFiles retorna os arquivos de bibliografia referenciados por
+// \bibliography e \addbibresource que existem no disco
+func (p *Project) BibFiles() []string {
+	var files []string
+	seen := make(map[string]bool)
+
+	for _, ref := range p.References() {
+		if ref.Kind != KindBib || ref.Path == "" || seen[ref.Path] {
+			continue
+		}
+		seen[ref.Path] = true
+		files = append(files, ref.Path)
+	}
+	return files
+}
+
+// SectionLevels são os níveis de seção, do mais alto ao mais baixo
+var SectionLevels = []string{"part", "chapter", "section", "subsection", "subsubsection", "paragraph", "subparagraph"}
+
+var (
+	figureEnvs = map[string]bool{"figure": true, "figure*": true, "wrapfigure": true, "SCfigure": true}
+	tableEnvs  = map[string]bool{"table": true, "table*": true, "wraptable": true, "longtable": true}
+	mathEnvs   = map[string]bool{
+		"equation": true, "equation*": true,
+		"align": true, "align*": true,
+		"gather": true, "gather*": true,
+		"multline": true, "multline*": true,
+		"flalign": true, "flalign*": true,
+		"eqnarray": true, "eqnarray*": true,
+		"displaymath": true,
+	}
+)
+
+// Stats resume a estrutura de um documento
+type Stats struct {
+	Files           int            `json:"files"`
+	Sections        map[string]int `json:"sections"` // por nível (SectionLevels)
+	Figures         int            `json:"figures"`
+	Tables          int            `json:"tables"`
+	Equations       int            `json:"equations"`
+	Citations       int            `json:"citations"`        // chaves citadas, com repetição
+	UniqueCitations int            `json:"unique_citations"` // chaves distintas
+	Labels          int            `json:"labels"`
+}
+
+// Stats conta seções, figuras, tabelas, equações em destaque, citações e
+// rótulos em todos os arquivos do projeto. Comentários e blocos verbatim não
+// são considerados.
+func (p *Project) Stats() Stats {
+	stats := Stats{Files: len(p.Files), Sections: make(map[string]int)}
+	keys := make(map[string]bool)
+
+	for _, cmd := range p.Commands() {
+		switch {
+		case cmd.Name == "begin":
+			env := strings.TrimSpace(cmd.Arg(0))
+			switch {
+			case figureEnvs[env]:
+				stats.Figures++
+			case tableEnvs[env]:
+				stats.Tables++
+			case mathEnvs[env]:
+				stats.Equations++
+			}
+
+		case cmd.Name == "[":
+			stats.Equations++
+
+		case cmd.Name == "label":
+			stats.Labels++
+
+		case IsCitation(cmd.Name):
+			for _, key := range cmd.Keys() {
+				stats.Citations++
+				keys[key] = true
+			}
+
+		default:
+			for _, level := range SectionLevels {
+				if cmd.Name == level {
+					stats.Sections[level]++
+					break
+				}
+			}
+		}
+	}
+
+	stats.UniqueCitations = len(keys)
+	return stats
+}
+
+// CitedKeys retorna as chaves citadas no projeto, sem repetição e ordenadas
+func (p *Project) CitedKeys() []string {
+	seen := make(map[string]bool)
+	for _, cmd := range p.Commands() {
+		if !IsCitation(cmd.Name) {
+			continue
+		}
+		for _, key := range cmd.Keys() {
+			seen[key] = true
+		}
+	}
+
+	keys := make([]string, 0, len(seen))
+	for key := range seen {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// IsCitation reconhece \cite e variantes (\citep, \textcite, \parencite,
+// \autocite...), exceto \nocite, que não gera citação no texto
+func IsCitation(name string) bool {
+	name = strings.ToLower(name)
+	if name == "nocite" {
+		return false
+	}
+	return strings.HasPrefix(name, "cite") || strings.HasSuffix(name, "cite") || strings.HasSuffix(name, "cites")
+}
+
+// splitList separa uma lista de nomes por vírgulas (chaves de citação,
+// arquivos de bibliografia)
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// ReplaceCitationKeys troca chaves de citação no fonte (\cite, variantes e
+// \nocite), mantendo o restante do texto. Retorna o novo fonte e quantas
+// chaves foram trocadas.
+func ReplaceCitationKeys(src string, renames map[string]string) (string, int) {
+	var out strings.Builder
+	replaced := 0
+
+	last := 0
+	for i := 0; i < len(src); i++ {
+		if src[i] != '\\' {
+			continue
+		}
+		name := readName(src, i+1)
+		if name == "" {
+			i++
+			continue
+		}
+		if !IsCitation(name) && name != "nocite" {
+			i += len(name)
+			continue
+		}
+
+		j := i + 1 + len(name)
+		if j < len(src) && src[j] == '*' {
+			j++
+		}
+		for {
+			k := skipSpaces(src, j)
+			if k >= len(src) || src[k] != '[' {
+				break
+			}
+			_, after, ok := groupAt(src, k)
+			if !ok {
+				break
+			}
+			j = after
+		}
+
+		// \cites{a}{b} aceita vários grupos; os demais comandos, apenas um
+		for {
+			k := skipSpaces(src, j)
+			if k >= len(src) || src[k] != '{' {
+				break
+			}
+			content, after, ok := groupAt(src, k)
+			if !ok {
+				break
+			}
+
+			keys := strings.Split(content, ",")
+			changed := false
+			for n, key := range keys {
+				trimmed := strings.TrimSpace(key)
+				if renamed, ok := renames[trimmed]; ok {
+					keys[n] = strings.Replace(key, trimmed, renamed, 1)
+					changed = true
+					replaced++
+				}
+			}
+			if changed {
+				out.WriteString(src[last : k+1])
+				out.WriteString(strings.Join(keys, ","))
+				last = after - 1
+			}
+
+			j = after
+			if !strings.HasSuffix(strings.ToLower(name), "cites") {
+				break
+			}
+		}
+		i = j - 1
+	}
+
+	out.WriteString(src[last:])
+	return out.String(), replaced
+}
@@ -0,0 +1,196 @@
+package latex
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestProjectStats(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"main.tex":            "\\title{Tese}\n\\author{Ana}\n\\begin{document}\n% \\input{chapters/antigo}\n\\input{chapters/cap1}\n\\include{chapters/cap2.tex}\n\\input{chapters/ausente}\n\\bibliography{refs}\n\\end{document}\n",
+		"chapters/cap1.tex":   "\\chapter{Um}\n\\section{A}\\label{sec:a}\n\\begin{figure}\\caption{F \\cite{x}}\\end{figure}\n\\begin{verbatim}\n\\section{Falsa}\n\\end{verbatim}\n\\input{chapters/cap1}\n",
+		"chapters/cap2.tex":   "\\chapter{Dois}\n\\begin{table}\\end{table}\n\\begin{align}a\\end{align}\n\\[ b \\]\n\\textcite{x} \\parencite{y,z} \\nocite{*}\n",
+		"chapters/antigo.tex": "\\chapter{Antigo}\n",
+		"refs.bib":            "@article{x,}\n@book{y,}\n@article{z,}\n",
+	})
+
+	p, err := Load(filepath.Join(dir, "main.tex"))
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	stats := p.Stats()
+	checks := []struct {
+		name     string
+		got      int
+		expected int
+	}{
+		{"files", stats.Files, 3},
+		{"chapters", stats.Sections["chapter"], 2},
+		{"sections", stats.Sections["section"], 1},
+		{"figures", stats.Figures, 1},
+		{"tables", stats.Tables, 1},
+		{"equations", stats.Equations, 2},
+		{"citations", stats.Citations, 4},
+		{"unique citations", stats.UniqueCitations, 3},
+		{"labels", stats.Labels, 1},
+	}
+	for _, c := range checks {
+		if c.got != c.expected {
+			t.Errorf("%s = %d, expected %d", c.name, c.got, c.expected)
+		}
+	}
+
+	if len(p.Missing) != 1 || p.Missing[0] != "chapters/ausente" {
+		t.Errorf("Missing = %v, expected [chapters/ausente]", p.Missing)
+	}
+	if title := p.FirstArg("title"); title != "Tese" {
+		t.Errorf("FirstArg(title) = %q, expected Tese", title)
+	}
+
+	bibs := p.BibFiles()
+	if len(bibs) != 1 || filepath.Base(bibs[0]) != "refs.bib" {
+		t.Fatalf("BibFiles() = %v, expected [refs.bib]", bibs)
+	}
+	types, err := BibEntryTypes(bibs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if types["article"] != 2 || types["book"] != 1 {
+		t.Errorf("BibEntryTypes() = %v, expected 2 article and 1 book", types)
+	}
+}
+
+func TestReplaceCitationKeys(t *testing.T) {
+	src := "Ver \\cite[p.~2]{a, b} e \\textcite{c}.\n\\cites{a}{c}\n\\nocite{a}\n\\ref{a}\n% \\cite{a}"
+	expected := "Ver \\cite[p.~2]{silva2020, b} e \\textcite{souza2021}.\n\\cites{silva2020}{souza2021}\n\\nocite{silva2020}\n\\ref{a}\n% \\cite{silva2020}"
+
+	got, replaced := ReplaceCitationKeys(src, map[string]string{"a": "silva2020", "c": "souza2021"})
+	if got != expected {
+		t.Errorf("ReplaceCitationKeys() =\n%s\nexpected\n%s", got, expected)
+	}
+	if replaced != 6 {
+		t.Errorf("replaced = %d, expected 6", replaced)
+	}
+}
+
+func TestBibliography(t *testing.T) {
+	tests := []struct {
+		name     string
+		main     string
+		backend  string
+		biblatex bool
+	}{
+		{name: "natbib e \\bibliography", main: "\\usepackage{natbib}\n\\bibliography{refs}", backend: BackendBibtex},
+		{name: "biblatex padrão", main: "\\usepackage[style=abnt]{biblatex}\n\\addbibresource{refs.bib}", backend: BackendBiber, biblatex: true},
+		{name: "biblatex com backend=bibtex", main: "\\usepackage[backend=bibtex, style=ieee]{biblatex}\n\\bibliography{refs}", backend: BackendBibtex, biblatex: true},
+		{name: "\\addbibresource sem o pacote (carregado pela classe)", main: "\\addbibresource{refs.bib}", backend: BackendBiber, biblatex: true},
+		{name: "comentado", main: "% \\usepackage{biblatex}\n\\bibliography{refs}", backend: BackendBibtex},
+		{name: "sem bibliografia", main: "\\section{A}", backend: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"main.tex": tt.main})
+			p, err := Load(filepath.Join(dir, "main.tex"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			bib := p.Bibliography()
+			if bib.Backend != tt.backend || bib.Biblatex != tt.biblatex {
+				t.Errorf("Bibliography() = %+v, expected backend %q e biblatex %v", bib, tt.backend, tt.biblatex)
+			}
+		})
+	}
+}
+
+func TestIndexing(t *testing.T) {
+	tests := []struct {
+		name    string
+		main    string
+		tools   []string
+		types   []string
+		indexes []string
+	}{
+		{
+			name:  "glossaries com siglas e glossário próprio",
+			main:  "\\usepackage[acronym, symbols]{glossaries}\n\\newglossary[nlg2]{notacao}{not}{ntn}{Notação}\n\\makeglossaries",
+			tools: []string{"makeglossaries"},
+			types: []string{"main:glo", "acronym:acn", "symbols:slo", "notacao:ntn"},
+		},
+		{
+			name:  "glossaries sem \\makeglossaries",
+			main:  "\\usepackage{glossaries}\n\\makenoidxglossaries",
+			tools: nil,
+		},
+		{
+			name:  "bib2gls",
+			main:  "\\usepackage[record,abbreviations]{glossaries-extra}\n\\GlsXtrLoadResources[src=termos]",
+			tools: []string{"bib2gls"},
+		},
+		{
+			name:  "nomencl e índice",
+			main:  "\\usepackage{nomencl}\n\\makenomenclature\n\\usepackage{makeidx}\n\\makeindex",
+			tools: []string{"makeindex"},
+		},
+		{
+			name:    "imakeidx com xindy e índice nomeado",
+			main:    "\\usepackage[xindy]{imakeidx}\n\\makeindex\n\\makeindex[name=autores, title=Autores]",
+			tools:   []string{"texindy"},
+			indexes: []string{"autores"},
+		},
+		{
+			name:  "comentado",
+			main:  "% \\usepackage{glossaries}\\makeglossaries\n% \\makeindex",
+			tools: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, map[string]string{"main.tex": tt.main})
+			p, err := Load(filepath.Join(dir, "main.tex"))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			ix := p.Indexing()
+			if !reflect.DeepEqual(ix.Tools(), tt.tools) {
+				t.Errorf("Indexing().Tools() = %v, expected %v", ix.Tools(), tt.tools)
+			}
+
+			var types []string
+			for _, gt := range ix.Types {
+				types = append(types, gt.Name+":"+gt.Out)
+			}
+			if !reflect.DeepEqual(types, tt.types) {
+				t.Errorf("Indexing().Types = %v, expected %v", types, tt.types)
+			}
+			if !reflect.DeepEqual(ix.Indexes, tt.indexes) {
+				t.Errorf("Indexing().Indexes = %v, expected %v", ix.Indexes, tt.indexes)
+			}
+			if ix.Any() != (len(ix.Evidence) > 0) {
+				t.Errorf("Indexing().Evidence = %v, expected evidência para cada processamento", ix.Evidence)
+			}
+		})
+	}
+}
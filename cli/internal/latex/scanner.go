@@ -0,0 +1,357 @@
+// Package latex lê fontes LaTeX sem compilá-los: remove comentários e blocos
+// verbatim, extrai comandos e ambientes e segue \input/\include para reunir
+// estatísticas do documento.
+package latex
+
+import (
+	"strings"
+)
+
+// verbatimEnvs são ambientes cujo conteúdo não é interpretado como LaTeX
+var verbatimEnvs = map[string]bool{
+	"verbatim":      true,
+	"verbatim*":     true,
+	"Verbatim":      true,
+	"Verbatim*":     true,
+	"BVerbatim":     true,
+	"LVerbatim":     true,
+	"lstlisting":    true,
+	"minted":        true,
+	"comment":       true,
+	"filecontents":  true,
+	"filecontents*": true,
+}
+
+// definitionCommands definem macros; seus argumentos não são uso dos comandos
+// que contêm (ex.: \renewcommand{\title}{...})
+var definitionCommands = map[string]bool{
+	"newcommand":           true,
+	"renewcommand":         true,
+	"providecommand":       true,
+	"DeclareRobustCommand": true,
+	"newenvironment":       true,
+	"renewenvironment":     true,
+	"def":                  true,
+	"gdef":                 true,
+	"edef":                 true,
+	"xdef":                 true,
+	"let":                  true,
+}
+
+// Command é uma ocorrência de um comando no fonte
+type Command struct {
+	Name     string   // sem a barra; "[" para \[
+	Star     bool     // \section*
+	Optional []string // argumentos entre colchetes
+	Args     []string // argumentos entre chaves, na ordem
+	File     string   // arquivo de origem (preenchido por Project)
+	Line     int      // linha (1-based)
+	Offset   int      // posição da barra no texto após Strip
+}
+
+// Keys retorna a lista separada por vírgulas do último argumento obrigatório
+// (chaves de \cite, rótulos de \cref)
+func (c Command) Keys() []string {
+	return splitList(c.Arg(len(c.Args) - 1))
+}
+
+// Arg retorna o i-ésimo argumento obrigatório, ou "" se não houver
+func (c Command) Arg(i int) string {
+	if i >= 0 && i < len(c.Args) {
+		return c.Args[i]
+	}
+	return ""
+}
+
+// Strip remove comentários, \verb e o conteúdo de ambientes verbatim,
+// preservando as quebras de linha para que as linhas continuem correspondendo
+// às do arquivo original
+func Strip(src string) string {
+	var out strings.Builder
+	out.Grow(len(src))
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == '\\' && i+1 < len(src):
+			name := readName(src, i+1)
+
+			if name == "verb" {
+				end := verbEnd(src, i+5)
+				blank(&out, src[i:end])
+				i = end
+				continue
+			}
+
+			if name == "begin" {
+				if env, after, ok := groupAt(src, i+6); ok && verbatimEnvs[env] {
+					endTag := `\end{` + env + `}`
+					end := strings.Index(src[after:], endTag)
+
+					// Mantém \begin e \end para que o ambiente continue visível
+					out.WriteString(src[i:after])
+					if end < 0 {
+						blank(&out, src[after:])
+						i = len(src)
+					} else {
+						blank(&out, src[after:after+end])
+						out.WriteString(endTag)
+						i = after + end + len(endTag)
+					}
+					continue
+				}
+			}
+
+			// Comando ou símbolo escapado (\%, \\): copiar sem interpretar
+			if name == "" {
+				out.WriteString(src[i : i+2])
+				i += 2
+			} else {
+				out.WriteString(src[i : i+1+len(name)])
+				i += 1 + len(name)
+			}
+
+		case c == '%':
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				i = len(src)
+			} else {
+				i += end
+			}
+
+		default:
+			out.WriteByte(c)
+			i++
+		}
+	}
+
+	return out.String()
+}
+
+// blank escreve um trecho substituído por espaços, mantendo as quebras de linha
+func blank(out *strings.Builder, s string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '\n' {
+			out.WriteByte('\n')
+		} else {
+			out.WriteByte(' ')
+		}
+	}
+}
+
+// verbEnd retorna o fim de um \verb iniciado em i (logo após "\verb")
+func verbEnd(src string, i int) int {
+	if i < len(src) && src[i] == '*' {
+		i++
+	}
+	if i >= len(src) {
+		return len(src)
+	}
+
+	delim := src[i]
+	for j := i + 1; j < len(src); j++ {
+		if src[j] == delim || src[j] == '\n' {
+			return j + 1
+		}
+	}
+	return len(src)
+}
+
+// readName lê o nome de um comando (letras) a partir de i
+func readName(src string, i int) string {
+	j := i
+	for j < len(src) && isLetter(src[j]) {
+		j++
+	}
+	return src[i:j]
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '@'
+}
+
+// groupAt lê um grupo delimitado ({...} ou [...]) a partir de i, ignorando
+// espaços antes dele. Retorna o conteúdo e a posição após o fechamento.
+func groupAt(src string, i int) (string, int, bool) {
+	for i < len(src) && (src[i] == ' ' || src[i] == '\t') {
+		i++
+	}
+	if i >= len(src) || (src[i] != '{' && src[i] != '[') {
+		return "", i, false
+	}
+
+	open := src[i]
+	closeChar := byte('}')
+	if open == '[' {
+		closeChar = ']'
+	}
+
+	depth := 0
+	for j := i; j < len(src); j++ {
+		switch src[j] {
+		case '\\':
+			j++ // ignora o caractere escapado
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if open == '{' && depth == 0 {
+				return src[i+1 : j], j + 1, true
+			}
+		case closeChar:
+			if open == '[' && depth == 0 {
+				return src[i+1 : j], j + 1, true
+			}
+		}
+	}
+	return "", i, false
+}
+
+// Commands extrai os comandos de um fonte já processado por Strip. Os
+// argumentos de cada comando também são percorridos (\caption{... \cite{x}}
+// gera \caption e \cite), exceto os de definições de macros.
+func Commands(text string) []Command {
+	var commands []Command
+	line := 1
+
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if c == '\n' {
+			line++
+			continue
+		}
+		if c != '\\' || i+1 >= len(text) {
+			continue
+		}
+
+		name := readName(text, i+1)
+		if name == "" {
+			// Símbolos: apenas \[ interessa (equação em destaque)
+			if text[i+1] == '[' {
+				commands = append(commands, Command{Name: "[", Line: line, Offset: i})
+			}
+			i++
+			continue
+		}
+
+		cmd := Command{Name: name, Line: line, Offset: i}
+		j := i + 1 + len(name)
+		if j < len(text) && text[j] == '*' {
+			cmd.Star = true
+			j++
+		}
+
+		// Argumentos: grupos consecutivos, podendo atravessar uma quebra de linha simples
+		for {
+			k := j
+			newlines := 0
+			for k < len(text) && (text[k] == ' ' || text[k] == '\t' || text[k] == '\n') {
+				if text[k] == '\n' {
+					newlines++
+				}
+				k++
+			}
+			if newlines > 1 || k >= len(text) {
+				break
+			}
+
+			content, after, ok := groupAt(text, k)
+			if !ok {
+				break
+			}
+			if text[k] == '[' {
+				cmd.Optional = append(cmd.Optional, content)
+			} else {
+				cmd.Args = append(cmd.Args, content)
+			}
+			j = after
+		}
+
+		commands = append(commands, cmd)
+
+		if definitionCommands[name] {
+			// Pular a definição inteira, contando as linhas
+			j = skipDefinition(text, name, i+1+len(name))
+			line += strings.Count(text[i:j], "\n")
+			i = j - 1
+			continue
+		}
+
+		i += len(name)
+	}
+
+	return commands
+}
+
+// skipDefinition retorna o fim de uma definição de macro iniciada em i (logo
+// após o nome do comando de definição): \newcommand{\foo}[1]{...},
+// \newcommand\foo{...}, \def\foo#1{...} ou \let\a\b
+func skipDefinition(text, name string, i int) int {
+	for i < len(text) && (text[i] == ' ' || text[i] == '\t' || text[i] == '*') {
+		i++
+	}
+
+	// Nome da macro fora de chaves
+	if i < len(text) && text[i] == '\\' {
+		i++
+		if macro := readName(text, i); macro != "" {
+			i += len(macro)
+		} else {
+			i++
+		}
+	}
+
+	switch name {
+	case "let":
+		return i
+
+	case "def", "gdef", "edef", "xdef":
+		// Parâmetros (#1#2...) até o corpo
+		for i < len(text) && text[i] != '{' && text[i] != '\n' {
+			i++
+		}
+		if _, after, ok := groupAt(text, i); ok {
+			return after
+		}
+		return i
+	}
+
+	// \newcommand e afins: todos os grupos seguintes ({nome}[n][padrão]{corpo})
+	for {
+		k := i
+		for k < len(text) && (text[k] == ' ' || text[k] == '\t' || text[k] == '\n') {
+			k++
+		}
+		_, after, ok := groupAt(text, k)
+		if !ok {
+			return i
+		}
+		i = after
+	}
+}
+
+// formattingCommands são removidos por PlainText, mantendo o argumento
+var formattingCommands = []string{"textbf", "textit", "emph", "textsc", "texttt", "textrm", "textsf", "underline", "uppercase", "MakeUppercase"}
+
+// PlainText simplifica um argumento para exibição (títulos, autores):
+// remove comandos de formatação mantendo o texto e troca \\ e ~ por espaços
+func PlainText(s string) string {
+	for _, name := range formattingCommands {
+		prefix := `\` + name
+		for {
+			start := strings.Index(s, prefix+"{")
+			if start < 0 {
+				break
+			}
+			content, after, ok := groupAt(s, start+len(prefix))
+			if !ok {
+				break
+			}
+			s = s[:start] + content + s[after:]
+		}
+	}
+
+	s = strings.NewReplacer(`\\`, " ", "~", " ", "\n", " ").Replace(s)
+	return strings.Join(strings.Fields(s), " ")
+}
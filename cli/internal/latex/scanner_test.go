@@ -0,0 +1,103 @@
+package latex
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		src      string
+		expected string
+	}{
+		{
+			name:     "comentário de linha",
+			src:      "texto % \\section{Oculta}\nmais",
+			expected: "texto \nmais",
+		},
+		{
+			name:     "porcentagem escapada",
+			src:      "50\\% dos casos % comentário",
+			expected: "50\\% dos casos ",
+		},
+		{
+			name:     "quebra de linha seguida de comentário",
+			src:      "a\\\\% comentário\nb",
+			expected: "a\\\\\nb",
+		},
+		{
+			name:     "verbatim preserva linhas",
+			src:      "\\begin{verbatim}\n\\section{X}\n\\end{verbatim}\n\\section{Y}",
+			expected: "\\begin{verbatim}\n           \n\\end{verbatim}\n\\section{Y}",
+		},
+		{
+			name:     "verb inline",
+			src:      "use \\verb|\\cite{x}| aqui",
+			expected: "use " + strings.Repeat(" ", 15) + " aqui",
+		},
+		{
+			name:     "porcentagem dentro de lstlisting",
+			src:      "\\begin{lstlisting}\n100%\n\\end{lstlisting}",
+			expected: "\\begin{lstlisting}\n    \n\\end{lstlisting}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Strip(tt.src); got != tt.expected {
+				t.Errorf("Strip() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommands(t *testing.T) {
+	src := "\\section*{Intro}\n\\begin{figure}[h]\n  \\caption{Veja \\cite[p.~2]{a,b}}\n\\end{figure}\n\\newcommand{\\sec}[1]{\\section{#1}}\n\\def\\x#1{\\cite{z}}\n\\[ x \\]\n\\label{fim}"
+	commands := Commands(Strip(src))
+
+	var names []string
+	for _, cmd := range commands {
+		names = append(names, cmd.Name)
+	}
+	expected := []string{"section", "begin", "caption", "cite", "end", "newcommand", "def", "[", "label"}
+	if len(names) != len(expected) {
+		t.Fatalf("Commands() = %v, expected %v", names, expected)
+	}
+	for i := range expected {
+		if names[i] != expected[i] {
+			t.Fatalf("Commands() = %v, expected %v", names, expected)
+		}
+	}
+
+	if !commands[0].Star || commands[0].Arg(0) != "Intro" {
+		t.Errorf("section = %+v, expected starred with arg Intro", commands[0])
+	}
+	if commands[1].Arg(0) != "figure" || len(commands[1].Optional) != 1 {
+		t.Errorf("begin = %+v, expected figure with optional [h]", commands[1])
+	}
+	if commands[3].Arg(0) != "a,b" || commands[3].Line != 3 {
+		t.Errorf("cite = %+v, expected keys a,b on line 3", commands[3])
+	}
+	if commands[8].Line != 8 {
+		t.Errorf("label on line %d, expected 8", commands[8].Line)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Minha Tese", "Minha Tese"},
+		{"\\textbf{ Minha Tese }", "Minha Tese"},
+		{"Um \\emph{estudo}\\\\ de caso", "Um estudo de caso"},
+		{"My {Complex} Title", "My {Complex} Title"},
+	}
+
+	for _, tt := range tests {
+		if got := PlainText(tt.input); got != tt.expected {
+			t.Errorf("PlainText(%q) = %q, expected %q", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,382 @@
+package latex
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// WordCount separa as palavras de um trecho por categoria
+type WordCount struct {
+	Text      int `json:"text"`
+	Headers   int `json:"headers"`
+	Captions  int `json:"captions"`
+	Footnotes int `json:"footnotes"`
+}
+
+// Add soma outra contagem a esta
+func (w *WordCount) Add(other WordCount) {
+	w.Text += other.Text
+	w.Headers += other.Headers
+	w.Captions += other.Captions
+	w.Footnotes += other.Footnotes
+}
+
+// Total soma todas as categorias
+func (w WordCount) Total() int {
+	return w.Text + w.Headers + w.Captions + w.Footnotes
+}
+
+// SectionWords é a contagem de uma seção. O trecho antes da primeira seção de
+// um arquivo tem Level vazio.
+type SectionWords struct {
+	Level string `json:"level,omitempty"`
+	Title string `json:"title,omitempty"`
+	Line  int    `json:"line"`
+	WordCount
+}
+
+// FileWords é a contagem de um arquivo, com suas seções
+type FileWords struct {
+	Path     string         `json:"path"`
+	Sections []SectionWords `json:"sections"`
+	WordCount
+}
+
+// WordSectionLevels são os níveis que iniciam uma nova seção na contagem;
+// títulos de níveis inferiores contam como títulos da seção que os contém
+var WordSectionLevels = []string{"part", "chapter", "section"}
+
+type wordCategory int
+
+const (
+	categoryText wordCategory = iota
+	categoryHeaders
+	categoryCaptions
+	categoryFootnotes
+)
+
+// ignoredArgs são comandos cujos argumentos obrigatórios (na quantidade
+// indicada) não são texto: rótulos, referências, arquivos, medidas...
+var ignoredArgs = map[string]int{
+	"label": 1, "ref": 1, "eqref": 1, "pageref": 1, "autoref": 1, "nameref": 1, "vref": 1, "cref": 1, "Cref": 1,
+	"input": 1, "include": 1, "subfile": 1, "import": 2, "subimport": 2, "includegraphics": 1, "includepdf": 1,
+	"lstinputlisting": 1, "inputminted": 2, "usepackage": 1, "RequirePackage": 1, "documentclass": 1,
+	"bibliography": 1, "bibliographystyle": 1, "addbibresource": 1, "printbibliography": 0, "nocite": 1,
+	"url": 1, "href": 1, "hspace": 1, "vspace": 1, "setlength": 2, "addtolength": 2, "setcounter": 2,
+	"addtocounter": 2, "newcounter": 1, "pagestyle": 1, "thispagestyle": 1, "pagenumbering": 1,
+	"graphicspath": 1, "hypersetup": 1, "geometry": 1, "color": 1, "textcolor": 1, "definecolor": 3,
+	"selectlanguage": 1, "usetikzlibrary": 1, "tikzset": 1, "bibitem": 1, "end": 1,
+	"title": 1, "author": 1, "date": 1,
+}
+
+// envArgs são os argumentos após \begin{env} que não são texto
+var envArgs = map[string]int{
+	"tabular": 1, "tabular*": 2, "tabularx": 2, "longtable": 1, "array": 1,
+	"minipage": 1, "wrapfigure": 2, "wraptable": 2, "multicols": 1,
+}
+
+// skippedEnvs são ambientes cujo conteúdo inteiro não é texto
+var skippedEnvs = map[string]bool{
+	"math": true, "tikzpicture": true, "thebibliography": true,
+}
+
+// CountWords conta as palavras de um fonte já processado por Strip, por
+// seção. Em arquivos com \begin{document}, apenas o corpo do documento é
+// contado. Matemática, argumentos de referências e rótulos são ignorados.
+func CountWords(path, text string) FileWords {
+	c := &wordCounter{line: 1}
+
+	if start := strings.Index(text, `\begin{document}`); start >= 0 {
+		c.line += strings.Count(text[:start], "\n")
+		text = text[start+len(`\begin{document}`):]
+		if end := strings.Index(text, `\end{document}`); end >= 0 {
+			text = text[:end]
+		}
+	}
+	c.sections = []SectionWords{{Line: c.line}}
+
+	c.scan(text, categoryText)
+
+	file := FileWords{Path: path}
+	for _, s := range c.sections {
+		// Omitir o trecho inicial vazio quando o arquivo começa por uma seção
+		if s.Level == "" && s.Total() == 0 && len(c.sections) > 1 {
+			continue
+		}
+		file.Sections = append(file.Sections, s)
+		file.Add(s.WordCount)
+	}
+	return file
+}
+
+// Words conta as palavras de cada arquivo do projeto
+func (p *Project) Words() []FileWords {
+	files := make([]FileWords, 0, len(p.Files))
+	for _, f := range p.Files {
+		files = append(files, CountWords(f.Path, f.Text))
+	}
+	return files
+}
+
+type wordCounter struct {
+	sections []SectionWords
+	line     int
+}
+
+func (c *wordCounter) add(category wordCategory) {
+	s := &c.sections[len(c.sections)-1]
+	switch category {
+	case categoryHeaders:
+		s.Headers++
+	case categoryCaptions:
+		s.Captions++
+	case categoryFootnotes:
+		s.Footnotes++
+	default:
+		s.Text++
+	}
+}
+
+// skip descarta um trecho, contando apenas suas linhas
+func (c *wordCounter) skip(s string) {
+	c.line += strings.Count(s, "\n")
+}
+
+func (c *wordCounter) scan(s string, category wordCategory) {
+	for i := 0; i < len(s); {
+		ch := s[i]
+
+		switch {
+		case ch == '\n':
+			c.line++
+			i++
+
+		case ch == '\\':
+			i = c.command(s, i, category)
+
+		case ch == '$':
+			i = c.skipMath(s, i)
+
+		default:
+			r, size := utf8.DecodeRuneInString(s[i:])
+			if !isWordRune(r) {
+				i += size
+				continue
+			}
+			i = wordEnd(s, i)
+			c.add(category)
+		}
+	}
+}
+
+// command trata o comando em s[i] e retorna a posição seguinte a ele
+func (c *wordCounter) command(s string, i int, category wordCategory) int {
+	name := readName(s, i+1)
+	if name == "" {
+		if i+1 >= len(s) {
+			return i + 1
+		}
+		switch s[i+1] {
+		case '(':
+			return c.skipUntil(s, i, `\)`)
+		case '[':
+			return c.skipUntil(s, i, `\]`)
+		}
+		return i + 2 // \%, \&, \\, \,
+	}
+
+	j := i + 1 + len(name)
+	if j < len(s) && s[j] == '*' {
+		j++
+	}
+
+	switch {
+	case name == "begin":
+		env, after, ok := groupAt(s, j)
+		if !ok {
+			return j
+		}
+		c.skip(s[j:after])
+		if mathEnvs[env] || skippedEnvs[env] {
+			return c.skipUntil(s, after, `\end{`+env+`}`)
+		}
+		return c.skipArgs(s, after, envArgs[env])
+
+	case isSectionCommand(name):
+		title, after := c.lastArg(s, j)
+		if containsString(WordSectionLevels, name) {
+			c.sections = append(c.sections, SectionWords{Level: name, Title: PlainText(title), Line: c.line})
+		}
+		c.scan(title, categoryHeaders)
+		return after
+
+	case name == "caption":
+		arg, after := c.lastArg(s, j)
+		c.scan(arg, categoryCaptions)
+		return after
+
+	case name == "footnote" || name == "footnotetext":
+		arg, after := c.lastArg(s, j)
+		c.scan(arg, categoryFootnotes)
+		return after
+
+	case IsCitation(name):
+		return c.skipArgs(s, j, -1)
+	}
+
+	if n, ok := ignoredArgs[name]; ok {
+		return c.skipArgs(s, j, n)
+	}
+
+	// Demais comandos: o nome some e os argumentos obrigatórios são texto
+	// (\textbf{palavra}); os opcionais são descartados
+	for {
+		k := skipSpaces(s, j)
+		if k >= len(s) || (s[k] != '{' && s[k] != '[') {
+			return j
+		}
+		content, after, ok := groupAt(s, k)
+		if !ok {
+			return j
+		}
+		c.skip(s[j:k])
+		if s[k] == '[' {
+			c.skip(content)
+		} else {
+			c.scan(content, category)
+		}
+		j = after
+	}
+}
+
+// lastArg descarta os argumentos opcionais e retorna o obrigatório seguinte
+func (c *wordCounter) lastArg(s string, j int) (string, int) {
+	for {
+		k := skipSpaces(s, j)
+		content, after, ok := groupAt(s, k)
+		if !ok {
+			return "", j
+		}
+		c.skip(s[j:k])
+		if s[k] == '{' {
+			return content, after
+		}
+		c.skip(content)
+		j = after
+	}
+}
+
+// skipArgs descarta os opcionais e n argumentos obrigatórios (todos os
+// grupos seguintes com n < 0)
+func (c *wordCounter) skipArgs(s string, j, n int) int {
+	for {
+		k := skipSpaces(s, j)
+		if k >= len(s) || (s[k] != '{' && s[k] != '[') {
+			return j
+		}
+		if s[k] == '{' {
+			if n == 0 {
+				return j
+			}
+			n--
+		}
+		content, after, ok := groupAt(s, k)
+		if !ok {
+			return j
+		}
+		c.skip(s[j:k])
+		c.skip(content)
+		j = after
+	}
+}
+
+// skipUntil descarta tudo até o fim do delimitador informado
+func (c *wordCounter) skipUntil(s string, i int, end string) int {
+	k := strings.Index(s[i+1:], end)
+	if k < 0 {
+		c.skip(s[i:])
+		return len(s)
+	}
+	stop := i + 1 + k + len(end)
+	c.skip(s[i:stop])
+	return stop
+}
+
+// skipMath descarta $...$ e $$...$$
+func (c *wordCounter) skipMath(s string, i int) int {
+	delim := "$"
+	if strings.HasPrefix(s[i:], "$$") {
+		delim = "$$"
+	}
+
+	for j := i + len(delim); j < len(s); j++ {
+		switch {
+		case s[j] == '\\':
+			j++
+		case strings.HasPrefix(s[j:], delim):
+			c.skip(s[i:j])
+			return j + len(delim)
+		}
+	}
+	c.skip(s[i:])
+	return len(s)
+}
+
+// skipSpaces avança espaços e uma única quebra de linha (uma linha em branco
+// encerra os argumentos de um comando)
+func skipSpaces(s string, i int) int {
+	newline := false
+	for i < len(s) {
+		switch s[i] {
+		case ' ', '\t':
+		case '\n':
+			if newline {
+				return i
+			}
+			newline = true
+		default:
+			return i
+		}
+		i++
+	}
+	return i
+}
+
+// wordEnd retorna o fim da palavra iniciada em i. Hífens e apóstrofos entre
+// letras fazem parte da palavra.
+func wordEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if isWordRune(r) {
+			i += size
+			continue
+		}
+		if r == '-' || r == '\'' || r == '’' {
+			next, _ := utf8.DecodeRuneInString(s[i+size:])
+			if i+size < len(s) && isWordRune(next) {
+				i += size
+				continue
+			}
+		}
+		break
+	}
+	return i
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isSectionCommand(name string) bool {
+	return containsString(SectionLevels, name)
+}
+
+func containsString(list []string, value string) bool {
+	for _, item := range list {
+		if item == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package latex
+
+import "testing"
+
+func TestCountWords(t *testing.T) {
+	src := `\documentclass{article}
+\title{Não contado}
+\begin{document}
+Texto inicial com cinco palavras.
+\section{Introdução ao tema}\label{sec:intro}
+Um texto \textbf{em negrito} com $x + y$ e \cite[p.~3]{silva} guarda-chuva.
+% um comentário com muitas palavras
+\begin{figure}[h]
+  \includegraphics[width=\linewidth]{fig/a.png}
+  \caption[Curta]{Legenda da figura}
+\end{figure}
+\subsection{Detalhes}
+Nota\footnote{Texto da nota} final.
+\begin{equation} a = b \end{equation}
+\begin{tabular}{lcr} célula \end{tabular}
+\end{document}`
+
+	file := CountWords("main.tex", Strip(src))
+
+	expected := WordCount{Text: 5 + 7 + 2 + 1, Headers: 3 + 1, Captions: 3, Footnotes: 3}
+	if file.WordCount != expected {
+		t.Errorf("CountWords() = %+v, expected %+v", file.WordCount, expected)
+	}
+
+	if len(file.Sections) != 2 {
+		t.Fatalf("Sections = %+v, expected 2", file.Sections)
+	}
+	intro := file.Sections[1]
+	if intro.Level != "section" || intro.Title != "Introdução ao tema" || intro.Line != 5 {
+		t.Errorf("section = %+v, expected Introdução ao tema na linha 5", intro)
+	}
+	if file.Sections[0].Text != 5 || file.Sections[0].Line != 3 {
+		t.Errorf("trecho inicial = %+v, expected 5 palavras na linha 3", file.Sections[0])
+	}
+}
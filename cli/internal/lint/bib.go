@@ -0,0 +1,183 @@
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+)
+
+// requiredFields lista, por tipo de entrada, os campos obrigatórios do BibTeX
+// e do BibLaTeX; cada item aceita qualquer uma das alternativas
+var requiredFields = map[string][][]string{
+	"article":       {{"author"}, {"title"}, {"journal", "journaltitle"}, {"year", "date"}},
+	"book":          {{"author", "editor"}, {"title"}, {"publisher"}, {"year", "date"}},
+	"booklet":       {{"title"}},
+	"inbook":        {{"author", "editor"}, {"title"}, {"chapter", "pages"}, {"publisher"}, {"year", "date"}},
+	"incollection":  {{"author"}, {"title"}, {"booktitle"}, {"publisher"}, {"year", "date"}},
+	"inproceedings": {{"author"}, {"title"}, {"booktitle"}, {"year", "date"}},
+	"conference":    {{"author"}, {"title"}, {"booktitle"}, {"year", "date"}},
+	"manual":        {{"title"}},
+	"mastersthesis": {{"author"}, {"title"}, {"school", "institution"}, {"year", "date"}},
+	"phdthesis":     {{"author"}, {"title"}, {"school", "institution"}, {"year", "date"}},
+	"thesis":        {{"author"}, {"title"}, {"type"}, {"institution", "school"}, {"year", "date"}},
+	"proceedings":   {{"title"}, {"year", "date"}},
+	"techreport":    {{"author"}, {"title"}, {"institution"}, {"year", "date"}},
+	"report":        {{"author"}, {"title"}, {"type"}, {"institution"}, {"year", "date"}},
+	"unpublished":   {{"author"}, {"title"}, {"note"}},
+	"online":        {{"author", "editor", "organization"}, {"title"}, {"url", "doi", "eprint"}, {"year", "date"}},
+	"misc":          {},
+}
+
+// BibRules são as regras de 'ltx bib lint'
+var BibRules = []Rule{
+	{ID: "bib-syntax", Level: LevelError, Description: "erro de sintaxe no arquivo .bib"},
+	{ID: "bib-duplicate-key", Level: LevelError, Description: "chave definida mais de uma vez"},
+	{ID: "bib-missing-field", Level: LevelWarning, Description: "campo obrigatório ausente para o tipo da entrada"},
+	{ID: "bib-unknown-type", Level: LevelNote, Description: "tipo de entrada desconhecido"},
+	{ID: "bib-duplicate-doi", Level: LevelWarning, Description: "DOI repetido em entradas diferentes"},
+	{ID: "bib-similar-title", Level: LevelWarning, Description: "títulos iguais ou quase iguais em entradas diferentes"},
+}
+
+// similarTitleThreshold é a semelhança mínima (coeficiente de Dice sobre
+// pares de letras) para dois títulos serem considerados duplicados
+const similarTitleThreshold = 0.9
+
+// CheckBib verifica um banco de referências e retorna os resultados, sem os
+// das regras desativadas
+func CheckBib(db *bibtex.Database, disabled map[string]bool) []Finding {
+	var findings []Finding
+	add := func(rule string, e *bibtex.Entry, format string, args ...interface{}) {
+		if disabled[rule] {
+			return
+		}
+		level := LevelWarning
+		for _, r := range BibRules {
+			if r.ID == rule {
+				level = r.Level
+			}
+		}
+		findings = append(findings, Finding{Rule: rule, Level: level, Message: fmt.Sprintf(format, args...), File: e.File, Line: e.Line})
+	}
+
+	for _, err := range db.Errors {
+		if !disabled["bib-syntax"] {
+			findings = append(findings, Finding{Rule: "bib-syntax", Level: LevelError, Message: err.Message, File: err.File, Line: err.Line})
+		}
+	}
+
+	keys := make(map[string]*bibtex.Entry)
+	dois := make(map[string]*bibtex.Entry)
+	var titles []titleInfo
+
+	for _, e := range db.Entries {
+		if first, ok := keys[strings.ToLower(e.Key)]; ok {
+			add("bib-duplicate-key", e, "chave %q já definida em %s:%d", e.Key, first.File, first.Line)
+		} else {
+			keys[strings.ToLower(e.Key)] = e
+		}
+
+		required, known := requiredFields[e.Type]
+		if !known {
+			add("bib-unknown-type", e, "@%s{%s}: tipo de entrada desconhecido", e.Type, e.Key)
+		}
+		for _, alternatives := range required {
+			if !hasAny(e, alternatives) {
+				add("bib-missing-field", e, "@%s{%s}: campo obrigatório ausente: %s", e.Type, e.Key, strings.Join(alternatives, " ou "))
+			}
+		}
+
+		if doi := normalizeDOI(e.Get("doi")); doi != "" {
+			if first, ok := dois[doi]; ok {
+				add("bib-duplicate-doi", e, "%s: DOI %s também usado por %s", e.Key, doi, first.Key)
+			} else {
+				dois[doi] = e
+			}
+		}
+
+		if title := normalizeTitle(e.Get("title")); title != "" {
+			titles = append(titles, titleInfo{entry: e, title: title, bigrams: bigrams(title)})
+		}
+	}
+
+	for i := range titles {
+		for j := 0; j < i; j++ {
+			a, b := titles[j], titles[i]
+			if strings.EqualFold(a.entry.Key, b.entry.Key) {
+				continue
+			}
+			if a.title == b.title || dice(a.bigrams, b.bigrams) >= similarTitleThreshold {
+				add("bib-similar-title", b.entry, "%s: título semelhante ao de %s", b.entry.Key, a.entry.Key)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(findings, func(i, j int) bool {
+		if findings[i].File != findings[j].File {
+			return findings[i].File < findings[j].File
+		}
+		return findings[i].Line < findings[j].Line
+	})
+	return findings
+}
+
+func hasAny(e *bibtex.Entry, fields []string) bool {
+	for _, name := range fields {
+		if e.Has(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeDOI remove prefixos de URL e diferenças de caixa de um DOI
+func normalizeDOI(doi string) string {
+	doi = strings.ToLower(strings.TrimSpace(doi))
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return strings.ReplaceAll(doi, `\_`, "_")
+}
+
+// normalizeTitle reduz um título a letras e dígitos minúsculos, sem acentos
+func normalizeTitle(title string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(bibtex.Fold(bibtex.PlainText(title))) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+type titleInfo struct {
+	entry   *bibtex.Entry
+	title   string
+	bigrams map[string]int
+}
+
+func bigrams(s string) map[string]int {
+	result := make(map[string]int)
+	for i := 0; i+1 < len(s); i++ {
+		result[s[i:i+2]]++
+	}
+	return result
+}
+
+// dice calcula o coeficiente de Dice entre dois conjuntos de pares de letras
+func dice(a, b map[string]int) float64 {
+	total, common := 0, 0
+	for pair, n := range a {
+		total += n
+		common += min(n, b[pair])
+	}
+	for _, n := range b {
+		total += n
+	}
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(common) / float64(total)
+}
@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+)
+
+func TestCheckBib(t *testing.T) {
+	db := bibtex.Parse(`@article{silva2020,
+  author = {Silva, Ana}, title = {Deep Learning for {LaTeX}}, journal = {J}, year = 2020,
+  doi = {10.1000/ABC}
+}
+@article{Silva2020, author = {Silva, Ana}, title = {Outro}, journaltitle = {J}, date = {2020}}
+@book{livro, title = {Livro}, year = 2019}
+@inproceedings{conf, author = {Souza, J.}, title = {Deep learning for LaTeX.}, booktitle = {C}, year = 2021,
+  doi = {https://doi.org/10.1000/abc}}
+@misc{site, title = {Site}}
+@unknowntype{u, title = {U}}
+@article{quebrada, title = {Sem fim}
+`)
+
+	findings := CheckBib(db, map[string]bool{"bib-unknown-type": true})
+
+	var got []string
+	for _, f := range findings {
+		got = append(got, f.Rule+" "+f.Message)
+	}
+	expected := []string{
+		"bib-duplicate-key chave \"Silva2020\" já definida em :1",
+		"bib-missing-field @book{livro}: campo obrigatório ausente: author ou editor",
+		"bib-missing-field @book{livro}: campo obrigatório ausente: publisher",
+		"bib-duplicate-doi conf: DOI 10.1000/abc também usado por silva2020",
+		"bib-similar-title conf: título semelhante ao de silva2020",
+		"bib-syntax @article{quebrada: entrada não fechada",
+	}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("CheckBib() =\n%v\nexpected\n%v", got, expected)
+	}
+}
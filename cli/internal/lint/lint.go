@@ -0,0 +1,199 @@
+// Package lint verifica fontes LaTeX sem compilá-los, com regras sobre o
+// scanner de internal/latex, e formata os resultados em texto, JSON ou SARIF.
+package lint
+
+import (
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// Níveis dos resultados (os mesmos do SARIF)
+const (
+	LevelError   = "error"
+	LevelWarning = "warning"
+	LevelNote    = "note"
+)
+
+// Finding é um problema encontrado no documento
+type Finding struct {
+	Rule    string `json:"rule"`
+	Level   string `json:"level"`
+	Message string `json:"message"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Column  int    `json:"column,omitempty"`
+}
+
+// Rule descreve uma regra do linter
+type Rule struct {
+	ID          string
+	Level       string
+	Description string
+	check       func(p *latex.Project) []Finding
+}
+
+// Rules são as regras nativas, na ordem em que são executadas
+var Rules = []Rule{
+	{ID: "undefined-ref", Level: LevelError, Description: "\\ref para um rótulo que não existe", check: checkUndefinedRefs},
+	{ID: "duplicate-label", Level: LevelError, Description: "\\label definido mais de uma vez", check: checkDuplicateLabels},
+	{ID: "unused-label", Level: LevelNote, Description: "\\label nunca referenciado", check: checkUnusedLabels},
+	{ID: "undefined-cite", Level: LevelError, Description: "\\cite de uma chave ausente dos arquivos .bib", check: checkUndefinedCites},
+	{ID: "unbalanced-braces", Level: LevelError, Description: "chaves { } sem par", check: checkBraces},
+	{ID: "unbalanced-env", Level: LevelError, Description: "\\begin sem \\end correspondente (ou vice-versa)", check: checkEnvironments},
+	{ID: "dollar-display-math", Level: LevelWarning, Description: "$$ ... $$ em vez de \\[ ... \\]", check: checkDisplayMath},
+	{ID: "missing-tie", Level: LevelWarning, Description: "espaço em vez de ~ antes de \\ref ou \\cite", check: checkTies},
+}
+
+// FindRule retorna a regra nativa com o identificador informado
+func FindRule(id string) (Rule, bool) {
+	for _, rule := range Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Run executa as regras nativas que não estão em disabled e aplica os
+// comentários % ltx-lint-disable dos fontes
+func Run(p *latex.Project, disabled map[string]bool) []Finding {
+	var findings []Finding
+	for _, rule := range Rules {
+		if disabled[rule.ID] {
+			continue
+		}
+		for _, f := range rule.check(p) {
+			f.Rule = rule.ID
+			if f.Level == "" {
+				f.Level = rule.Level
+			}
+			findings = append(findings, f)
+		}
+	}
+
+	return Filter(p, findings, disabled)
+}
+
+// Filter descarta os resultados de regras desativadas e os suprimidos por
+// comentários nos fontes. Serve também para resultados de ferramentas
+// externas (chktex), cujas regras são identificadas como "chktex/N".
+func Filter(p *latex.Project, findings []Finding, disabled map[string]bool) []Finding {
+	suppressions := make(map[string]*suppression)
+	for _, f := range p.Files {
+		suppressions[cleanPath(f.Path)] = parseSuppressions(f.Source)
+	}
+
+	var kept []Finding
+	for _, f := range findings {
+		if disabled[f.Rule] {
+			continue
+		}
+		if s := suppressions[cleanPath(f.File)]; s != nil && s.suppressed(f.Rule, f.Line) {
+			continue
+		}
+		kept = append(kept, f)
+	}
+
+	sort.SliceStable(kept, func(i, j int) bool {
+		if kept[i].File != kept[j].File {
+			return kept[i].File < kept[j].File
+		}
+		if kept[i].Line != kept[j].Line {
+			return kept[i].Line < kept[j].Line
+		}
+		return kept[i].Column < kept[j].Column
+	})
+	return kept
+}
+
+// % ltx-lint-disable[-line|-next-line] [regra, ...]   e   % ltx-lint-enable [regra, ...]
+var directivePattern = regexp.MustCompile(`%\s*ltx-lint-(disable-next-line|disable-line|disable|enable)\b([^%\n]*)`)
+
+// suppression guarda os trechos em que cada regra está desativada. A chave ""
+// representa todas as regras.
+type suppression struct {
+	lines  map[int][]string    // linha -> regras
+	ranges map[string][][2]int // regra -> intervalos [início, fim]
+}
+
+func parseSuppressions(source string) *suppression {
+	s := &suppression{lines: make(map[int][]string), ranges: make(map[string][][2]int)}
+	open := make(map[string]int)
+
+	lines := strings.Split(source, "\n")
+	for i, line := range lines {
+		m := directivePattern.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+
+		n := i + 1
+		rules := splitRules(m[2])
+		switch m[1] {
+		case "disable-line":
+			s.lines[n] = append(s.lines[n], rules...)
+		case "disable-next-line":
+			s.lines[n+1] = append(s.lines[n+1], rules...)
+		case "disable":
+			for _, rule := range rules {
+				if _, ok := open[rule]; !ok {
+					open[rule] = n
+				}
+			}
+		case "enable":
+			if strings.TrimSpace(m[2]) == "" {
+				rules = nil
+				for rule := range open {
+					rules = append(rules, rule)
+				}
+			}
+			for _, rule := range rules {
+				if start, ok := open[rule]; ok {
+					s.ranges[rule] = append(s.ranges[rule], [2]int{start, n})
+					delete(open, rule)
+				}
+			}
+		}
+	}
+
+	for rule, start := range open {
+		s.ranges[rule] = append(s.ranges[rule], [2]int{start, len(lines)})
+	}
+	return s
+}
+
+// splitRules separa a lista de regras de uma diretiva; vazia significa todas
+func splitRules(s string) []string {
+	var rules []string
+	for _, field := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == ' ' || r == '\t' }) {
+		rules = append(rules, field)
+	}
+	if len(rules) == 0 {
+		return []string{""}
+	}
+	return rules
+}
+
+func (s *suppression) suppressed(rule string, line int) bool {
+	for _, r := range s.lines[line] {
+		if r == "" || r == rule {
+			return true
+		}
+	}
+	for _, key := range []string{"", rule} {
+		for _, span := range s.ranges[key] {
+			if line >= span[0] && line <= span[1] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cleanPath(path string) string {
+	return filepath.ToSlash(filepath.Clean(path))
+}
@@ -0,0 +1,123 @@
+package lint
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// loadProject cria main.tex (e refs.bib, se informado) e carrega o projeto
+func loadProject(t *testing.T, main, bib string) *latex.Project {
+	t.Helper()
+	dir := t.TempDir()
+
+	files := map[string]string{"main.tex": main}
+	if bib != "" {
+		files["refs.bib"] = bib
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p, err := latex.Load(filepath.Join(dir, "main.tex"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+// positions resume os resultados como "regra:linha:coluna"
+func positions(findings []Finding) []string {
+	var result []string
+	for _, f := range findings {
+		result = append(result, fmt.Sprintf("%s:%d:%d", f.Rule, f.Line, f.Column))
+	}
+	return result
+}
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		main     string
+		bib      string
+		expected []string
+	}{
+		{
+			name:     "referência indefinida e rótulo não usado",
+			main:     "\\section{A}\\label{sec:a}\nVer~\\ref{sec:b} e~\\cref{sec:a,fig:c}.\n\\label{tab:d}\n",
+			expected: []string{"undefined-ref:2:5", "undefined-ref:2:19", "unused-label:3:1"},
+		},
+		{
+			name:     "rótulo duplicado",
+			main:     "\\label{a}\n\\label{a}~\\ref{a}\n",
+			expected: []string{"duplicate-label:2:1"},
+		},
+		{
+			name:     "rótulo duplicado e não usado",
+			main:     "\\label{dup}\\label{dup}\n",
+			expected: []string{"unused-label:1:1", "duplicate-label:1:12"},
+		},
+		{
+			name:     "citação ausente do .bib",
+			main:     "Como em~\\cite{x,y}.\n\\bibliography{refs}\n",
+			bib:      "@book{x,}\n",
+			expected: []string{"undefined-cite:1:9"},
+		},
+		{
+			name:     "citação sem bibliografia não é verificada",
+			main:     "Como em~\\cite{x}.\n",
+			expected: nil,
+		},
+		{
+			name:     "chaves sem par, ignorando escapadas",
+			main:     "\\{ texto {a}\n}\n\\textbf{b\n",
+			expected: []string{"unbalanced-braces:2:1", "unbalanced-braces:3:8"},
+		},
+		{
+			name:     "ambientes sem par",
+			main:     "\\begin{itemize}\n\\end{enumerate}\n\\end{figure}\n\\begin{table}\n",
+			expected: []string{"unbalanced-env:2:1", "unbalanced-env:3:1", "unbalanced-env:4:1"},
+		},
+		{
+			name:     "$$ e espaço antes de \\ref",
+			main:     "$$ a $$ e \\$$ não\nTabela \\ref{t}\n\n\\ref{t} no início e (\\ref{t})\n\\label{t}\n",
+			expected: []string{"dollar-display-math:1:1", "missing-tie:2:8"},
+		},
+		{
+			name:     "comentários e verbatim são ignorados",
+			main:     "% \\ref{x} {\n\\begin{verbatim}\n\\ref{y} $$ {\n\\end{verbatim}\n",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			findings := Run(loadProject(t, tt.main, tt.bib), nil)
+			if result := positions(findings); !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Run() = %v, expected %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSuppressions(t *testing.T) {
+	main := "\\ref{a} % ltx-lint-disable-line\n" +
+		"% ltx-lint-disable-next-line undefined-ref\n" +
+		"x~\\ref{b}\n" +
+		"% ltx-lint-disable unused-label, missing-tie\n" +
+		"\\label{c} x \\ref{d}\n" +
+		"% ltx-lint-enable\n" +
+		"\\label{e} x \\ref{f}\n"
+
+	findings := Run(loadProject(t, main, ""), map[string]bool{"undefined-ref": true})
+	expected := []string{"unused-label:7:1", "missing-tie:7:13"}
+	if result := positions(findings); !reflect.DeepEqual(result, expected) {
+		t.Errorf("Run() = %v, expected %v", result, expected)
+	}
+}
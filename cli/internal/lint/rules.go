@@ -0,0 +1,272 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/bibtex"
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// refCommands referenciam rótulos (\cref e afins aceitam listas)
+var refCommands = map[string]bool{
+	"ref": true, "eqref": true, "pageref": true, "autoref": true, "nameref": true,
+	"vref": true, "cref": true, "Cref": true, "cpageref": true, "Cpageref": true,
+}
+
+// tieCommands devem ser precedidos de ~ quando seguem uma palavra
+var tieCommands = map[string]bool{"ref": true, "eqref": true, "pageref": true, "cite": true}
+
+// label é uma ocorrência de \label
+type label struct {
+	name string
+	cmd  latex.Command
+}
+
+func labels(p *latex.Project) []label {
+	var all []label
+	for _, cmd := range p.Commands() {
+		if cmd.Name == "label" {
+			if name := strings.TrimSpace(cmd.Arg(0)); name != "" {
+				all = append(all, label{name: name, cmd: cmd})
+			}
+		}
+	}
+	return all
+}
+
+// referencedLabels retorna os rótulos usados por \ref e afins (e por
+// \hyperref[rótulo]), com os comandos que os usam
+func referencedLabels(p *latex.Project) map[string][]latex.Command {
+	refs := make(map[string][]latex.Command)
+	for _, cmd := range p.Commands() {
+		switch {
+		case refCommands[cmd.Name]:
+			for _, name := range cmd.Keys() {
+				refs[name] = append(refs[name], cmd)
+			}
+		case cmd.Name == "hyperref" && len(cmd.Optional) > 0:
+			name := strings.TrimSpace(cmd.Optional[0])
+			refs[name] = append(refs[name], cmd)
+		}
+	}
+	return refs
+}
+
+func checkUndefinedRefs(p *latex.Project) []Finding {
+	defined := make(map[string]bool)
+	for _, l := range labels(p) {
+		defined[l.name] = true
+	}
+
+	var findings []Finding
+	for name, cmds := range referencedLabels(p) {
+		if defined[name] {
+			continue
+		}
+		for _, cmd := range cmds {
+			findings = append(findings, at(p, cmd, fmt.Sprintf("rótulo %q não definido", name)))
+		}
+	}
+	return findings
+}
+
+func checkDuplicateLabels(p *latex.Project) []Finding {
+	first := make(map[string]latex.Command)
+
+	var findings []Finding
+	for _, l := range labels(p) {
+		prev, ok := first[l.name]
+		if !ok {
+			first[l.name] = l.cmd
+			continue
+		}
+		findings = append(findings, at(p, l.cmd, fmt.Sprintf("rótulo %q já definido em %s:%d", l.name, prev.File, prev.Line)))
+	}
+	return findings
+}
+
+// checkUnusedLabels aponta cada rótulo não referenciado uma vez, na primeira
+// definição; as repetições já são apontadas por duplicate-label
+func checkUnusedLabels(p *latex.Project) []Finding {
+	used := referencedLabels(p)
+	reported := make(map[string]bool)
+
+	var findings []Finding
+	for _, l := range labels(p) {
+		if _, ok := used[l.name]; ok || reported[l.name] {
+			continue
+		}
+		reported[l.name] = true
+		findings = append(findings, at(p, l.cmd, fmt.Sprintf("rótulo %q nunca referenciado", l.name)))
+	}
+	return findings
+}
+
+// checkUndefinedCites compara as citações com as chaves dos arquivos .bib e
+// dos \bibitem. Sem nenhuma fonte de referências, a regra não se aplica.
+func checkUndefinedCites(p *latex.Project) []Finding {
+	keys := make(map[string]bool)
+	sources := 0
+
+	for _, bib := range p.BibFiles() {
+		db, err := bibtex.ParseFile(bib)
+		if err != nil {
+			continue
+		}
+		sources++
+		for _, entry := range db.Entries {
+			keys[entry.Key] = true
+		}
+	}
+	for _, cmd := range p.Commands() {
+		if cmd.Name == "bibitem" {
+			sources++
+			keys[strings.TrimSpace(cmd.Arg(0))] = true
+		}
+	}
+	if sources == 0 {
+		return nil
+	}
+
+	var findings []Finding
+	for _, cmd := range p.Commands() {
+		if !latex.IsCitation(cmd.Name) {
+			continue
+		}
+		for _, key := range cmd.Keys() {
+			if !keys[key] {
+				findings = append(findings, at(p, cmd, fmt.Sprintf("chave %q não encontrada na bibliografia", key)))
+			}
+		}
+	}
+	return findings
+}
+
+// checkBraces procura chaves sem par em cada arquivo, ignorando \{ e \}
+func checkBraces(p *latex.Project) []Finding {
+	var findings []Finding
+	for _, f := range p.Files {
+		var open []int // posições das chaves abertas
+		for i := 0; i < len(f.Text); i++ {
+			switch f.Text[i] {
+			case '\\':
+				i++
+			case '{':
+				open = append(open, i)
+			case '}':
+				if len(open) == 0 {
+					findings = append(findings, atOffset(f, i, "} sem { correspondente"))
+					continue
+				}
+				open = open[:len(open)-1]
+			}
+		}
+		for _, pos := range open {
+			findings = append(findings, atOffset(f, pos, "{ sem } correspondente"))
+		}
+	}
+	return findings
+}
+
+// checkEnvironments confere o aninhamento de \begin e \end em cada arquivo
+func checkEnvironments(p *latex.Project) []Finding {
+	var findings []Finding
+	for _, f := range p.Files {
+		var stack []latex.Command
+		for _, cmd := range f.Commands {
+			switch cmd.Name {
+			case "begin":
+				stack = append(stack, cmd)
+			case "end":
+				env := cmd.Arg(0)
+				if len(stack) == 0 {
+					findings = append(findings, at(p, cmd, fmt.Sprintf("\\end{%s} sem \\begin correspondente", env)))
+					continue
+				}
+				top := stack[len(stack)-1]
+				if top.Arg(0) != env {
+					findings = append(findings, at(p, cmd, fmt.Sprintf("\\end{%s} fecha \\begin{%s} da linha %d", env, top.Arg(0), top.Line)))
+				}
+				stack = stack[:len(stack)-1]
+			}
+		}
+		for _, cmd := range stack {
+			findings = append(findings, at(p, cmd, fmt.Sprintf("\\begin{%s} sem \\end correspondente", cmd.Arg(0))))
+		}
+	}
+	return findings
+}
+
+// checkDisplayMath aponta o $$ que abre cada bloco de matemática em destaque
+func checkDisplayMath(p *latex.Project) []Finding {
+	var findings []Finding
+	for _, f := range p.Files {
+		opening := true
+		for i := 0; i+1 < len(f.Text); i++ {
+			switch {
+			case f.Text[i] == '\\':
+				i++
+			case f.Text[i] == '$' && f.Text[i+1] == '$':
+				if opening {
+					findings = append(findings, atOffset(f, i, "use \\[ ... \\] em vez de $$ ... $$"))
+				}
+				opening = !opening
+				i++
+			}
+		}
+	}
+	return findings
+}
+
+// checkTies aponta \ref e \cite separados da palavra anterior por um espaço
+// que permite quebra de linha
+func checkTies(p *latex.Project) []Finding {
+	var findings []Finding
+	for _, f := range p.Files {
+		for _, cmd := range f.Commands {
+			if !tieCommands[cmd.Name] || !breakableSpaceBefore(f.Text, cmd.Offset) {
+				continue
+			}
+			findings = append(findings, at(p, cmd, fmt.Sprintf("use ~ antes de \\%s para evitar quebra de linha", cmd.Name)))
+		}
+	}
+	return findings
+}
+
+// breakableSpaceBefore indica se o texto antes da posição termina em palavra
+// seguida de espaço ou de uma quebra de linha simples
+func breakableSpaceBefore(text string, offset int) bool {
+	i := offset - 1
+	newlines := 0
+	for i >= 0 && (text[i] == ' ' || text[i] == '\t' || text[i] == '\n') {
+		if text[i] == '\n' {
+			newlines++
+		}
+		i--
+	}
+	if i == offset-1 || i < 0 || newlines > 1 {
+		return false
+	}
+
+	c := text[i]
+	return c >= 0x80 || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9') || c == '.' || c == ','
+}
+
+// at cria um resultado na posição de um comando
+func at(p *latex.Project, cmd latex.Command, message string) Finding {
+	for _, f := range p.Files {
+		if f.Path == cmd.File {
+			return atOffset(f, cmd.Offset, message)
+		}
+	}
+	return Finding{File: cmd.File, Line: cmd.Line, Message: message}
+}
+
+// atOffset cria um resultado em uma posição do texto de um arquivo
+func atOffset(f *latex.File, offset int, message string) Finding {
+	before := f.Text[:offset]
+	line := strings.Count(before, "\n") + 1
+	column := offset - strings.LastIndex(before, "\n")
+	return Finding{File: f.Path, Line: line, Column: column, Message: message}
+}
@@ -0,0 +1,100 @@
+package lock
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/distribution/reference"
+	"gopkg.in/yaml.v3"
+)
+
+// File é o arquivo de lock, na raiz do projeto. Deve ser versionado.
+const File = "ltx.lock"
+
+const header = `# Gerado por 'ltx lock update' - não edite manualmente.
+# Fixa a imagem LaTeX por digest para compilações reproduzíveis.
+`
+
+// Lock registra a versão exata do ambiente de compilação
+type Lock struct {
+	Image     string    `yaml:"image"`   // referência configurada (ex: texlive/texlive:latest)
+	Digest    string    `yaml:"digest"`  // referência por conteúdo (repo@sha256:...)
+	TexLive   string    `yaml:"texlive"` // ano do TeX Live da imagem
+	Packages  Packages  `yaml:"packages"`
+	UpdatedAt time.Time `yaml:"updated_at"`
+}
+
+// Packages lista os extras instalados sobre a imagem travada
+type Packages struct {
+	Tex      []string `yaml:"tex,omitempty"`      // TEX_PACKAGES (imagem derivada)
+	Apt      []string `yaml:"apt,omitempty"`      // APT_PACKAGES (imagem derivada)
+	Pip      []string `yaml:"pip,omitempty"`      // PIP_PACKAGES (imagem derivada)
+	Recorded []string `yaml:"recorded,omitempty"` // ltx-packages.txt (volume latex-cache)
+}
+
+// Load lê o lock do projeto. Retorna nil, sem erro, se o arquivo não existir.
+func Load(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var l Lock
+	if err := yaml.Unmarshal(data, &l); err != nil {
+		return nil, fmt.Errorf("erro ao ler %s: %w", path, err)
+	}
+	if l.Digest == "" {
+		return nil, fmt.Errorf("%s não contém o digest da imagem", path)
+	}
+
+	return &l, nil
+}
+
+// Save grava o lock no caminho informado
+func (l *Lock) Save(path string) error {
+	data, err := yaml.Marshal(l)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append([]byte(header), data...), 0644)
+}
+
+// DigestFor escolhe, entre os RepoDigests de uma imagem, o que pertence ao
+// mesmo repositório da referência (ex: texlive/texlive:latest)
+func DigestFor(ref string, repoDigests []string) (string, error) {
+	named, err := reference.ParseNormalizedNamed(ref)
+	if err != nil {
+		return "", fmt.Errorf("referência de imagem inválida %s: %w", ref, err)
+	}
+
+	for _, candidate := range repoDigests {
+		digested, err := reference.ParseNormalizedNamed(candidate)
+		if err != nil {
+			continue
+		}
+		if digested.Name() == named.Name() {
+			return reference.FamiliarString(digested), nil
+		}
+	}
+
+	return "", fmt.Errorf("imagem %s não possui digest de registro (foi construída localmente?)", ref)
+}
+
+// Matches indica se algum dos RepoDigests de uma imagem local corresponde ao lock
+func (l *Lock) Matches(repoDigests []string) bool {
+	for _, candidate := range repoDigests {
+		if candidate == l.Digest {
+			return true
+		}
+		// RepoDigests podem vir com o nome completo (docker.io/library/...)
+		if named, err := reference.ParseNormalizedNamed(candidate); err == nil && reference.FamiliarString(named) == l.Digest {
+			return true
+		}
+	}
+	return false
+}
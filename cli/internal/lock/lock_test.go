@@ -0,0 +1,102 @@
+package lock
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestDigestFor(t *testing.T) {
+	repoDigests := []string{
+		"outra/imagem@" + testDigest,
+		"texlive/texlive@" + testDigest,
+	}
+
+	tests := []struct {
+		name     string
+		ref      string
+		digests  []string
+		expected string
+		wantErr  bool
+	}{
+		{
+			name:     "tag flutuante",
+			ref:      "texlive/texlive:latest",
+			digests:  repoDigests,
+			expected: "texlive/texlive@" + testDigest,
+		},
+		{
+			name:     "imagem oficial",
+			ref:      "ubuntu:22.04",
+			digests:  []string{"ubuntu@" + testDigest},
+			expected: "ubuntu@" + testDigest,
+		},
+		{
+			name:    "imagem local sem digest",
+			ref:     "ltx-local/latex:abc",
+			digests: nil,
+			wantErr: true,
+		},
+		{
+			name:    "referência inválida",
+			ref:     "INVALIDA",
+			digests: repoDigests,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DigestFor(tt.ref, tt.digests)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DigestFor() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.expected {
+				t.Errorf("DigestFor() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMatches(t *testing.T) {
+	l := &Lock{Digest: "ubuntu@" + testDigest}
+
+	if !l.Matches([]string{"docker.io/library/ubuntu@" + testDigest}) {
+		t.Error("Matches() deveria aceitar o nome completo do repositório")
+	}
+	if l.Matches([]string{"ubuntu@sha256:ffff"}) {
+		t.Error("Matches() não deveria aceitar outro digest")
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), File)
+
+	missing, err := Load(path)
+	if err != nil || missing != nil {
+		t.Fatalf("Load() em arquivo inexistente = %v, %v", missing, err)
+	}
+
+	original := &Lock{
+		Image:     "texlive/texlive:latest",
+		Digest:    "texlive/texlive@" + testDigest,
+		TexLive:   "2024",
+		Packages:  Packages{Tex: []string{"enumitem"}, Recorded: []string{"koma-script"}},
+		UpdatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	if err := original.Save(path); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !reflect.DeepEqual(loaded, original) {
+		t.Errorf("Load() = %+v, expected %+v", loaded, original)
+	}
+}
@@ -0,0 +1,123 @@
+package texlive
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// BlgMessage é um aviso ou erro do BibTeX ou do biber
+type BlgMessage struct {
+	Level   string `json:"level"` // error ou warning
+	Message string `json:"message"`
+	File    string `json:"file,omitempty"`
+	Line    int    `json:"line,omitempty"`
+}
+
+// BlgReport é o resultado de um arquivo .blg
+type BlgReport struct {
+	Backend  string       `json:"backend"` // bibtex ou biber
+	Messages []BlgMessage `json:"messages"`
+}
+
+// Count retorna quantas mensagens há com o nível informado
+func (r BlgReport) Count(level string) int {
+	count := 0
+	for _, m := range r.Messages {
+		if m.Level == level {
+			count++
+		}
+	}
+	return count
+}
+
+var (
+	// [123] Biber.pm:131> WARN - mensagem
+	biberPattern = regexp.MustCompile(`^\[\d+\] [^>]*> (INFO|WARN|ERROR) - (.*)$`)
+	// BibTeX subsystem: /tmp/.../refs.bib_123.utf8, line 4, syntax error: ...
+	biberSourcePattern = regexp.MustCompile(`(?:BibTeX subsystem: )?(\S+?\.bib)(?:_\d+\.utf8)?, line (\d+), (.*)$`)
+	// I was expecting a `,' or a `}'---line 12 of file refs.bib
+	bibtexLinePattern = regexp.MustCompile(`^(.*)---line (\d+) of file (.+)$`)
+	// --line 5 of file refs.bib (continuação de um aviso)
+	bibtexWarningLinePattern = regexp.MustCompile(`^--line (\d+) of file (.+)$`)
+)
+
+// ParseBlg interpreta o arquivo .blg do BibTeX ou do biber
+func ParseBlg(content string) BlgReport {
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+
+	report := BlgReport{Backend: latex.BackendBibtex}
+	for _, line := range lines {
+		if biberPattern.MatchString(line) {
+			report.Backend = latex.BackendBiber
+			break
+		}
+	}
+
+	if report.Backend == latex.BackendBiber {
+		report.Messages = parseBiberLog(lines)
+	} else {
+		report.Messages = parseBibtexLog(lines)
+	}
+	return report
+}
+
+func parseBiberLog(lines []string) []BlgMessage {
+	var messages []BlgMessage
+	for _, line := range lines {
+		m := biberPattern.FindStringSubmatch(line)
+		if m == nil || m[1] == "INFO" {
+			continue
+		}
+
+		msg := BlgMessage{Level: "warning", Message: strings.TrimSpace(m[2])}
+		if m[1] == "ERROR" {
+			msg.Level = "error"
+		}
+		if src := biberSourcePattern.FindStringSubmatch(msg.Message); src != nil {
+			msg.File = baseBibName(src[1])
+			msg.Line, _ = strconv.Atoi(src[2])
+			msg.Message = src[3]
+		}
+		messages = append(messages, msg)
+	}
+	return messages
+}
+
+func parseBibtexLog(lines []string) []BlgMessage {
+	var messages []BlgMessage
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "Warning--"):
+			msg := BlgMessage{Level: "warning", Message: strings.TrimPrefix(line, "Warning--")}
+			if i+1 < len(lines) {
+				if m := bibtexWarningLinePattern.FindStringSubmatch(lines[i+1]); m != nil {
+					msg.Line, _ = strconv.Atoi(m[1])
+					msg.File = m[2]
+				}
+			}
+			messages = append(messages, msg)
+
+		case bibtexLinePattern.MatchString(line) && !strings.HasPrefix(line, "--line"):
+			m := bibtexLinePattern.FindStringSubmatch(line)
+			n, _ := strconv.Atoi(m[2])
+			messages = append(messages, BlgMessage{Level: "error", Message: strings.TrimSpace(m[1]), File: m[3], Line: n})
+
+		case strings.HasPrefix(line, "I couldn't open") || strings.HasPrefix(line, "I found no "):
+			messages = append(messages, BlgMessage{Level: "error", Message: strings.TrimSpace(line)})
+		}
+	}
+	return messages
+}
+
+// baseBibName remove o diretório temporário do biber (/tmp/biber_tmp_x/refs.bib)
+func baseBibName(path string) string {
+	if strings.Contains(path, "biber_tmp") {
+		if i := strings.LastIndex(path, "/"); i >= 0 {
+			return path[i+1:]
+		}
+	}
+	return path
+}
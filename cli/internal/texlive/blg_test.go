@@ -0,0 +1,62 @@
+package texlive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseBlg(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		backend  string
+		expected []BlgMessage
+	}{
+		{
+			name: "bibtex",
+			content: `This is BibTeX, Version 0.99d (TeX Live 2023)
+The top-level auxiliary file: dist/main.aux
+The style file: plainnat.bst
+Database file #1: src/references.bib
+Warning--I didn't find a database entry for "ausente"
+Warning--empty journal in silva2020
+--line 12 of file src/references.bib
+I was expecting a ` + "`,' or a `}'" + `---line 30 of file src/references.bib
+ :   title = {x}
+ :           ^
+I'm skipping whatever remains of this entry
+(There were 2 warnings)
+(There was 1 error message)`,
+			backend: "bibtex",
+			expected: []BlgMessage{
+				{Level: "warning", Message: `I didn't find a database entry for "ausente"`},
+				{Level: "warning", Message: "empty journal in silva2020", File: "src/references.bib", Line: 12},
+				{Level: "error", Message: "I was expecting a `,' or a `}'", File: "src/references.bib", Line: 30},
+			},
+		},
+		{
+			name: "biber",
+			content: `[0] Config.pm:307> INFO - This is Biber 2.19
+[45] Biber.pm:424> WARN - Duplicate entry key 'x' in file 'src/refs.bib', skipping ...
+[60] Utils.pm:411> ERROR - BibTeX subsystem: /tmp/biber_tmp_XyZ/refs.bib_1234.utf8, line 4, syntax error: found "title", expected end of entry ("}" or ")") (skipping to next "@")
+[70] Biber.pm:136> INFO - ERRORS: 1`,
+			backend: "biber",
+			expected: []BlgMessage{
+				{Level: "warning", Message: "Duplicate entry key 'x' in file 'src/refs.bib', skipping ..."},
+				{Level: "error", Message: `syntax error: found "title", expected end of entry ("}" or ")") (skipping to next "@")`, File: "refs.bib", Line: 4},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := ParseBlg(tt.content)
+			if report.Backend != tt.backend {
+				t.Errorf("Backend = %q, expected %q", report.Backend, tt.backend)
+			}
+			if !reflect.DeepEqual(report.Messages, tt.expected) {
+				t.Errorf("Messages =\n%+v\nexpected\n%+v", report.Messages, tt.expected)
+			}
+		})
+	}
+}
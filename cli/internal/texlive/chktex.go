@@ -0,0 +1,69 @@
+package texlive
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// chktexFormat produz uma linha por aviso: arquivo:linha:coluna:tipo:número:mensagem
+const chktexFormat = `%f:%l:%c:%k:%n:%m\n`
+
+// ChktexWarning é um aviso emitido pelo chktex
+type ChktexWarning struct {
+	File    string
+	Line    int
+	Column  int
+	Kind    string // Warning, Error ou Message
+	Number  int
+	Message string
+}
+
+// Chktex verifica um documento com o chktex, seguindo \input e \include
+func Chktex(ctx context.Context, runner Runner, target string) ([]ChktexWarning, error) {
+	result, err := runner.Run(ctx, []string{"chktex", "-q", "-f", chktexFormat, target})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao executar chktex: %w", err)
+	}
+
+	// 0: nada encontrado; 2: avisos; 3: erros
+	switch result.ExitCode {
+	case 0, 2, 3:
+	default:
+		return nil, fmt.Errorf("chktex falhou (código %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	return parseChktexOutput(result.Stdout), nil
+}
+
+// parseChktexOutput interpreta a saída no formato chktexFormat, ignorando
+// linhas que não o seguem
+func parseChktexOutput(output string) []ChktexWarning {
+	var warnings []ChktexWarning
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.SplitN(strings.TrimRight(line, "\r"), ":", 6)
+		if len(fields) != 6 {
+			continue
+		}
+
+		lineNum, err1 := strconv.Atoi(fields[1])
+		column, err2 := strconv.Atoi(fields[2])
+		number, err3 := strconv.Atoi(fields[4])
+		if err1 != nil || err2 != nil || err3 != nil {
+			continue
+		}
+
+		warnings = append(warnings, ChktexWarning{
+			File:    fields[0],
+			Line:    lineNum,
+			Column:  column,
+			Kind:    fields[3],
+			Number:  number,
+			Message: strings.TrimSpace(fields[5]),
+		})
+	}
+
+	return warnings
+}
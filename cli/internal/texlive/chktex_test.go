@@ -0,0 +1,28 @@
+package texlive
+
+import (
+	"testing"
+)
+
+func TestParseChktexOutput(t *testing.T) {
+	output := "src/main.tex:12:5:Warning:1:Command terminated with space.\n" +
+		"src/chapters/cap1.tex:3:18:Error:17:Number of `(' doesn't match the number of `)'!\n" +
+		"src/main.tex:40:1:Message:30:Multiple spaces detected in output: a: b\n" +
+		"ChkTeX v1.7.8 - Copyright 1995-96 Jens T. Berger Thielemann.\n"
+
+	warnings := parseChktexOutput(output)
+	if len(warnings) != 3 {
+		t.Fatalf("parseChktexOutput() = %d avisos, expected 3: %+v", len(warnings), warnings)
+	}
+
+	first := warnings[0]
+	if first.File != "src/main.tex" || first.Line != 12 || first.Column != 5 || first.Kind != "Warning" || first.Number != 1 {
+		t.Errorf("warnings[0] = %+v", first)
+	}
+	if warnings[1].Kind != "Error" || warnings[1].Number != 17 {
+		t.Errorf("warnings[1] = %+v, expected Error 17", warnings[1])
+	}
+	if warnings[2].Message != "Multiple spaces detected in output: a: b" {
+		t.Errorf("warnings[2].Message = %q, expected mensagem com dois-pontos preservados", warnings[2].Message)
+	}
+}
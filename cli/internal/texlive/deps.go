@@ -0,0 +1,69 @@
+package texlive
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// DependencyFile converte o nome de um pacote declarado em template.yaml
+// no arquivo procurado pelo kpsewhich (hyperref -> hyperref.sty)
+func DependencyFile(dep string) string {
+	dep = strings.TrimSpace(dep)
+	if path.Ext(dep) != "" {
+		return dep
+	}
+	return dep + ".sty"
+}
+
+// CheckDependencies verifica, em uma única chamada ao kpsewhich, quais
+// dependências não estão disponíveis na instalação TeX do runner
+func CheckDependencies(ctx context.Context, runner Runner, deps []string) ([]string, error) {
+	if len(deps) == 0 {
+		return nil, nil
+	}
+
+	files := make([]string, 0, len(deps))
+	for _, dep := range deps {
+		if strings.TrimSpace(dep) == "" {
+			continue
+		}
+		files = append(files, DependencyFile(dep))
+	}
+
+	cmd := append([]string{"kpsewhich"}, files...)
+	result, err := runner.Run(ctx, cmd)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao executar kpsewhich: %w", err)
+	}
+
+	// kpsewhich retorna 1 quando algum arquivo não é encontrado,
+	// mas ainda imprime os caminhos dos que existem
+	if result.ExitCode > 1 {
+		return nil, fmt.Errorf("kpsewhich falhou (código %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	return missingFromKpsewhich(files, result.Stdout), nil
+}
+
+// missingFromKpsewhich compara os arquivos pedidos com os caminhos resolvidos
+func missingFromKpsewhich(files []string, output string) []string {
+	found := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		found[path.Base(line)] = true
+	}
+
+	var missing []string
+	for _, file := range files {
+		if !found[path.Base(file)] {
+			missing = append(missing, file)
+		}
+	}
+
+	return missing
+}
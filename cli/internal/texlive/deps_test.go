@@ -0,0 +1,89 @@
+package texlive
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// fakeRunner devolve respostas pré-definidas indexadas pelo comando
+type fakeRunner struct {
+	responses map[string]*Result
+	calls     [][]string
+}
+
+func (f *fakeRunner) Run(ctx context.Context, cmd []string) (*Result, error) {
+	f.calls = append(f.calls, cmd)
+	if result, ok := f.responses[strings.Join(cmd, " ")]; ok {
+		return result, nil
+	}
+	return &Result{ExitCode: 1}, nil
+}
+
+func (f *fakeRunner) Name() string {
+	return "fake"
+}
+
+func TestDependencyFile(t *testing.T) {
+	tests := []struct {
+		dep      string
+		expected string
+	}{
+		{"hyperref", "hyperref.sty"},
+		{" amsmath ", "amsmath.sty"},
+		{"abntex2.cls", "abntex2.cls"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.dep, func(t *testing.T) {
+			if got := DependencyFile(tt.dep); got != tt.expected {
+				t.Errorf("DependencyFile(%q) = %q, expected %q", tt.dep, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCheckDependencies(t *testing.T) {
+	runner := &fakeRunner{responses: map[string]*Result{
+		"kpsewhich hyperref.sty foo.sty amsmath.sty": {
+			Stdout:   "/usr/local/texlive/texmf-dist/tex/latex/hyperref/hyperref.sty\n/usr/local/texlive/texmf-dist/tex/latex/amsmath/amsmath.sty\n",
+			ExitCode: 1,
+		},
+	}}
+
+	missing, err := CheckDependencies(context.Background(), runner, []string{"hyperref", "foo", "amsmath"})
+	if err != nil {
+		t.Fatalf("CheckDependencies() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(missing, []string{"foo.sty"}) {
+		t.Errorf("CheckDependencies() = %v, expected [foo.sty]", missing)
+	}
+
+	if len(runner.calls) != 1 {
+		t.Errorf("kpsewhich deveria ser chamado uma única vez, foi chamado %d vezes", len(runner.calls))
+	}
+}
+
+func TestCheckDependenciesEmpty(t *testing.T) {
+	runner := &fakeRunner{}
+
+	missing, err := CheckDependencies(context.Background(), runner, nil)
+	if err != nil || missing != nil {
+		t.Errorf("CheckDependencies(nil) = %v, %v", missing, err)
+	}
+	if len(runner.calls) != 0 {
+		t.Errorf("Nenhum comando deveria ser executado sem dependências")
+	}
+}
+
+func TestCheckDependenciesFailure(t *testing.T) {
+	runner := &fakeRunner{responses: map[string]*Result{
+		"kpsewhich foo.sty": {Stderr: "kpsewhich: command not found", ExitCode: 127},
+	}}
+
+	if _, err := CheckDependencies(context.Background(), runner, []string{"foo"}); err == nil {
+		t.Error("CheckDependencies() deveria falhar quando kpsewhich não executa")
+	}
+}
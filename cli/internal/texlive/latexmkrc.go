@@ -0,0 +1,188 @@
+package texlive
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// pdfModes são os valores de $pdf_mode de cada engine
+var pdfModes = map[string]int{"pdflatex": 1, "lualatex": 4, "xelatex": 5}
+
+// Latexmkrc é a configuração do latexmk gerada pelo ltx para um documento:
+// tudo o que o build passaria na linha de comando, para que 'latexmk -r'
+// reproduza a compilação também dentro de 'ltx shell'
+type Latexmkrc struct {
+	Target      string            // documento principal (src/main.tex)
+	Engine      string            // pdflatex, xelatex ou lualatex
+	OutDir      string            // diretório do PDF
+	AuxDir      string            // diretório dos auxiliares (.aux, .log...); vazio usa OutDir
+	TexOptions  []string          // opções repassadas à engine (-synctex=1, -shell-restricted...)
+	Env         map[string]string // variáveis de ambiente da compilação
+	SearchPaths map[string]string // caminhos acrescentados a TEXINPUTS, BIBINPUTS...
+	BibtexUse   int               // $bibtex_use: 0 desativa, 2 executa BibTeX/biber
+	BibBackend  string            // bibtex, biber ou none (BIBLIOGRAPHY_BACKEND resolvido)
+	MaxRepeat   int               // $max_repeat (0 mantém o padrão do latexmk)
+	Indexing    latex.Indexing    // glossários, nomenclaturas e índices
+	Extra       string            // código Perl acrescentado ao fim (LATEXMK_EXTRA)
+}
+
+// Render escreve o latexmkrc (Perl). Os arquivos intermediários de
+// glossários e índices ficam com os auxiliares: os programas recebem o
+// caminho base ("tmp/main").
+func (rc Latexmkrc) Render() string {
+	var b strings.Builder
+	b.WriteString("# Gerado pelo ltx a partir da configuração e dos fontes; não edite.\n")
+	b.WriteString("# Use LATEXMK_EXTRA na configuração para acrescentar código.\n")
+
+	b.WriteString("\n# Documento e diretórios\n")
+	if rc.Target != "" {
+		fmt.Fprintf(&b, "@default_files = (%s);\n", perlList([]string{rc.Target}))
+	}
+	if rc.OutDir != "" {
+		fmt.Fprintf(&b, "$out_dir = %s;\n", perlString(rc.OutDir))
+	}
+	if rc.AuxDir != "" && rc.AuxDir != rc.OutDir {
+		// As engines do TeX Live não têm -aux-directory: o latexmk compila no
+		// diretório auxiliar e move o PDF para $out_dir
+		fmt.Fprintf(&b, "$aux_dir = %s;\n", perlString(rc.AuxDir))
+		b.WriteString("$emulate_aux = 1;\n")
+	}
+
+	b.WriteString("\n# Engine e opções do TeX\n")
+	if mode, ok := pdfModes[rc.Engine]; ok {
+		fmt.Fprintf(&b, "$pdf_mode = %d;\n", mode)
+		b.WriteString("$postscript_mode = $dvi_mode = 0;\n")
+	}
+	fmt.Fprintf(&b, "set_tex_cmds(%s);\n", perlString(strings.Join(append(append([]string(nil), rc.TexOptions...), "%O", "%S"), " ")))
+	b.WriteString("$recorder = 1;\n")
+	if rc.MaxRepeat > 0 {
+		fmt.Fprintf(&b, "$max_repeat = %d;\n", rc.MaxRepeat)
+	}
+
+	if len(rc.Env) > 0 || len(rc.SearchPaths) > 0 {
+		b.WriteString("\n# Ambiente\n")
+		for _, name := range sortedKeys(rc.SearchPaths) {
+			fmt.Fprintf(&b, "ensure_path(%s, %s);\n", perlString(name), perlString(rc.SearchPaths[name]))
+		}
+		for _, name := range sortedKeys(rc.Env) {
+			fmt.Fprintf(&b, "$ENV{%s} = %s;\n", perlString(name), perlString(rc.Env[name]))
+		}
+	}
+
+	b.WriteString("\n# Bibliografia: 2 executa o BibTeX ou o biber quando necessário, 0 desativa\n")
+	fmt.Fprintf(&b, "$bibtex_use = %d;\n", rc.BibtexUse)
+	switch rc.BibBackend {
+	case "biber":
+		// O biber lê o .bcf nos auxiliares e encontra os .bib pelo kpsewhich (BIBINPUTS)
+		b.WriteString("$biber = 'biber %O %S';\n")
+		b.WriteString("push @generated_exts, 'bcf', 'run.xml';\n")
+	case "bibtex":
+		// Com $aux_dir o BibTeX roda no diretório dos auxiliares ($bibtex_fudge)
+		b.WriteString("$bibtex = 'bibtex %O %S';\n")
+		b.WriteString("$bibtex_fudge = 1;\n")
+	}
+
+	ix := rc.Indexing
+	if ix.Glossaries {
+		b.WriteString("\n# Glossários e siglas (glossaries): makeglossaries\n")
+		var exts []string
+		for _, t := range ix.Types {
+			if ix.Nomencl && t.Out == "nlo" {
+				continue // as extensões do nomencl têm precedência
+			}
+			fmt.Fprintf(&b, "add_cus_dep(%s, %s, 0, 'ltx_makeglossaries');\n", perlString(t.Out), perlString(t.In))
+			exts = append(exts, t.Out, t.In, t.Log)
+		}
+		b.WriteString(`sub ltx_makeglossaries {
+    my ($base, $path) = fileparse($_[0]);
+    my @args = ('-d', $path, $base);
+    unshift @args, '-q' if $silent;
+    return system('makeglossaries', @args);
+}
+`)
+		fmt.Fprintf(&b, "push @generated_exts, %s;\n", perlList(exts))
+		b.WriteString("$clean_ext .= ' %R.ist %R.xdy';\n")
+	}
+
+	if ix.Bib2gls {
+		b.WriteString("\n# Glossários com bib2gls (glossaries-extra com record)\n")
+		b.WriteString(`add_cus_dep('aux', 'glstex', 0, 'ltx_bib2gls');
+sub ltx_bib2gls {
+    my ($base, $path) = fileparse($_[0]);
+    my @args = ('--group', '--dir', $path, $base);
+    unshift @args, '--silent' if $silent;
+    my $ret = system('bib2gls', @args);
+    # Os .bib lidos passam a ser dependências da regra
+    if (!$ret && open(my $log, '<', "$_[0].glg")) {
+        while (<$log>) {
+            rdb_ensure_file($rule, $1) if /^Reading (.*\.bib)\s*$/;
+        }
+        close($log);
+    }
+    return $ret;
+}
+push @generated_exts, 'glstex', 'glg';
+`)
+	}
+
+	if ix.Nomencl {
+		b.WriteString("\n# Nomenclatura (nomencl): makeindex com o estilo nomencl.ist\n")
+		b.WriteString(`add_cus_dep('nlo', 'nls', 0, 'ltx_makenomenclature');
+sub ltx_makenomenclature {
+    return system('makeindex', '-s', 'nomencl.ist', '-t', "$_[0].nlg", '-o', "$_[0].nls", "$_[0].nlo");
+}
+push @generated_exts, 'nlo', 'nls', 'nlg';
+`)
+	}
+
+	if ix.Index {
+		program := "makeindex"
+		if ix.Xindy {
+			program = "texindy"
+			b.WriteString("\n# Índice processado pelo xindy\n")
+			b.WriteString("$makeindex = 'texindy %O -o %D %S';\n")
+		}
+
+		if len(ix.Indexes) > 0 {
+			fmt.Fprintf(&b, "\n# Índices nomeados do imakeidx (%s)\n", strings.Join(ix.Indexes, ", "))
+			b.WriteString("add_cus_dep('idx', 'ind', 0, 'ltx_makeindex');\n")
+			b.WriteString("sub ltx_makeindex {\n")
+			fmt.Fprintf(&b, "    return system('%s', '-o', \"$_[0].ind\", \"$_[0].idx\");\n", program)
+			b.WriteString("}\n")
+		}
+		b.WriteString("push @generated_exts, 'idx', 'ind', 'ilg';\n")
+	}
+
+	if extra := strings.TrimSpace(rc.Extra); extra != "" {
+		b.WriteString("\n# LATEXMK_EXTRA\n")
+		b.WriteString(extra + "\n")
+	}
+
+	return b.String()
+}
+
+// perlString escreve uma string literal em Perl ('texto', sem interpolação)
+func perlString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// perlList escreve uma lista de strings em Perl ('a', 'b')
+func perlList(items []string) string {
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = perlString(item)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,172 @@
+package texlive
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+func TestLatexmkrcRender(t *testing.T) {
+	tests := []struct {
+		name     string
+		indexing latex.Indexing
+		contains []string
+		absent   []string
+	}{
+		{
+			name: "glossaries com siglas",
+			indexing: latex.Indexing{Glossaries: true, Types: []latex.GlossaryType{
+				{Name: "main", Log: "glg", In: "gls", Out: "glo"},
+				{Name: "acronym", Log: "alg", In: "acr", Out: "acn"},
+			}},
+			contains: []string{
+				"add_cus_dep('glo', 'gls', 0, 'ltx_makeglossaries');",
+				"add_cus_dep('acn', 'acr', 0, 'ltx_makeglossaries');",
+				"system('makeglossaries', @args)",
+				"push @generated_exts, 'glo', 'gls', 'glg', 'acn', 'acr', 'alg';",
+			},
+			absent: []string{"bib2gls", "nomencl"},
+		},
+		{
+			name: "nomencl tem precedência sobre o glossário numbers",
+			indexing: latex.Indexing{Glossaries: true, Nomencl: true, Types: []latex.GlossaryType{
+				{Name: "main", Log: "glg", In: "gls", Out: "glo"},
+				{Name: "numbers", Log: "nlg", In: "nls", Out: "nlo"},
+			}},
+			contains: []string{"add_cus_dep('nlo', 'nls', 0, 'ltx_makenomenclature');", "'-s', 'nomencl.ist'"},
+			absent:   []string{"add_cus_dep('nlo', 'nls', 0, 'ltx_makeglossaries');"},
+		},
+		{
+			name:     "bib2gls",
+			indexing: latex.Indexing{Bib2gls: true},
+			contains: []string{"add_cus_dep('aux', 'glstex', 0, 'ltx_bib2gls');", "rdb_ensure_file($rule, $1)"},
+		},
+		{
+			name:     "índices nomeados com xindy",
+			indexing: latex.Indexing{Index: true, Xindy: true, Indexes: []string{"autores"}},
+			contains: []string{"$makeindex = 'texindy %O -o %D %S';", "# Índices nomeados do imakeidx (autores)", "system('texindy', '-o'"},
+		},
+		{
+			name:     "índice simples usa a regra padrão do latexmk",
+			indexing: latex.Indexing{Index: true},
+			contains: []string{"push @generated_exts, 'idx', 'ind', 'ilg';"},
+			absent:   []string{"add_cus_dep", "$makeindex"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := Latexmkrc{Indexing: tt.indexing}.Render()
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("Render() não contém %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("Render() não deveria conter %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestLatexmkrcRenderConfig(t *testing.T) {
+	rc := Latexmkrc{
+		Target:      "src/main.tex",
+		Engine:      "xelatex",
+		OutDir:      "dist",
+		AuxDir:      ".ltx/build/main",
+		TexOptions:  []string{"-synctex=1", "-shell-restricted"},
+		Env:         map[string]string{"openout_any": "p"},
+		SearchPaths: map[string]string{"TEXINPUTS": "/workspace/src//"},
+		MaxRepeat:   7,
+		Extra:       "$preview_continuous_mode = 1;\n",
+	}
+
+	expected := []string{
+		"@default_files = ('src/main.tex');",
+		"$out_dir = 'dist';",
+		"$aux_dir = '.ltx/build/main';\n$emulate_aux = 1;",
+		"$pdf_mode = 5;",
+		"set_tex_cmds('-synctex=1 -shell-restricted %O %S');",
+		"$max_repeat = 7;",
+		"ensure_path('TEXINPUTS', '/workspace/src//');",
+		"$ENV{'openout_any'} = 'p';",
+		"$bibtex_use = 0;",
+		"# LATEXMK_EXTRA\n$preview_continuous_mode = 1;\n",
+	}
+
+	out := rc.Render()
+	for _, want := range expected {
+		if !strings.Contains(out, want) {
+			t.Errorf("Render() não contém %q:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"add_cus_dep", "@generated_exts"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("Render() sem glossários nem índices não deveria conter %q", unwanted)
+		}
+	}
+
+	if strings.Contains(out, "$biber =") || strings.Contains(out, "$bibtex =") {
+		t.Errorf("Render() sem bibliografia não deveria configurar BibTeX nem biber:\n%s", out)
+	}
+
+	// Auxiliares no diretório de saída dispensam $aux_dir
+	rc.AuxDir = "dist"
+	if out := rc.Render(); strings.Contains(out, "$aux_dir") {
+		t.Errorf("Render() com AuxDir igual a OutDir não deveria definir $aux_dir:\n%s", out)
+	}
+}
+
+func TestLatexmkrcBibliography(t *testing.T) {
+	tests := []struct {
+		backend  string
+		contains []string
+		absent   []string
+	}{
+		{backend: "biber", contains: []string{"$bibtex_use = 2;", "$biber = 'biber %O %S';", "push @generated_exts, 'bcf', 'run.xml';"}, absent: []string{"$bibtex ="}},
+		{backend: "bibtex", contains: []string{"$bibtex_use = 2;", "$bibtex = 'bibtex %O %S';", "$bibtex_fudge = 1;"}, absent: []string{"$biber ="}},
+		{backend: "none", contains: []string{"$bibtex_use = 0;"}, absent: []string{"$biber =", "$bibtex ="}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.backend, func(t *testing.T) {
+			use := 2
+			if tt.backend == "none" {
+				use = 0
+			}
+			out := Latexmkrc{BibtexUse: use, BibBackend: tt.backend}.Render()
+			for _, want := range tt.contains {
+				if !strings.Contains(out, want) {
+					t.Errorf("Render() não contém %q:\n%s", want, out)
+				}
+			}
+			for _, unwanted := range tt.absent {
+				if strings.Contains(out, unwanted) {
+					t.Errorf("Render() não deveria conter %q:\n%s", unwanted, out)
+				}
+			}
+		})
+	}
+}
+
+func TestPerlString(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "dist", expected: "'dist'"},
+		{input: "d'Ávila", expected: `'d\'Ávila'`},
+		{input: `C:\tex`, expected: `'C:\\tex'`},
+		{input: "$HOME", expected: "'$HOME'"},
+	}
+
+	for _, tt := range tests {
+		if got := perlString(tt.input); got != tt.expected {
+			t.Errorf("perlString(%q) = %s, expected %s", tt.input, got, tt.expected)
+		}
+	}
+}
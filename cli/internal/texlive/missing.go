@@ -0,0 +1,69 @@
+package texlive
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// ! LaTeX Error: File `foo.sty' not found.
+	// ./src/main.tex:3: LaTeX Error: File `foo.cls' not found.
+	missingFilePattern = regexp.MustCompile("LaTeX Error: File [`']([^'`]+)' not found")
+
+	// ! Font \foo=ptmr7t at 10.0pt not loadable: Metric (TFM) file not found.
+	missingFontPattern = regexp.MustCompile(`Font \\[^=]+=([^\s:]+)(?: at [^\s]+)? not loadable: Metric \(TFM\) file not found`)
+
+	// ! I can't find file `tikzlibraryfoo.code.tex'.
+	missingInputPattern = regexp.MustCompile("I can't find file [`']([^'`]+)'")
+)
+
+// packageFileSuffixes são os arquivos de pacotes do TeX Live que uma entrada
+// ausente pode indicar. Entradas sem extensão ou .tex comuns são arquivos do
+// próprio documento (\input{capitulo}) e ficam como erro de compilação.
+var packageFileSuffixes = []string{
+	".sty", ".cls", ".clo", ".cfg", ".def", ".fd", ".ldf", ".tfm", ".vf",
+	".enc", ".map", ".pfb", ".bbx", ".cbx", ".lbx", ".dbx", ".bst", ".code.tex",
+}
+
+func isPackageFile(file string) bool {
+	for _, suffix := range packageFileSuffixes {
+		if strings.HasSuffix(file, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// MissingFiles extrai do log da compilação os arquivos (.sty, .cls, .tfm, ...)
+// que o TeX não conseguiu encontrar, sem repetições e na ordem em que aparecem
+func MissingFiles(log string) []string {
+	var files []string
+	seen := make(map[string]bool)
+
+	add := func(file string) {
+		file = strings.TrimSpace(file)
+		if file == "" || seen[file] {
+			return
+		}
+		seen[file] = true
+		files = append(files, file)
+	}
+
+	for _, line := range strings.Split(log, "\n") {
+		if m := missingFilePattern.FindStringSubmatch(line); m != nil {
+			add(m[1])
+			continue
+		}
+
+		if m := missingFontPattern.FindStringSubmatch(line); m != nil {
+			add(m[1] + ".tfm")
+			continue
+		}
+
+		if m := missingInputPattern.FindStringSubmatch(line); m != nil && isPackageFile(m[1]) {
+			add(m[1])
+		}
+	}
+
+	return files
+}
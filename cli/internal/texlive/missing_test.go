@@ -0,0 +1,70 @@
+package texlive
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMissingFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      string
+		expected []string
+	}{
+		{
+			name:     "pacote ausente",
+			log:      "! LaTeX Error: File `enumitem.sty' not found.\n\nType X to quit or <RETURN> to proceed,",
+			expected: []string{"enumitem.sty"},
+		},
+		{
+			name:     "classe ausente com -file-line-error",
+			log:      "./src/main.tex:1: LaTeX Error: File `abntex2.cls' not found.",
+			expected: []string{"abntex2.cls"},
+		},
+		{
+			name:     "fonte ausente",
+			log:      "! Font \\T1/ptm/m/n/10=ptmr8t at 10.0pt not loadable: Metric (TFM) file not found.",
+			expected: []string{"ptmr8t.tfm"},
+		},
+		{
+			name:     "arquivo de pacote ausente",
+			log:      "! I can't find file `tikzlibraryfoo.code.tex'.",
+			expected: []string{"tikzlibraryfoo.code.tex"},
+		},
+		{
+			name:     "definições de pacote ausentes",
+			log:      "! I can't find file `t1foo.fd'.",
+			expected: []string{"t1foo.fd"},
+		},
+		{
+			name:     "entrada do documento sem extensão",
+			log:      "! I can't find file `capitulo'.",
+			expected: nil,
+		},
+		{
+			name:     "entrada .tex do documento",
+			log:      "! I can't find file `capitulos/intro.tex'.",
+			expected: nil,
+		},
+		{
+			name: "repetições",
+			log: "! LaTeX Error: File `foo.sty' not found.\n" +
+				"! LaTeX Error: File `bar.sty' not found.\n" +
+				"! LaTeX Error: File `foo.sty' not found.\n",
+			expected: []string{"foo.sty", "bar.sty"},
+		},
+		{
+			name:     "log sem erros",
+			log:      "Output written on dist/main.pdf (1 page, 12345 bytes).",
+			expected: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MissingFiles(tt.log); !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MissingFiles() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
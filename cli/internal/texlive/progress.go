@@ -0,0 +1,80 @@
+package texlive
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ./src/cap1.tex:42: Undefined control sequence.
+	fileLinePattern = regexp.MustCompile(`^(\.?/?[^\s:()]+\.(?:tex|sty|cls|bbl|ltx|def)):(\d+):`)
+
+	// l.42 \foo
+	contextLinePattern = regexp.MustCompile(`^l\.(\d+)`)
+
+	// ... on input line 42.
+	inputLinePattern = regexp.MustCompile(`on input line (\d+)`)
+
+	// (./src/cap1.tex   ou   (/usr/share/texmf/tex/latex/base/article.cls
+	openFilePattern = regexp.MustCompile(`^\(((?:\.{0,2}/)?[^\s()]+\.[A-Za-z]+)`)
+)
+
+// LastPosition estima, a partir de um log do TeX (possivelmente incompleto,
+// como o de uma compilação interrompida), o arquivo que estava sendo
+// processado e a última linha mencionada dele. Retorna linha 0 quando o log
+// não cita nenhuma linha do arquivo aberto por último.
+func LastPosition(log string) (string, int) {
+	var stack []string // "" para parênteses que não abrem arquivo
+	lines := make(map[string]int)
+
+	current := func() string {
+		for i := len(stack) - 1; i >= 0; i-- {
+			if stack[i] != "" {
+				return stack[i]
+			}
+		}
+		return ""
+	}
+
+	for _, logLine := range strings.Split(log, "\n") {
+		if m := fileLinePattern.FindStringSubmatch(logLine); m != nil {
+			if n, err := strconv.Atoi(m[2]); err == nil {
+				lines[m[1]] = n
+			}
+			continue
+		}
+
+		if m := contextLinePattern.FindStringSubmatch(logLine); m != nil {
+			if file := current(); file != "" {
+				lines[file], _ = strconv.Atoi(m[1])
+			}
+			continue
+		}
+
+		if m := inputLinePattern.FindStringSubmatch(logLine); m != nil {
+			if file := current(); file != "" {
+				lines[file], _ = strconv.Atoi(m[1])
+			}
+		}
+
+		for i := 0; i < len(logLine); i++ {
+			switch logLine[i] {
+			case '(':
+				file := ""
+				if m := openFilePattern.FindStringSubmatch(logLine[i:]); m != nil {
+					file = m[1]
+					i += len(m[0]) - 1
+				}
+				stack = append(stack, file)
+			case ')':
+				if len(stack) > 0 {
+					stack = stack[:len(stack)-1]
+				}
+			}
+		}
+	}
+
+	file := current()
+	return file, lines[file]
+}
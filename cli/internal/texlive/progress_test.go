@@ -0,0 +1,58 @@
+package texlive
+
+import "testing"
+
+func TestLastPosition(t *testing.T) {
+	tests := []struct {
+		name string
+		log  string
+		file string
+		line int
+	}{
+		{
+			name: "log vazio",
+			log:  "",
+			file: "",
+			line: 0,
+		},
+		{
+			name: "arquivo aberto sem linha conhecida",
+			log:  "(./src/main.tex\nLaTeX2e <2023-11-01>\n(/usr/share/texlive/texmf-dist/tex/latex/base/article.cls\nDocument Class: article\n)\n(./src/cap1.tex",
+			file: "./src/cap1.tex",
+			line: 0,
+		},
+		{
+			name: "aviso com on input line",
+			log:  "(./src/main.tex (./src/cap1.tex\nLaTeX Warning: Reference `fig:a' on page 1 undefined on input line 17.\n",
+			file: "./src/cap1.tex",
+			line: 17,
+		},
+		{
+			name: "arquivo fechado volta ao anterior",
+			log:  "(./src/main.tex\nPackage foo Info: bar on input line 8.\n(./src/cap1.tex\nLaTeX Warning: x on input line 30.\n) [1]\nOverfull \\hbox (3.0pt too wide) in paragraph at lines 40--41\n",
+			file: "./src/main.tex",
+			line: 8,
+		},
+		{
+			name: "erro com -file-line-error",
+			log:  "(./src/main.tex (./src/fig.tex\n./src/fig.tex:12: Undefined control sequence.\nl.12 \\foo\n",
+			file: "./src/fig.tex",
+			line: 12,
+		},
+		{
+			name: "contexto l.N",
+			log:  "(./src/main.tex (./src/loop.tex\n! TeX capacity exceeded, sorry [main memory size=5000000].\nl.23 \\loop\n",
+			file: "./src/loop.tex",
+			line: 23,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, line := LastPosition(tt.log)
+			if file != tt.file || line != tt.line {
+				t.Errorf("LastPosition() = %q, %d, expected %q, %d", file, line, tt.file, tt.line)
+			}
+		})
+	}
+}
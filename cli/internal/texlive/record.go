@@ -0,0 +1,157 @@
+package texlive
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// RecordFile guarda, na raiz do projeto, os pacotes extras instalados pelo ltx.
+// Deve ser versionado para que colegas e o CI instalem os mesmos pacotes.
+const RecordFile = "ltx-packages.txt"
+
+const recordHeader = `# Pacotes do TeX Live instalados pelo ltx (tlmgr --usermode)
+# Mantenha este arquivo no controle de versão: 'ltx build' instala
+# automaticamente os pacotes listados aqui no volume latex-cache.
+`
+
+// stampFile marca, dentro da árvore de usuário, qual conjunto de pacotes já foi instalado
+const stampFile = ".ltx-packages.sha256"
+
+// LoadRecord lê os pacotes registrados. Um arquivo inexistente resulta em lista vazia.
+func LoadRecord(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := file.Close(); err != nil {
+			fmt.Printf("Erro ao fechar arquivo: %v\n", err)
+		}
+	}()
+
+	var packages []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		packages = append(packages, line)
+	}
+
+	return packages, scanner.Err()
+}
+
+// AddToRecord acrescenta pacotes ao registro, mantendo a lista ordenada e sem duplicatas.
+// Retorna os pacotes que ainda não estavam registrados.
+func AddToRecord(path string, packages []string) ([]string, error) {
+	existing, err := LoadRecord(path)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	for _, pkg := range existing {
+		seen[pkg] = true
+	}
+
+	var added []string
+	for _, pkg := range packages {
+		if !seen[pkg] {
+			seen[pkg] = true
+			added = append(added, pkg)
+		}
+	}
+
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	all := append(existing, added...)
+	sort.Strings(all)
+
+	content := recordHeader + strings.Join(all, "\n") + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		return nil, err
+	}
+
+	return added, nil
+}
+
+// InstallRecorded instala pacotes junto com os já registrados e só então os
+// acrescenta ao registro, para que ele não liste pacotes cuja instalação
+// falhou. Retorna os pacotes que ainda não estavam registrados.
+func InstallRecorded(ctx context.Context, runner Runner, path string, packages []string) ([]string, error) {
+	all, err := LoadRecord(path)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range packages {
+		if !slices.Contains(all, pkg) {
+			all = append(all, pkg)
+		}
+	}
+
+	if _, err := SyncRecord(ctx, runner, all); err != nil {
+		return nil, err
+	}
+
+	added, err := AddToRecord(path, packages)
+	if err != nil {
+		return nil, fmt.Errorf("erro ao atualizar %s: %w", path, err)
+	}
+	return added, nil
+}
+
+// recordHash identifica um conjunto de pacotes independentemente da ordem
+func recordHash(packages []string) string {
+	sorted := append([]string(nil), packages...)
+	sort.Strings(sorted)
+
+	sum := sha256.Sum256([]byte(strings.Join(sorted, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// SyncRecord garante que os pacotes registrados estejam instalados na árvore de usuário.
+// Usa um arquivo de marcação no TEXMFHOME para só chamar o tlmgr quando a lista mudar.
+// Retorna true se uma instalação foi necessária.
+func SyncRecord(ctx context.Context, runner Runner, packages []string) (bool, error) {
+	if len(packages) == 0 {
+		return false, nil
+	}
+
+	hash := recordHash(packages)
+	stamp := `"$(kpsewhich -var-value TEXMFHOME)/` + stampFile + `"`
+
+	result, err := runner.Run(ctx, []string{"sh", "-c", "cat " + stamp + " 2>/dev/null"})
+	if err != nil {
+		return false, err
+	}
+	if strings.TrimSpace(result.Stdout) == hash {
+		return false, nil
+	}
+
+	if err := Install(ctx, runner, packages); err != nil {
+		return false, err
+	}
+
+	result, err = runner.Run(ctx, []string{"sh", "-c", "echo " + hash + " > " + stamp})
+	if err != nil {
+		return true, err
+	}
+	if result.ExitCode != 0 {
+		return true, fmt.Errorf("erro ao gravar marcação de pacotes: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	return true, nil
+}
@@ -0,0 +1,124 @@
+package texlive
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RecordFile)
+
+	packages, err := LoadRecord(path)
+	if err != nil || packages != nil {
+		t.Fatalf("LoadRecord() em arquivo inexistente = %v, %v", packages, err)
+	}
+
+	added, err := AddToRecord(path, []string{"koma-script", "enumitem"})
+	if err != nil {
+		t.Fatalf("AddToRecord() error = %v", err)
+	}
+	if !reflect.DeepEqual(added, []string{"koma-script", "enumitem"}) {
+		t.Errorf("AddToRecord() added = %v", added)
+	}
+
+	added, err = AddToRecord(path, []string{"enumitem", "xcolor"})
+	if err != nil {
+		t.Fatalf("AddToRecord() error = %v", err)
+	}
+	if !reflect.DeepEqual(added, []string{"xcolor"}) {
+		t.Errorf("AddToRecord() added = %v, expected [xcolor]", added)
+	}
+
+	packages, err = LoadRecord(path)
+	if err != nil {
+		t.Fatalf("LoadRecord() error = %v", err)
+	}
+	if !reflect.DeepEqual(packages, []string{"enumitem", "koma-script", "xcolor"}) {
+		t.Errorf("LoadRecord() = %v", packages)
+	}
+}
+
+func TestRecordHashIgnoresOrder(t *testing.T) {
+	if recordHash([]string{"a", "b"}) != recordHash([]string{"b", "a"}) {
+		t.Error("recordHash() deveria ignorar a ordem dos pacotes")
+	}
+	if recordHash([]string{"a"}) == recordHash([]string{"a", "b"}) {
+		t.Error("recordHash() deveria mudar quando a lista muda")
+	}
+}
+
+func TestSyncRecordUpToDate(t *testing.T) {
+	packages := []string{"enumitem"}
+	stamp := `cat "$(kpsewhich -var-value TEXMFHOME)/` + stampFile + `" 2>/dev/null`
+
+	runner := &fakeRunner{responses: map[string]*Result{
+		"sh -c " + stamp: {Stdout: recordHash(packages) + "\n"},
+	}}
+
+	installed, err := SyncRecord(context.Background(), runner, packages)
+	if err != nil {
+		t.Fatalf("SyncRecord() error = %v", err)
+	}
+	if installed {
+		t.Error("SyncRecord() não deveria instalar quando a marcação está atualizada")
+	}
+	if len(runner.calls) != 1 {
+		t.Errorf("SyncRecord() executou %d comandos, esperado 1", len(runner.calls))
+	}
+}
+
+func TestInstallRecorded(t *testing.T) {
+	stamp := `"$(kpsewhich -var-value TEXMFHOME)/` + stampFile + `"`
+	all := []string{"enumitem", "xcolor"}
+
+	tests := []struct {
+		name      string
+		responses map[string]*Result
+		wantErr   bool
+		expected  []string
+	}{
+		{
+			name: "instalação bem-sucedida",
+			responses: map[string]*Result{
+				"sh -c " + initUserTree:                         {},
+				"tlmgr --usermode install enumitem xcolor":      {},
+				"sh -c echo " + recordHash(all) + " > " + stamp: {},
+			},
+			expected: all,
+		},
+		{
+			name: "tlmgr falha",
+			responses: map[string]*Result{
+				"sh -c " + initUserTree:                    {},
+				"tlmgr --usermode install enumitem xcolor": {ExitCode: 1, Stderr: "package xcolor not present"},
+			},
+			wantErr:  true,
+			expected: []string{"enumitem"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), RecordFile)
+			if _, err := AddToRecord(path, []string{"enumitem"}); err != nil {
+				t.Fatalf("AddToRecord() error = %v", err)
+			}
+
+			runner := &fakeRunner{responses: tt.responses}
+			_, err := InstallRecorded(context.Background(), runner, path, []string{"xcolor"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("InstallRecorded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			packages, err := LoadRecord(path)
+			if err != nil {
+				t.Fatalf("LoadRecord() error = %v", err)
+			}
+			if !reflect.DeepEqual(packages, tt.expected) {
+				t.Errorf("registro após InstallRecorded() = %v, expected %v", packages, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package texlive
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os/exec"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/docker"
+)
+
+// UserTree é a árvore TEXMFHOME dentro do volume persistente latex-cache
+const UserTree = "/home/latexuser/.texlive/texmf"
+
+// Result contém a saída de um comando TeX executado por um Runner
+type Result struct {
+	Stdout   string
+	Stderr   string
+	ExitCode int
+}
+
+// Runner executa ferramentas do TeX Live (kpsewhich, tlmgr, ...) em algum backend
+type Runner interface {
+	Run(ctx context.Context, cmd []string) (*Result, error)
+	Name() string
+}
+
+// ContainerRunner executa comandos dentro do container LaTeX
+type ContainerRunner struct {
+	Client    *docker.Client
+	Container string
+}
+
+func (r *ContainerRunner) Run(ctx context.Context, cmd []string) (*Result, error) {
+	var stdout, stderr bytes.Buffer
+
+	code, err := r.Client.Exec(ctx, r.Container, docker.ExecOptions{
+		Cmd:    cmd,
+		Env:    []string{"TEXMFHOME=" + UserTree},
+		Stdout: &stdout,
+		Stderr: &stderr,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: code}, nil
+}
+
+func (r *ContainerRunner) Name() string {
+	return "container " + r.Container
+}
+
+// EphemeralRunner executa cada comando em um container descartável, com o
+// projeto e o volume de cache montados (container_mode=ephemeral)
+type EphemeralRunner struct {
+	Client  *docker.Client
+	Options docker.RunOptions // imagem, montagens e labels; Cmd e saídas são preenchidos por Run
+}
+
+func (r *EphemeralRunner) Run(ctx context.Context, cmd []string) (*Result, error) {
+	var stdout, stderr bytes.Buffer
+
+	opts := r.Options
+	opts.Cmd = cmd
+	opts.Env = append([]string{"TEXMFHOME=" + UserTree}, opts.Env...)
+	opts.Stdout = &stdout
+	opts.Stderr = &stderr
+
+	code, err := r.Client.Run(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Stdout: stdout.String(), Stderr: stderr.String(), ExitCode: code}, nil
+}
+
+func (r *EphemeralRunner) Name() string {
+	return "container descartável (" + r.Options.Image + ")"
+}
+
+// LocalRunner executa comandos usando a instalação TeX do host
+type LocalRunner struct{}
+
+func (r *LocalRunner) Run(ctx context.Context, cmd []string) (*Result, error) {
+	var stdout, stderr bytes.Buffer
+
+	c := exec.CommandContext(ctx, cmd[0], cmd[1:]...)
+	c.Stdout = &stdout
+	c.Stderr = &stderr
+
+	err := c.Run()
+	result := &Result{Stdout: stdout.String(), Stderr: stderr.String()}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		result.ExitCode = exitErr.ExitCode()
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (r *LocalRunner) Name() string {
+	return "instalação local"
+}
+
+// LocalAvailable indica se há uma instalação TeX Live utilizável no host
+func LocalAvailable() bool {
+	_, err := exec.LookPath("kpsewhich")
+	return err == nil
+}
@@ -0,0 +1,86 @@
+package texlive
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	// LaTeX Warning: Reference `fig:x' on page 1 undefined on input line 12.
+	// Package hyperref Warning: Token not allowed in a PDF string
+	// LaTeX Font Warning: Font shape `T1/cmr/bx/sc' undefined
+	// pdfTeX warning (ext4): destination with the same identifier
+	warningPattern = regexp.MustCompile(`(?i)^(?:LaTeX|LaTeX Font|Package \S+|Class \S+|pdfTeX)\s+warning\b`)
+
+	// Output written on tmp/main.pdf (12 pages, 345678 bytes).
+	outputWrittenPattern = regexp.MustCompile(`Output written on .*\((\d+) pages?, \d+ bytes\)`)
+
+	// Run number 1 of rule 'pdflatex'
+	latexmkPassPattern = regexp.MustCompile(`Run number \d+ of rule '(?:pdf|xe|lua)?latex'`)
+
+	// Latexmk: All targets (dist/main.pdf) are up-to-date
+	latexmkUpToDatePattern = regexp.MustCompile(`All targets .* are up-to-date`)
+)
+
+// LogSummary resume o log de uma compilação
+type LogSummary struct {
+	Warnings int // avisos do LaTeX, de pacotes e classes e do pdfTeX
+	Errors   int // erros (! ... ou arquivo:linha: com -file-line-error)
+	Pages    int // páginas do PDF gerado (0 se não houve saída)
+}
+
+// SummarizeLog conta os avisos e erros do log do TeX e as páginas do PDF. O
+// latexmk sobrescreve o log a cada passada, então o resumo é o da última.
+func SummarizeLog(log string) LogSummary {
+	var summary LogSummary
+
+	for _, line := range strings.Split(log, "\n") {
+		switch {
+		case warningPattern.MatchString(line):
+			summary.Warnings++
+		case strings.HasPrefix(line, "! ==>"):
+			// "! ==> Fatal error occurred" repete o erro anterior
+		case strings.HasPrefix(line, "! "), fileLinePattern.MatchString(line):
+			summary.Errors++
+		}
+
+		if m := outputWrittenPattern.FindStringSubmatch(line); m != nil {
+			summary.Pages, _ = strconv.Atoi(m[1])
+		}
+	}
+
+	return summary
+}
+
+// PassCounter conta, na saída do latexmk, quantas vezes a engine foi
+// executada e se o latexmk concluiu que não havia nada a compilar. O latexmk
+// escreve essas mensagens no stderr. Implementa io.Writer para acompanhar um
+// fluxo durante a compilação, sem guardá-lo inteiro; use um contador por fluxo,
+// já que as linhas de stdout e stderr chegam intercaladas.
+type PassCounter struct {
+	Passes   int
+	UpToDate bool // "All targets (...) are up-to-date"
+	partial  []byte
+}
+
+func (c *PassCounter) Write(p []byte) (int, error) {
+	data := append(c.partial, p...)
+	for {
+		i := bytes.IndexByte(data, '\n')
+		if i < 0 {
+			break
+		}
+		line := data[:i]
+		switch {
+		case latexmkPassPattern.Match(line):
+			c.Passes++
+		case latexmkUpToDatePattern.Match(line):
+			c.UpToDate = true
+		}
+		data = data[i+1:]
+	}
+	c.partial = append(c.partial[:0], data...)
+	return len(p), nil
+}
@@ -0,0 +1,75 @@
+package texlive
+
+import (
+	"testing"
+)
+
+func TestSummarizeLog(t *testing.T) {
+	tests := []struct {
+		name     string
+		log      string
+		expected LogSummary
+	}{
+		{
+			name: "avisos e páginas",
+			log: "LaTeX Warning: Reference `fig:x' on page 1 undefined on input line 12.\n" +
+				"Package hyperref Warning: Token not allowed in a PDF string (Unicode):\n" +
+				"LaTeX Font Warning: Font shape `T1/cmr/bx/sc' undefined\n" +
+				"pdfTeX warning (ext4): destination with the same identifier\n" +
+				"Overfull \\hbox (2.3pt too wide) in paragraph at lines 3--4\n" +
+				"Output written on tmp/main.pdf (12 pages, 345678 bytes).",
+			expected: LogSummary{Warnings: 4, Pages: 12},
+		},
+		{
+			name: "erros com e sem -file-line-error",
+			log: "./src/cap1.tex:42: Undefined control sequence.\n" +
+				"! LaTeX Error: Environment foo undefined.\n" +
+				"! ==> Fatal error occurred, no output PDF file produced!",
+			expected: LogSummary{Errors: 2},
+		},
+		{
+			name:     "uma página",
+			log:      "Output written on main.pdf (1 page, 1024 bytes).",
+			expected: LogSummary{Pages: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SummarizeLog(tt.log); got != tt.expected {
+				t.Errorf("SummarizeLog() = %+v, expected %+v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPassCounter(t *testing.T) {
+	var c PassCounter
+	// Linhas divididas entre escritas, como na saída do container
+	for _, chunk := range []string{
+		"Latexmk: applying rule 'pdflatex'...\nRun number 1 of rule 'pdf",
+		"latex'\nRun number 1 of rule 'biber'\n",
+		"Run number 2 of rule 'pdflatex'\nRun number 1 of rule 'xelatex'",
+	} {
+		if _, err := c.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A última linha ainda não terminou
+	if c.Passes != 2 {
+		t.Errorf("Passes = %d, expected 2", c.Passes)
+	}
+	_, _ = c.Write([]byte("\n"))
+	if c.Passes != 3 {
+		t.Errorf("Passes = %d, expected 3", c.Passes)
+	}
+	if c.UpToDate {
+		t.Error("UpToDate = true, expected false")
+	}
+
+	_, _ = c.Write([]byte("Latexmk: All targets (dist/main.pdf) are up-to-date\n"))
+	if !c.UpToDate {
+		t.Error("UpToDate = false, expected true")
+	}
+}
@@ -0,0 +1,102 @@
+package texlive
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+// 120+5+3 (2/1/0/0) Section: Introdução
+var subcountPattern = regexp.MustCompile(`^\s*(\d+)\+(\d+)\+(\d+) \(\d+/\d+/\d+/\d+\) (.*)$`)
+
+// Texcount conta as palavras de um documento com o texcount, por arquivo
+// (seguindo \input e \include) e por seção
+func Texcount(ctx context.Context, runner Runner, target string) ([]latex.FileWords, error) {
+	result, err := runner.Run(ctx, []string{"texcount", "-inc", "-sub=section", "-utf8", "-nocol", target})
+	if err != nil {
+		return nil, fmt.Errorf("erro ao executar texcount: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return nil, fmt.Errorf("texcount falhou (código %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	files := parseTexcountOutput(result.Stdout)
+	if len(files) == 0 {
+		return nil, fmt.Errorf("saída do texcount não reconhecida")
+	}
+	return files, nil
+}
+
+// parseTexcountOutput interpreta a saída de 'texcount -inc -sub=section', com
+// um bloco por arquivo seguido do total:
+//
+//	File: src/main.tex
+//	Words in text: 100
+//	Words in headers: 5
+//	Words outside text (captions, etc.): 15
+//	...
+//	Subcounts:
+//	  text+headers+captions (#headers/#floats/#inlines/#displayed)
+//	  10+0+0 (0/0/0/0) _top_
+//	  90+5+15 (2/1/3/1) Section: Introdução
+//
+//	Included file: src/chapters/cap1.tex
+//	...
+//	File(s) total: src/main.tex
+func parseTexcountOutput(output string) []latex.FileWords {
+	var files []latex.FileWords
+	var current *latex.FileWords
+
+	for _, line := range strings.Split(output, "\n") {
+		line = strings.TrimRight(line, "\r")
+
+		switch {
+		case strings.HasPrefix(line, "File(s) total") || strings.HasPrefix(line, "Total"):
+			current = nil
+
+		case strings.HasPrefix(line, "File: ") || strings.HasPrefix(line, "Included file: "):
+			path := strings.TrimSpace(line[strings.Index(line, ":")+1:])
+			files = append(files, latex.FileWords{Path: path})
+			current = &files[len(files)-1]
+
+		case current == nil:
+
+		case strings.HasPrefix(line, "Words in text:"):
+			current.Text = countAfterColon(line)
+
+		case strings.HasPrefix(line, "Words in headers:"):
+			current.Headers = countAfterColon(line)
+
+		case strings.HasPrefix(line, "Words outside text"):
+			current.Captions = countAfterColon(line)
+
+		default:
+			m := subcountPattern.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+
+			section := latex.SectionWords{}
+			section.Text, _ = strconv.Atoi(m[1])
+			section.Headers, _ = strconv.Atoi(m[2])
+			section.Captions, _ = strconv.Atoi(m[3])
+			if kind, title, ok := strings.Cut(m[4], ": "); ok {
+				section.Level = strings.ToLower(kind)
+				section.Title = strings.TrimSpace(title)
+			}
+			current.Sections = append(current.Sections, section)
+		}
+	}
+
+	return files
+}
+
+func countAfterColon(line string) int {
+	_, value, _ := strings.Cut(line, ":")
+	n, _ := strconv.Atoi(strings.TrimSpace(value))
+	return n
+}
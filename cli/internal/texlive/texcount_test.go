@@ -0,0 +1,61 @@
+package texlive
+
+import (
+	"testing"
+
+	"github.com/martinsmiguel/latex-docker-env/cli/internal/latex"
+)
+
+func TestParseTexcountOutput(t *testing.T) {
+	output := `File: src/main.tex
+Encoding: utf8
+Words in text: 100
+Words in headers: 5
+Words outside text (captions, etc.): 15
+Number of headers: 2
+Number of floats/tables/figures: 1
+Number of math inlines: 3
+Number of math displayed: 1
+Subcounts:
+  text+headers+captions (#headers/#floats/#inlines/#displayed)
+  10+0+0 (0/0/0/0) _top_
+  90+5+15 (2/1/3/1) Section: Introdução: contexto
+
+Included file: src/chapters/cap1.tex
+Words in text: 40
+Words in headers: 1
+Words outside text (captions, etc.): 0
+Subcounts:
+  text+headers+captions (#headers/#floats/#inlines/#displayed)
+  40+1+0 (1/0/0/0) Chapter: Um
+
+File(s) total: src/main.tex
+Words in text: 140
+Words in headers: 6
+Words outside text (captions, etc.): 15
+`
+
+	files := parseTexcountOutput(output)
+	if len(files) != 2 {
+		t.Fatalf("parseTexcountOutput() = %d arquivos, expected 2", len(files))
+	}
+
+	main := files[0]
+	if main.Path != "src/main.tex" || main.WordCount != (latex.WordCount{Text: 100, Headers: 5, Captions: 15}) {
+		t.Errorf("main = %+v", main)
+	}
+	if len(main.Sections) != 2 {
+		t.Fatalf("main.Sections = %+v, expected 2", main.Sections)
+	}
+	if s := main.Sections[1]; s.Level != "section" || s.Title != "Introdução: contexto" || s.Text != 90 || s.Captions != 15 {
+		t.Errorf("section = %+v", s)
+	}
+	if main.Sections[0].Level != "" || main.Sections[0].Text != 10 {
+		t.Errorf("_top_ = %+v", main.Sections[0])
+	}
+
+	cap1 := files[1]
+	if cap1.Path != "src/chapters/cap1.tex" || cap1.Text != 40 || len(cap1.Sections) != 1 || cap1.Sections[0].Level != "chapter" {
+		t.Errorf("cap1 = %+v", cap1)
+	}
+}
@@ -0,0 +1,101 @@
+package texlive
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+// initUserTree cria o banco de dados do modo usuário do tlmgr em TEXMFHOME, se ainda não existir
+const initUserTree = `[ -f "$(kpsewhich -var-value TEXMFHOME)/tlpkg/texlive.tlpdb" ] || tlmgr init-usertree`
+
+// SearchFile descobre qual pacote do TeX Live fornece um arquivo (ex: enumitem.sty)
+func SearchFile(ctx context.Context, runner Runner, file string) (string, error) {
+	result, err := runner.Run(ctx, []string{"tlmgr", "search", "--global", "--file", "/" + file})
+	if err != nil {
+		return "", fmt.Errorf("erro ao executar tlmgr search: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return "", fmt.Errorf("tlmgr search falhou (código %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	pkg := parseSearchOutput(file, result.Stdout)
+	if pkg == "" {
+		return "", fmt.Errorf("nenhum pacote do TeX Live fornece %s", file)
+	}
+
+	return pkg, nil
+}
+
+// parseSearchOutput interpreta a saída de 'tlmgr search --file', no formato:
+//
+//	pacote:
+//	    texmf-dist/tex/latex/pacote/arquivo.sty
+func parseSearchOutput(file, output string) string {
+	current := ""
+	for _, line := range strings.Split(output, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "tlmgr:") {
+			continue
+		}
+
+		if line[0] != ' ' && line[0] != '\t' && strings.HasSuffix(line, ":") {
+			current = strings.TrimSuffix(line, ":")
+			continue
+		}
+
+		if current != "" && path.Base(strings.TrimSpace(line)) == file {
+			return current
+		}
+	}
+
+	return ""
+}
+
+// ResolvePackages mapeia arquivos ausentes para os pacotes do TeX Live que os fornecem.
+// Arquivos sem pacote correspondente são retornados em unresolved.
+func ResolvePackages(ctx context.Context, runner Runner, files []string) (packages []string, unresolved []string) {
+	seen := make(map[string]bool)
+	for _, file := range files {
+		pkg, err := SearchFile(ctx, runner, file)
+		if err != nil {
+			unresolved = append(unresolved, file)
+			continue
+		}
+		if !seen[pkg] {
+			seen[pkg] = true
+			packages = append(packages, pkg)
+		}
+	}
+
+	sort.Strings(packages)
+	return packages, unresolved
+}
+
+// Install instala pacotes na árvore de usuário (TEXMFHOME), que no container
+// fica no volume persistente latex-cache
+func Install(ctx context.Context, runner Runner, packages []string) error {
+	if len(packages) == 0 {
+		return nil
+	}
+
+	result, err := runner.Run(ctx, []string{"sh", "-c", initUserTree})
+	if err != nil {
+		return fmt.Errorf("erro ao preparar árvore de usuário do TeX Live: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("tlmgr init-usertree falhou: %s", strings.TrimSpace(result.Stderr))
+	}
+
+	cmd := append([]string{"tlmgr", "--usermode", "install"}, packages...)
+	result, err = runner.Run(ctx, cmd)
+	if err != nil {
+		return fmt.Errorf("erro ao executar tlmgr install: %w", err)
+	}
+	if result.ExitCode != 0 {
+		return fmt.Errorf("tlmgr install falhou (código %d): %s", result.ExitCode, strings.TrimSpace(result.Stderr))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package texlive
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestParseSearchOutput(t *testing.T) {
+	output := `tlmgr: package repository https://mirror.ctan.org/systems/texlive/tlnet (verified)
+enumitem:
+	texmf-dist/tex/latex/enumitem/enumitem.sty
+koma-script:
+	texmf-dist/tex/latex/koma-script/scrartcl.cls
+	texmf-dist/tex/latex/koma-script/myenumitem.sty
+`
+
+	tests := []struct {
+		file     string
+		expected string
+	}{
+		{"enumitem.sty", "enumitem"},
+		{"scrartcl.cls", "koma-script"},
+		{"inexistente.sty", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			if got := parseSearchOutput(tt.file, output); got != tt.expected {
+				t.Errorf("parseSearchOutput(%q) = %q, expected %q", tt.file, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestResolvePackages(t *testing.T) {
+	runner := &fakeRunner{responses: map[string]*Result{
+		"tlmgr search --global --file /enumitem.sty": {Stdout: "enumitem:\n\ttexmf-dist/tex/latex/enumitem/enumitem.sty\n"},
+		"tlmgr search --global --file /scrartcl.cls": {Stdout: "koma-script:\n\ttexmf-dist/tex/latex/koma-script/scrartcl.cls\n"},
+		"tlmgr search --global --file /scrbook.cls":  {Stdout: "koma-script:\n\ttexmf-dist/tex/latex/koma-script/scrbook.cls\n"},
+	}}
+
+	packages, unresolved := ResolvePackages(context.Background(), runner,
+		[]string{"scrartcl.cls", "enumitem.sty", "scrbook.cls", "naoexiste.sty"})
+
+	if !reflect.DeepEqual(packages, []string{"enumitem", "koma-script"}) {
+		t.Errorf("ResolvePackages() packages = %v", packages)
+	}
+	if !reflect.DeepEqual(unresolved, []string{"naoexiste.sty"}) {
+		t.Errorf("ResolvePackages() unresolved = %v", unresolved)
+	}
+}
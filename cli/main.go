@@ -4,6 +4,13 @@ import (
 	"github.com/martinsmiguel/latex-docker-env/cli/cmd"
 )
 
+// Definidos na compilação via -ldflags (veja o Makefile)
+var (
+	version   = "2.0.0"
+	buildTime = ""
+)
+
 func main() {
+	cmd.SetVersion(version, buildTime)
 	cmd.Execute()
 }
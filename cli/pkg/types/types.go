@@ -4,12 +4,34 @@ import "time"
 
 // Config representa a configuração da CLI
 type Config struct {
-	LatexEngine   string `mapstructure:"latex_engine"`
-	OutputDir     string `mapstructure:"output_dir"`
-	SourceDir     string `mapstructure:"source_dir"`
-	ContainerName string `mapstructure:"container_name"`
-	ImageName     string `mapstructure:"image_name"`
-	WatchDebounce string `mapstructure:"watch_debounce"`
+	LatexEngine         string   `mapstructure:"latex_engine"`
+	OutputDir           string   `mapstructure:"output_dir"`
+	AuxDir              string   `mapstructure:"aux_dir"`
+	SynctexInOutput     bool     `mapstructure:"synctex_in_output"`
+	SourceDir           string   `mapstructure:"source_dir"`
+	ContainerName       string   `mapstructure:"container_name"`
+	ImageName           string   `mapstructure:"image_name"`
+	WatchDebounce       string   `mapstructure:"watch_debounce"`
+	StartTimeout        string   `mapstructure:"start_timeout"`
+	ContainerMode       string   `mapstructure:"container_mode"`
+	BuildTimeout        string   `mapstructure:"build_timeout"`
+	CPULimit            string   `mapstructure:"cpu_limit"`
+	MemoryLimit         string   `mapstructure:"memory_limit"`
+	PidsLimit           string   `mapstructure:"pids_limit"`
+	ShellEscape         string   `mapstructure:"shell_escape"`
+	ShellEscapeCommands []string `mapstructure:"shell_escape_commands"`
+	ShellEscapeTargets  []string `mapstructure:"shell_escape_targets"`
+	BibliographyBackend string   `mapstructure:"bibliography_backend"`
+	WordLimit           string   `mapstructure:"word_limit"`
+	WordLimitCounts     []string `mapstructure:"word_limit_counts"`
+	LintDisable         []string `mapstructure:"lint_disable"`
+	LintChktex          bool     `mapstructure:"lint_chktex"`
+	BibKeyPattern       string   `mapstructure:"bib_key_pattern"`
+	LatexmkMaxRepeat    string   `mapstructure:"latexmk_max_repeat"`
+	LatexmkExtra        string   `mapstructure:"latexmk_extra"`
+	TexPackages         []string `mapstructure:"tex_packages"`
+	AptPackages         []string `mapstructure:"apt_packages"`
+	PipPackages         []string `mapstructure:"pip_packages"`
 }
 
 // ProjectInfo contém informações do projeto LaTeX